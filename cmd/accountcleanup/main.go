@@ -49,7 +49,8 @@ func main() {
 	}
 
 	// create storage connection
-	cipher := storage.NewEncrypter(cfg.Database.SecretKey)
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
 	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher, logs.WithField("service", "storage"))
 	fatalOnError(err)
 
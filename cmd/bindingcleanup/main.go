@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/schema-migrator/cleaner"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	log "github.com/sirupsen/logrus"
+	"github.com/vrischmann/envconfig"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const revokeTimeout = time.Minute
+
+type BindingsRevoker interface {
+	Delete(ctx context.Context, instance *internal.Instance, bindingID string) error
+}
+
+type Config struct {
+	Database storage.Config
+	DryRun   bool `envconfig:"default=true"`
+}
+
+type BindingCleanupService struct {
+	cfg              Config
+	instancesStorage storage.Instances
+	bindingsStorage  storage.Bindings
+	revoker          BindingsRevoker
+}
+
+func main() {
+	log.SetFormatter(&log.JSONFormatter{})
+	log.Info("Starting binding cleanup job")
+
+	// create and fill config
+	var cfg Config
+	err := envconfig.InitWithPrefix(&cfg, "APP")
+	fatalOnError(err)
+
+	if cfg.DryRun {
+		log.Info("Dry run only - no changes")
+	}
+
+	// create storage connection
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
+	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher, log.WithField("service", "storage"))
+	fatalOnError(err)
+
+	kcpK8sConfig, err := config.GetConfig()
+	fatalOnError(err)
+	kcpK8sClient, err := client.New(kcpK8sConfig, client.Options{})
+	fatalOnError(err)
+	// the job only revokes the bindings, the kubeconfig builder and the cluster role are used when bindings are created
+	revoker := broker.NewServiceAccountBindingsManager(kubeconfig.NewK8sClientFromSecretProvider(kcpK8sClient), nil, "")
+
+	svc := newBindingCleanupService(cfg, db.Instances(), db.Bindings(), revoker)
+	err = svc.PerformCleanup()
+	fatalOnError(err)
+
+	log.Info("Binding cleanup job finished successfully!")
+
+	err = conn.Close()
+	if err != nil {
+		fatalOnError(err)
+	}
+
+	cleaner.HaltIstioSidecar()
+	// do not use defer, close must be done before halting
+	err = cleaner.Halt()
+	fatalOnError(err)
+}
+
+func newBindingCleanupService(cfg Config, instances storage.Instances, bindings storage.Bindings, revoker BindingsRevoker) *BindingCleanupService {
+	return &BindingCleanupService{
+		cfg:              cfg,
+		instancesStorage: instances,
+		bindingsStorage:  bindings,
+		revoker:          revoker,
+	}
+}
+
+func (s *BindingCleanupService) PerformCleanup() error {
+	now := time.Now()
+	bindings, err := s.bindingsStorage.ListExpired(now)
+	if err != nil {
+		log.Error(fmt.Sprintf("while getting expired bindings: %s", err))
+		return err
+	}
+
+	if s.cfg.DryRun {
+		for _, binding := range bindings {
+			log.Infof("instanceId: %s bindingId: %s expiresAt: %s", binding.InstanceID, binding.ID, binding.ExpiresAt)
+		}
+		log.Infof("Bindings to expire: %d", len(bindings))
+		return nil
+	}
+
+	failures := 0
+	for _, binding := range bindings {
+		err := s.expireBinding(binding, now)
+		if err != nil {
+			// ignoring errors - only logging, the binding is expired in the next run
+			log.Error(fmt.Sprintf("while expiring binding %s of the instance %s: %s", binding.ID, binding.InstanceID, err))
+			failures++
+		}
+	}
+	log.Infof("Bindings to expire: %d, expired: %d, failures: %d", len(bindings), len(bindings)-failures, failures)
+	return nil
+}
+
+func (s *BindingCleanupService) expireBinding(binding internal.Binding, now time.Time) error {
+	log.Infof("About to expire binding %s of the instance %s", binding.ID, binding.InstanceID)
+	instance, err := s.instancesStorage.GetByID(binding.InstanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		// the runtime is already gone together with the issued credentials
+		log.Infof("Instance %s not found, marking binding %s as expired", binding.InstanceID, binding.ID)
+	case err != nil:
+		return fmt.Errorf("while getting instance: %w", err)
+	default:
+		ctx, cancel := context.WithTimeout(context.Background(), revokeTimeout)
+		defer cancel()
+		if err := s.revoker.Delete(ctx, instance, binding.ID); err != nil {
+			return fmt.Errorf("while revoking binding: %w", err)
+		}
+	}
+
+	binding.ExpiredAt = &now
+	binding.UpdatedAt = now
+	binding.Description = "Binding expired"
+	if err := s.bindingsStorage.Update(&binding); err != nil {
+		return fmt.Errorf("while updating binding: %w", err)
+	}
+	return nil
+}
+
+func fatalOnError(err error) {
+	if err != nil {
+		// temporarily we exit with 0 to avoid any side effects - we ignore all errors only logging those
+		log.Error(err)
+		os.Exit(0)
+	}
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func TestBinding(t *testing.T) {
@@ -34,14 +40,63 @@ func TestBinding(t *testing.T) {
 	suite.processProvisioningByOperationID(opID)
 	suite.WaitForOperationState(opID, domain.Succeeded)
 
+	// when
+	resp = suite.CallAPI("PUT", fmt.Sprintf("oauth/v2/service_instances/%s/service_bindings/%s", iid, bid),
+		`{
+                "service_id": "47c9dcbf-ff30-448e-ab36-d3bad66ba281",
+                "plan_id": "361c511f-f939-4621-b228-d0fb79a1fe15",
+                "parameters": {
+                    "expiration_seconds": 3600
+                }
+               }`)
+
+	// then
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	binding := suite.decodeBinding(resp)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), binding.Credentials.ExpiresAt, time.Minute)
+	config, err := clientcmd.Load([]byte(binding.Credentials.Kubeconfig))
+	require.NoError(t, err)
+	for _, authInfo := range config.AuthInfos {
+		assert.NotEmpty(t, authInfo.Token)
+	}
+
+	// when
 	resp = suite.CallAPI("PUT", fmt.Sprintf("oauth/v2/service_instances/%s/service_bindings/%s", iid, bid),
 		`{
                 "service_id": "47c9dcbf-ff30-448e-ab36-d3bad66ba281",
                 "plan_id": "361c511f-f939-4621-b228-d0fb79a1fe15"
                }`)
 
-	b, _ := io.ReadAll(resp.Body)
-	suite.Log(string(b))
-	suite.Log(resp.Status)
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// when
+	resp = suite.CallAPI("GET", fmt.Sprintf("oauth/v2/service_instances/%s/service_bindings/%s", iid, bid), "")
+
+	// then
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, binding.Credentials, suite.decodeBinding(resp).Credentials)
+
+	// when
+	resp = suite.CallAPI("DELETE", fmt.Sprintf("oauth/v2/service_instances/%s/service_bindings/%s?service_id=47c9dcbf-ff30-448e-ab36-d3bad66ba281&plan_id=361c511f-f939-4621-b228-d0fb79a1fe15", iid, bid), "")
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = suite.CallAPI("GET", fmt.Sprintf("oauth/v2/service_instances/%s/service_bindings/%s", iid, bid), "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+type bindingResponse struct {
+	Credentials struct {
+		Kubeconfig string    `json:"kubeconfig"`
+		ExpiresAt  time.Time `json:"expires_at"`
+	} `json:"credentials"`
+}
 
+func (s *BrokerSuiteTest) decodeBinding(resp *http.Response) bindingResponse {
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(s.t, err)
+	var binding bindingResponse
+	require.NoError(s.t, json.Unmarshal(b, &binding))
+	return binding
 }
@@ -19,6 +19,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/dlmiddlecote/sqlstats"
+	"github.com/google/uuid"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/kyma-environment-broker/common/director"
@@ -31,13 +32,16 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	kebConfig "github.com/kyma-project/kyma-environment-broker/internal/config"
 	"github.com/kyma-project/kyma-environment-broker/internal/dashboard"
+	"github.com/kyma-project/kyma-environment-broker/internal/drift"
 	"github.com/kyma-project/kyma-environment-broker/internal/edp"
 	"github.com/kyma-project/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/kyma-environment-broker/internal/events"
 	eventshandler "github.com/kyma-project/kyma-environment-broker/internal/events/handler"
 	"github.com/kyma-project/kyma-environment-broker/internal/health"
+	"github.com/kyma-project/kyma-environment-broker/internal/hibernation"
 	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/kyma-environment-broker/internal/ias"
+	"github.com/kyma-project/kyma-environment-broker/internal/intervention"
 	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
 	"github.com/kyma-project/kyma-environment-broker/internal/metrics"
 	"github.com/kyma-project/kyma-environment-broker/internal/middleware"
@@ -55,9 +59,11 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/runtimeoverrides"
 	"github.com/kyma-project/kyma-environment-broker/internal/runtimeversion"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/kyma-environment-broker/internal/suspension"
 	"github.com/kyma-project/kyma-environment-broker/internal/swagger"
+	"github.com/kyma-project/kyma-environment-broker/internal/trace"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
@@ -112,6 +118,12 @@ type Config struct {
 	Gardener    gardener.Config
 	Kubeconfig  kubeconfig.Config
 
+	HyperscalerAccountPool hyperscaler.PoolConfig
+	AccountPoolMetrics     metrics.AccountPoolConfig
+	DriftDetection         drift.Config
+	Hibernation            hibernation.Config
+	Suspension             suspension.Config
+
 	KymaVersion                                                         string
 	EnableOnDemandVersion                                               bool `envconfig:"default=false"`
 	ManagedRuntimeComponentsYAMLFilePath                                string
@@ -168,6 +180,9 @@ type Config struct {
 	Provisioning   process.StagedManagerConfiguration
 	Deprovisioning process.StagedManagerConfiguration
 	Update         process.StagedManagerConfiguration
+
+	// OperationLeases allows to run many KEB replicas, an operation is processed by the replica holding its lease
+	OperationLeases process.LeaseConfiguration
 }
 
 type ProfilerConfig struct {
@@ -263,7 +278,8 @@ func main() {
 	skrK8sClientProvider := kubeconfig.NewK8sClientFromSecretProvider(cli)
 
 	// create storage
-	cipher := storage.NewEncrypter(cfg.Database.SecretKey)
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
 	var db storage.BrokerStorage
 	if cfg.DbInMemory {
 		db = storage.NewMemoryStorage()
@@ -308,9 +324,10 @@ func main() {
 	fatalOnError(err)
 
 	gardenerNamespace := fmt.Sprintf("garden-%v", cfg.Gardener.Project)
-	gardenerAccountPool := hyperscaler.NewAccountPool(dynamicGardener, gardenerNamespace)
-	gardenerSharedPool := hyperscaler.NewSharedGardenerAccountPool(dynamicGardener, gardenerNamespace)
-	accountProvider := hyperscaler.NewAccountProvider(gardenerAccountPool, gardenerSharedPool)
+	accountPoolRegistry, err := hyperscaler.NewConfiguredPoolRegistry(cfg.HyperscalerAccountPool, dynamicGardener, gardenerNamespace, db.HyperscalerAccounts(), db.Instances())
+	fatalOnError(err)
+	logs.Infof("Hyperscaler account pool backends: default=%s, per hyperscaler type=%v", cfg.HyperscalerAccountPool.Backend, cfg.HyperscalerAccountPool.Backends)
+	accountProvider := hyperscaler.NewAccountProvider(accountPoolRegistry, accountPoolRegistry)
 
 	regions, err := provider.ReadPlatformRegionMappingFromFile(cfg.TrialRegionMappingFilePath)
 	fatalOnError(err)
@@ -351,7 +368,15 @@ func main() {
 
 	// metrics collectors
 	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances())
+	eventBroker.Subscribe(process.OperationStepProcessed{}, trace.NewCollector(db.OperationTraces(), logs).OnOperationStepProcessed)
 	metrics.StartOpsMetricService(ctx, db.Operations(), logs)
+	var accountPoolCollector *metrics.AccountPoolCollector
+	if cfg.AccountPoolMetrics.Enabled {
+		accountPoolCollector, err = metrics.NewAccountPoolCollector(accountPoolRegistry, cfg.AccountPoolMetrics, logs)
+		fatalOnError(err)
+		prometheus.MustRegister(accountPoolCollector)
+		accountPoolCollector.Start(ctx)
+	}
 	// setup runtime overrides appender
 	runtimeOverrides := runtimeoverrides.NewRuntimeOverrides(ctx, cli)
 
@@ -371,8 +396,39 @@ func main() {
 		skrK8sClientProvider, cli, configProvider, logs)
 
 	updateManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, cfg.Update, logs.WithField("update", "manager"))
+	leaseOwner := leaseOwnerID()
+	if cfg.OperationLeases.Enabled {
+		logs.Infof("Operation leases enabled %s, lease owner: %s", cfg.OperationLeases, leaseOwner)
+		for _, manager := range []*process.StagedManager{provisionManager, deprovisionManager, updateManager} {
+			manager.UseLeases(db.OperationLeases(), leaseOwner, cfg.OperationLeases)
+		}
+	}
 	updateQueue := NewUpdateProcessingQueue(ctx, updateManager, cfg.Update.WorkersAmount, db, inputFactory, provisionerClient, eventBroker,
-		runtimeVerConfigurator, db.RuntimeStates(), componentsProvider, reconcilerClient, cfg, skrK8sClientProvider, cli, logs)
+		runtimeVerConfigurator, db.RuntimeStates(), componentsProvider, reconcilerClient, cfg, skrK8sClientProvider, cli, configProvider, logs)
+
+	if cfg.DriftDetection.Enabled {
+		detector := drift.NewDetector(db, dynamicGardener, gardenerNamespace, updateQueue, cfg.DriftDetection, logs)
+		if cfg.OperationLeases.Enabled {
+			detector.UseLease(process.NewJobLease(db.OperationLeases(), "drift-detection", leaseOwner, cfg.DriftDetection.Interval))
+		}
+		detector.Start(ctx)
+	}
+	if cfg.Hibernation.Enabled {
+		scheduler := hibernation.NewScheduler(db, dynamicGardener, gardenerNamespace, cfg.Hibernation, logs)
+		if cfg.OperationLeases.Enabled {
+			scheduler.UseLease(process.NewJobLease(db.OperationLeases(), "hibernation", leaseOwner, cfg.Hibernation.Interval))
+		}
+		scheduler.Start(ctx)
+	}
+	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue,
+		hibernation.NewShootHibernator(dynamicGardener, gardenerNamespace), cfg.Suspension, logs)
+	if cfg.Suspension.Retrier.Enabled {
+		retrier := suspension.NewRetrier(db, suspensionCtxHandler, cfg.Suspension.Retrier, logs)
+		if cfg.OperationLeases.Enabled {
+			retrier.UseLease(process.NewJobLease(db.OperationLeases(), "unsuspension-retrier", leaseOwner, cfg.Suspension.Retrier.Interval))
+		}
+		retrier.Start(ctx)
+	}
 	/***/
 	servicesConfig, err := broker.NewServicesConfigFromFile(cfg.CatalogFilePath)
 	fatalOnError(err)
@@ -380,32 +436,38 @@ func main() {
 	// create server
 	router := mux.NewRouter()
 
-	createAPI(router, servicesConfig, inputFactory, &cfg, db, provisionQueue, deprovisionQueue, updateQueue, logger, logs, inputFactory.GetPlanDefaults)
+	kcBuilder := kubeconfig.NewBuilder(provisionerClient, skrK8sClientProvider)
+	kcTokenIssuer := kubeconfig.NewServiceAccountTokenIssuer(skrK8sClientProvider, kcBuilder, cfg.Kubeconfig.Token.Expiration)
+	bindingsManager := broker.NewServiceAccountBindingsManager(skrK8sClientProvider, kcBuilder, cfg.Broker.Binding.ClusterRole)
+	createAPI(router, servicesConfig, inputFactory, &cfg, db, provisionQueue, deprovisionQueue, updateQueue, bindingsManager, logger, logs, inputFactory.GetPlanDefaults, suspensionCtxHandler, accountPoolRegistry)
 
 	// create metrics endpoint
 	router.Handle("/metrics", promhttp.Handler())
 
 	// create SKR kubeconfig endpoint
-	kcBuilder := kubeconfig.NewBuilder(provisionerClient, skrK8sClientProvider)
-	kcHandler := kubeconfig.NewHandler(db, kcBuilder, cfg.Kubeconfig.AllowOrigins, logs.WithField("service", "kubeconfigHandle"))
+	kcHandler := kubeconfig.NewHandler(db, kcBuilder, kcTokenIssuer, cfg.Kubeconfig, logs.WithField("service", "kubeconfigHandle"))
 	kcHandler.AttachRoutes(router)
 
-	runtimeLister := orchestration.NewRuntimeLister(db.Instances(), db.Operations(), runtime.NewConverter(cfg.DefaultRequestRegion), logs)
+	runtimeLister := orchestration.NewRuntimeLister(db.Instances(), db.Operations(), db.RuntimeStates(), runtime.NewConverter(cfg.DefaultRequestRegion), logs)
 	runtimeResolver := orchestrationExt.NewGardenerRuntimeResolver(dynamicGardener, gardenerNamespace, runtimeLister, logs)
 
-	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, runtimeResolver, upgradeEvalManager, &cfg, internalEvalAssistant, reconcilerClient, notificationBuilder, skrK8sClientProvider, logs, cli, 1)
-	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, provisionerClient, eventBroker, inputFactory,
+	kymaQueue, upgradeKymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, runtimeResolver, upgradeEvalManager, &cfg, internalEvalAssistant, reconcilerClient, notificationBuilder, skrK8sClientProvider, logs, cli, 1)
+	clusterQueue, upgradeClusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, provisionerClient, eventBroker, inputFactory,
 		nil, time.Minute, runtimeResolver, upgradeEvalManager, notificationBuilder, logs, cli, cfg, 1)
 
 	// TODO: in case of cluster upgrade the same Azure Zones must be send to the Provisioner
 	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, clusterQueue, cfg.MaxPaginationPage, logs)
 
 	if !cfg.DisableProcessOperationsInProgress {
-		err = processOperationsInProgressByType(internal.OperationTypeProvision, db.Operations(), provisionQueue, logs)
+		var leases storage.OperationLeases
+		if cfg.OperationLeases.Enabled {
+			leases = db.OperationLeases()
+		}
+		err = processOperationsInProgressByType(internal.OperationTypeProvision, db.Operations(), leases, leaseOwner, provisionQueue, logs)
 		fatalOnError(err)
-		err = processOperationsInProgressByType(internal.OperationTypeDeprovision, db.Operations(), deprovisionQueue, logs)
+		err = processOperationsInProgressByType(internal.OperationTypeDeprovision, db.Operations(), leases, leaseOwner, deprovisionQueue, logs)
 		fatalOnError(err)
-		err = processOperationsInProgressByType(internal.OperationTypeUpdate, db.Operations(), updateQueue, logs)
+		err = processOperationsInProgressByType(internal.OperationTypeUpdate, db.Operations(), leases, leaseOwner, updateQueue, logs)
 		fatalOnError(err)
 		err = reprocessOrchestrations(orchestrationExt.UpgradeKymaOrchestration, db.Orchestrations(), db.Operations(), kymaQueue, logs)
 		fatalOnError(err)
@@ -426,9 +488,28 @@ func main() {
 	orchestrationHandler.AttachRoutes(router)
 
 	// create list runtimes endpoint
-	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), db.RuntimeStates(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion, provisionerClient)
+	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), db.RuntimeStates(), db.RuntimeDrifts(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion, provisionerClient)
 	runtimeHandler.AttachRoutes(router)
 
+	// create operation trace endpoint
+	traceHandler := trace.NewHandler(db.Operations(), db.OperationTraces(), logs)
+	traceHandler.AttachRoutes(router)
+
+	// create operation intervention endpoints
+	interventionHandler := intervention.NewHandler(db.Operations(), map[internal.OperationType]intervention.Adder{
+		internal.OperationTypeProvision:      provisionQueue,
+		internal.OperationTypeDeprovision:    deprovisionQueue,
+		internal.OperationTypeUpdate:         updateQueue,
+		internal.OperationTypeUpgradeKyma:    upgradeKymaQueue,
+		internal.OperationTypeUpgradeCluster: upgradeClusterQueue,
+	}, logs)
+	interventionHandler.AttachRoutes(router)
+
+	// create account pool status endpoint
+	if accountPoolCollector != nil {
+		metrics.NewAccountPoolHandler(accountPoolCollector, logs).AttachRoutes(router)
+	}
+
 	// create expiration endpoint
 	expirationHandler := expiration.NewHandler(db.Instances(), db.Operations(), deprovisionQueue, logs)
 	expirationHandler.AttachRoutes(router)
@@ -458,9 +539,7 @@ func isVersionFollowingSemanticVersioning(version string) bool {
 	return false
 }
 
-func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planValidator broker.PlanValidator, cfg *Config, db storage.BrokerStorage, provisionQueue, deprovisionQueue, updateQueue *process.Queue, logger lager.Logger, logs logrus.FieldLogger, planDefaults broker.PlanDefaults) {
-	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue, logs)
-
+func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planValidator broker.PlanValidator, cfg *Config, db storage.BrokerStorage, provisionQueue, deprovisionQueue, updateQueue *process.Queue, bindingsManager broker.BindingsManager, logger lager.Logger, logs logrus.FieldLogger, planDefaults broker.PlanDefaults, suspensionCtxHandler *suspension.ContextUpdateHandler, accountPool broker.AccountPoolStatus) {
 	defaultPlansConfig, err := servicesConfig.DefaultPlansConfig()
 	fatalOnError(err)
 
@@ -476,22 +555,25 @@ func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planVal
 	fatalOnError(err)
 	logs.Infof("Number of globalAccountIds for EU Access: %d\n", len(whitelistedGlobalAccountIds))
 
+	provisionEndpoint := broker.NewProvision(cfg.Broker, cfg.Gardener, db.Operations(), db.Instances(),
+		provisionQueue, planValidator, defaultPlansConfig, cfg.EnableOnDemandVersion,
+		planDefaults, whitelistedGlobalAccountIds, cfg.EuAccessRejectionMessage, logs, cfg.KymaDashboardConfig)
+	provisionEndpoint.UsePreflightCheckers(broker.NewPreflightCheckers(cfg.Broker.Preflight, db.Instances(), accountPool)...)
+
 	// create KymaEnvironmentBroker endpoints
 	kymaEnvBroker := &broker.KymaEnvironmentBroker{
-		ServicesEndpoint: broker.NewServices(cfg.Broker, servicesConfig, logs),
-		ProvisionEndpoint: broker.NewProvision(cfg.Broker, cfg.Gardener, db.Operations(), db.Instances(),
-			provisionQueue, planValidator, defaultPlansConfig, cfg.EnableOnDemandVersion,
-			planDefaults, whitelistedGlobalAccountIds, cfg.EuAccessRejectionMessage, logs, cfg.KymaDashboardConfig),
+		ServicesEndpoint:    broker.NewServices(cfg.Broker, servicesConfig, logs),
+		ProvisionEndpoint:   provisionEndpoint,
 		DeprovisionEndpoint: broker.NewDeprovision(db.Instances(), db.Operations(), deprovisionQueue, logs),
 		UpdateEndpoint: broker.NewUpdate(cfg.Broker, db.Instances(), db.RuntimeStates(), db.Operations(),
 			suspensionCtxHandler, cfg.UpdateProcessingEnabled, cfg.UpdateSubAccountMovementEnabled, updateQueue, defaultPlansConfig,
 			planDefaults, logs, cfg.KymaDashboardConfig),
 		GetInstanceEndpoint:          broker.NewGetInstance(cfg.Broker, db.Instances(), db.Operations(), logs),
 		LastOperationEndpoint:        broker.NewLastOperation(db.Operations(), logs),
-		BindEndpoint:                 broker.NewBind(cfg.Broker.Binding, db.Instances(), logs),
-		UnbindEndpoint:               broker.NewUnbind(logs),
-		GetBindingEndpoint:           broker.NewGetBinding(logs),
-		LastBindingOperationEndpoint: broker.NewLastBindingOperation(logs),
+		BindEndpoint:                 broker.NewBind(cfg.Broker.Binding, db.Instances(), db.Bindings(), bindingsManager, logs),
+		UnbindEndpoint:               broker.NewUnbind(db.Instances(), db.Bindings(), bindingsManager, logs),
+		GetBindingEndpoint:           broker.NewGetBinding(db.Bindings(), logs),
+		LastBindingOperationEndpoint: broker.NewLastBindingOperation(db.Bindings(), logs),
 	}
 
 	router.Use(middleware.AddRegionToContext(cfg.DefaultRequestRegion))
@@ -511,18 +593,49 @@ func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planVal
 }
 
 // queues all in progress operations by type
-func processOperationsInProgressByType(opType internal.OperationType, op storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
+// processOperationsInProgressByType re-queues not finished operations. If the leases are given, operations leased by another replica
+// are queued after the lease expiration, the manager takes them over if the owner stops renewing the lease.
+func processOperationsInProgressByType(opType internal.OperationType, op storage.Operations, leases storage.OperationLeases, leaseOwner string, queue *process.Queue, log logrus.FieldLogger) error {
 	operations, err := op.GetNotFinishedOperationsByType(opType)
 	if err != nil {
 		return fmt.Errorf("while getting in progress operations from storage: %w", err)
 	}
 	for _, operation := range operations {
+		if leases != nil {
+			lease, err := leases.GetByOperationID(operation.ID)
+			if err != nil && !dberr.IsNotFound(err) {
+				return fmt.Errorf("while getting lease of the operation %s: %w", operation.ID, err)
+			}
+			if err == nil && lease.Owner != leaseOwner && time.Now().Before(lease.ExpiresAt) {
+				queue.AddAfter(operation.ID, time.Until(lease.ExpiresAt))
+				log.Infof("Operation %s ID: %s is leased by %s, checking the lease after %s", opType, operation.ID, lease.Owner, lease.ExpiresAt)
+				continue
+			}
+		}
 		queue.Add(operation.ID)
 		log.Infof("Resuming the processing of %s operation ID: %s", opType, operation.ID)
 	}
+
+	operations, err = op.GetOperationsWithCompensationInProgress(opType)
+	if err != nil {
+		return fmt.Errorf("while getting operations with compensation in progress from storage: %w", err)
+	}
+	for _, operation := range operations {
+		queue.Add(operation.ID)
+		log.Infof("Resuming the compensation of %s operation ID: %s", opType, operation.ID)
+	}
 	return nil
 }
 
+// leaseOwnerID identifies the KEB replica, the pod name is used to take over leases of the replica after its restart
+func leaseOwnerID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return uuid.NewString()
+	}
+	return hostname
+}
+
 func reprocessOrchestrations(orchestrationType orchestrationExt.Type, orchestrationsStorage storage.Orchestrations, operationsStorage storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
 	if err := processCancelingOrchestrations(orchestrationType, orchestrationsStorage, operationsStorage, queue, log); err != nil {
 		return fmt.Errorf("while processing canceled %s orchestrations: %w", orchestrationType, err)
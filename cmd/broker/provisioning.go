@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
 	"github.com/kyma-project/kyma-environment-broker/internal/avs"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/input"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/provisioning"
@@ -14,6 +16,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/runtimeversion"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,90 +27,101 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 	runtimeOverrides provisioning.RuntimeOverridesAppender, edpClient provisioning.EDPClient, accountProvider hyperscaler.AccountProvider,
 	reconcilerClient reconciler.Client, k8sClientProvider provisioning.K8sClientProvider, cli client.Client, logs logrus.FieldLogger) *process.Queue {
 
-	const postActionsStageName = "post_actions"
-	provisionManager.DefineStages([]string{startStageName, createRuntimeStageName,
+	const (
+		prepareInputStageName     = "prepare_input"
+		provisionClusterStageName = "provision_cluster"
+		postActionsStageName      = "post_actions"
+	)
+	provisionManager.DefineStages([]string{startStageName, prepareInputStageName, provisionClusterStageName, createRuntimeStageName,
 		checkKymaStageName, createKymaResourceStageName, postActionsStageName})
+	provisionManager.DefineSplitStages(createRuntimeStageName, prepareInputStageName, provisionClusterStageName)
+	provisionManager.UseInputCreatorRestorer(input.NewProvisionInputRestorer(inputFactory))
 	/*
-			The provisioning process contains the following stages:
-			1. "start" - changes the state from pending to in progress if no deprovisioning is ongoing.
-			2. "create_runtime" - collects all information needed to make an input for the Provisioner request as overrides and labels.
-			Those data is collected using an InputCreator which is not persisted. That's why all steps which prepares such data must be in the same stage as "create runtime step".
-		    All steps which requires InputCreator must be run in this stage.
-			3. "check_kyma" - checks if the Kyma is installed
-			4. "post_actions" - all steps which must be executed after the runtime is provisioned
+		The provisioning process contains the following stages:
+		1. "start" - changes the state from pending to in progress if no deprovisioning is ongoing.
+		2. "prepare_input" - creates the InputCreator and collects all information needed to make an input for the Provisioner request as overrides and labels.
+		The state of the InputCreator is stored in the operation when the stage is finished, the next stages restore the InputCreator from the operation.
+		3. "provision_cluster" - creates the cluster with the Provisioner and waits until it is ready
+		4. "create_runtime" - creates the runtime resources in KCP and the cluster configuration in the Reconciler
+		5. "check_kyma" - checks if the Kyma is installed
+		6. "create_kyma_resource" - creates the Kyma resource
+		7. "post_actions" - all steps which must be executed after the runtime is provisioned
 
-			Once the stage is done it will never be retried.
+		Once the stage is done it will never be retried. The stages "prepare_input" and "provision_cluster" were split from "create_runtime",
+		they are not executed for the operations which finished "create_runtime" before.
 	*/
 
 	provisioningSteps := []struct {
-		disabled  bool
-		stage     string
-		step      process.Step
-		condition process.StepCondition
+		disabled    bool
+		stage       string
+		step        process.Step
+		condition   process.StepCondition
+		retryPolicy *process.RetryPolicy
 	}{
 		{
 			stage: startStageName,
 			step:  provisioning.NewStartStep(db.Operations(), db.Instances()),
 		},
 		{
-			stage: createRuntimeStageName,
+			stage: prepareInputStageName,
 			step:  provisioning.NewInitialisationStep(db.Operations(), db.Instances(), inputFactory, runtimeVerConfigurator),
 		},
 		{
-			stage: createRuntimeStageName,
+			stage: prepareInputStageName,
 			step:  steps.NewInitKymaTemplate(db.Operations()),
 		},
 		{
-			stage: createRuntimeStageName,
+			stage: prepareInputStageName,
 			step:  provisioning.NewOverrideKymaModules(db.Operations()),
 		},
 		{
-			stage:     createRuntimeStageName,
+			stage:     prepareInputStageName,
 			step:      provisioning.NewResolveCredentialsStep(db.Operations(), accountProvider),
 			condition: provisioning.SkipForOwnClusterPlan,
 		},
 		{
-			stage:    createRuntimeStageName,
+			stage:    prepareInputStageName,
 			step:     provisioning.NewInternalEvaluationStep(avsDel, internalEvalAssistant),
 			disabled: cfg.Avs.Disabled,
 		},
 		{
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewEDPRegistrationStep(db.Operations(), edpClient, cfg.EDP),
+			stage:     prepareInputStageName,
+			step:      provisioning.NewEDPRegistrationStep(db.Operations(), db.Instances(), edpClient, cfg.EDP),
 			disabled:  cfg.EDP.Disabled,
 			condition: provisioning.SkipForOwnClusterPlan,
 		},
 		{
-			stage: createRuntimeStageName,
+			stage: prepareInputStageName,
 			step:  provisioning.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
 			// Preview plan does not call Reconciler so it does not need overrides
 			condition: skipForPreviewPlan,
 		},
 		{
 			condition: provisioning.WhenBTPOperatorCredentialsProvided,
-			stage:     createRuntimeStageName,
+			stage:     prepareInputStageName,
 			step:      provisioning.NewBTPOperatorOverridesStep(db.Operations()),
 		},
 		{
 			condition: provisioning.SkipForOwnClusterPlan,
-			stage:     createRuntimeStageName,
+			stage:     provisionClusterStageName,
 			step:      provisioning.NewCreateRuntimeWithoutKymaStep(db.Operations(), db.RuntimeStates(), db.Instances(), provisionerClient),
 		},
 		{
 			condition: provisioning.DoForOwnClusterPlanOnly,
-			stage:     createRuntimeStageName,
+			stage:     provisionClusterStageName,
 			step:      provisioning.NewCreateRuntimeForOwnClusterStep(db.Operations(), db.Instances()),
 		},
 		{
-			stage:     createRuntimeStageName,
+			stage:     provisionClusterStageName,
 			step:      provisioning.NewCheckRuntimeStep(db.Operations(), provisionerClient, cfg.Provisioner.ProvisioningTimeout),
 			condition: provisioning.SkipForOwnClusterPlan,
 		},
 		{
-			stage:     createRuntimeStageName,
-			disabled:  cfg.InfrastructureManagerIntegrationDisabled,
-			step:      steps.NewSyncGardenerCluster(db.Operations(), cli),
-			condition: provisioning.SkipForOwnClusterPlan,
+			stage:       createRuntimeStageName,
+			disabled:    cfg.InfrastructureManagerIntegrationDisabled,
+			step:        steps.NewSyncGardenerCluster(db.Operations(), cli),
+			condition:   provisioning.SkipForOwnClusterPlan,
+			retryPolicy: kcpResourceRetryPolicy(),
 		},
 		{
 			stage:     createRuntimeStageName,
@@ -142,9 +156,10 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 			condition: skipForPreviewPlan,
 		},
 		{
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			stage:    createKymaResourceStageName,
-			step:     provisioning.NewApplyKymaStep(db.Operations(), cli),
+			disabled:    cfg.LifecycleManagerIntegrationDisabled,
+			stage:       createKymaResourceStageName,
+			step:        provisioning.NewApplyKymaStep(db.Operations(), cli),
+			retryPolicy: kcpResourceRetryPolicy(),
 		},
 		// post actions
 		{
@@ -159,7 +174,11 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 	}
 	for _, step := range provisioningSteps {
 		if !step.disabled {
-			err := provisionManager.AddStep(step.stage, step.step, step.condition)
+			var opts []process.StepOption
+			if step.retryPolicy != nil {
+				opts = append(opts, process.WithRetryPolicy(*step.retryPolicy))
+			}
+			err := provisionManager.AddStep(step.stage, step.step, step.condition, opts...)
 			if err != nil {
 				fatalOnError(err)
 			}
@@ -171,3 +190,24 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 
 	return queue
 }
+
+// kcpResourceRetryPolicy is the retry policy of steps which create or update resources in the KCP cluster,
+// the temporary errors of the KCP API server are retried, other errors fail the operation
+func kcpResourceRetryPolicy() *process.RetryPolicy {
+	return &process.RetryPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxElapsedTime:  time.Minute,
+		RetryableReasons: []kebError.ErrReason{
+			kebError.ErrK8SUnexpectedServerError,
+			kebError.ErrReason(metav1.StatusReasonConflict),
+			kebError.ErrReason(metav1.StatusReasonServerTimeout),
+			kebError.ErrReason(metav1.StatusReasonTimeout),
+			kebError.ErrReason(metav1.StatusReasonTooManyRequests),
+			kebError.ErrReason(metav1.StatusReasonInternalError),
+			kebError.ErrReason(metav1.StatusReasonServiceUnavailable),
+		},
+	}
+}
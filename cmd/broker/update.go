@@ -19,7 +19,7 @@ import (
 
 func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManager, workersAmount int, db storage.BrokerStorage, inputFactory input.CreatorForPlan,
 	provisionerClient provisioner.Client, publisher event.Publisher, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator, runtimeStatesDb storage.RuntimeStates,
-	runtimeProvider input.ComponentListProvider, reconcilerClient reconciler.Client, cfg Config, k8sClientProvider K8sClientProvider, cli client.Client, logs logrus.FieldLogger) *process.Queue {
+	runtimeProvider input.ComponentListProvider, reconcilerClient reconciler.Client, cfg Config, k8sClientProvider K8sClientProvider, cli client.Client, configProvider input.ConfigurationProvider, logs logrus.FieldLogger) *process.Queue {
 
 	requiresReconcilerUpdate := update.RequiresReconcilerUpdate
 	if cfg.ReconcilerIntegrationDisabled {
@@ -40,6 +40,11 @@ func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManage
 			step:      update.NewUpgradeShootStep(db.Operations(), db.RuntimeStates(), provisionerClient),
 			condition: update.SkipForOwnClusterPlan,
 		},
+		{
+			stage:     "cluster",
+			step:      update.NewUpdateKymaModulesStep(db.Operations(), cli, configProvider, cfg.KymaVersion),
+			condition: update.RequiresModulesUpdate,
+		},
 		{
 			stage: "btp-operator",
 			step:  update.NewInitKymaVersionStep(db.Operations(), runtimeVerConfigurator, runtimeStatesDb),
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -12,6 +13,9 @@ import (
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const updateRequestPathFormat = "oauth/v2/service_instances/%s?accepts_incomplete=true"
@@ -2046,6 +2050,71 @@ func TestUpdateMachineType(t *testing.T) {
 	assert.Equal(t, "m5.2xlarge", rs[0].ClusterConfig.MachineType, "after update")
 }
 
+func TestUpdateModules(t *testing.T) {
+	// given
+	suite := NewBrokerSuiteTest(t)
+	mockBTPOperatorClusterID()
+	defer suite.TearDown()
+	id := "InstanceID-modules"
+
+	resp := suite.CallAPI("PUT", fmt.Sprintf("oauth/cf-eu10/v2/service_instances/%s?accepts_incomplete=true&plan_id=7d55d31d-35ae-4438-bf13-6ffdfa107d9f&service_id=47c9dcbf-ff30-448e-ab36-d3bad66ba281", id), `
+{
+	"service_id": "47c9dcbf-ff30-448e-ab36-d3bad66ba281",
+	"plan_id": "7d55d31d-35ae-4438-bf13-6ffdfa107d9f",
+	"context": {
+		"globalaccount_id": "g-account-id",
+		"subaccount_id": "sub-id",
+		"user_id": "john.smith@email.com"
+	},
+	"parameters": {
+		"name": "testing-cluster"
+	}
+}`)
+
+	opID := suite.DecodeOperationID(resp)
+	suite.processProvisioningByOperationID(opID)
+	suite.WaitForOperationState(opID, domain.Succeeded)
+
+	// when patch to change modules
+	resp = suite.CallAPI("PATCH", fmt.Sprintf("oauth/cf-eu10/v2/service_instances/%s?accepts_incomplete=true", id), `
+{
+	"service_id": "47c9dcbf-ff30-448e-ab36-d3bad66ba281",
+	"context": {
+		"globalaccount_id": "g-account-id",
+		"user_id": "john.smith@email.com"
+	},
+	"parameters": {
+		"modules": {
+			"list": [
+				{
+					"name": "api-gateway",
+					"channel": "fast",
+					"customResourcePolicy": ""
+				}
+			]
+		}
+	}
+}`)
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	updateOperationID := suite.DecodeOperationID(resp)
+	suite.FinishUpdatingOperationByProvisioner(updateOperationID)
+	suite.FinishUpdatingOperationByReconciler(updateOperationID)
+	suite.WaitForOperationState(updateOperationID, domain.Succeeded)
+
+	// then
+	instance := suite.GetInstance(id)
+	assert.Equal(t, "api-gateway", instance.Parameters.Parameters.Modules.List[0].Name)
+
+	kyma := &unstructured.Unstructured{}
+	kyma.SetGroupVersionKind(schema.GroupVersionKind{Group: "operator.kyma-project.io", Version: "v1beta2", Kind: "Kyma"})
+	err := suite.k8sKcp.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: instance.RuntimeID}, kyma)
+	require.NoError(t, err)
+	modules, _, err := unstructured.NestedSlice(kyma.Object, "spec", "modules")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "api-gateway", "channel": "fast"}}, modules)
+}
+
 func TestUpdateBTPOperatorCredsSuccess(t *testing.T) {
 	// given
 	suite := NewBrokerSuiteTest(t)
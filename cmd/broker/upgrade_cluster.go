@@ -22,38 +22,56 @@ import (
 func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, provisionerClient provisioner.Client,
 	pub event.Publisher, inputFactory input.CreatorForPlan, icfg *upgrade_cluster.TimeSchedule, pollingInterval time.Duration,
 	runtimeResolver orchestrationExt.RuntimeResolver, upgradeEvalManager *avs.EvaluationManager, notificationBuilder notification.BundleBuilder, logs logrus.FieldLogger,
-	cli client.Client, cfg Config, speedFactor int) *process.Queue {
+	cli client.Client, cfg Config, speedFactor int) (*process.Queue, *process.Queue) {
 
-	upgradeClusterManager := upgrade_cluster.NewManager(db.Operations(), pub, logs.WithField("upgradeCluster", "manager"))
+	const (
+		upgradeClusterStageName = "upgrade_cluster"
+		checkStageName          = "check"
+	)
+
+	// the steps check the time limits of the upgrade, a step which needs a retry returns the operation back to the orchestration strategy queue
+	upgradeClusterManager := process.NewStagedManager(db.Operations(), pub, 0, process.StagedManagerConfiguration{}, logs.WithField("upgradeCluster", "manager"))
+	upgradeClusterManager.DefineStages([]string{upgradeClusterStageName, checkStageName})
 	upgradeClusterInit := upgrade_cluster.NewInitialisationStep(db.Operations(), db.Orchestrations(), provisionerClient, inputFactory, upgradeEvalManager, icfg, notificationBuilder)
-	upgradeClusterManager.InitStep(upgradeClusterInit)
 
 	upgradeClusterSteps := []struct {
 		disabled  bool
-		weight    int
-		step      upgrade_cluster.Step
-		condition upgrade_cluster.StepCondition
+		stage     string
+		step      process.Step
+		condition process.StepCondition
 	}{
 		{
-			weight:    1,
+			stage: upgradeClusterStageName,
+			step:  upgradeClusterInit,
+		},
+		{
+			stage:     upgradeClusterStageName,
 			step:      upgrade_cluster.NewLogSkippingUpgradeStep(db.Operations()),
 			condition: provisioning.DoForOwnClusterPlanOnly,
 		},
 		{
-			weight:    10,
+			stage:     upgradeClusterStageName,
 			step:      upgrade_cluster.NewSendNotificationStep(db.Operations(), notificationBuilder),
 			condition: provisioning.SkipForOwnClusterPlan,
 		},
 		{
-			weight:    10,
+			stage:     upgradeClusterStageName,
 			step:      upgrade_cluster.NewUpgradeClusterStep(db.Operations(), db.RuntimeStates(), provisionerClient, icfg),
 			condition: provisioning.SkipForOwnClusterPlan,
 		},
+		// the initialisation step checks the status of the provisioner operation triggered by the upgrade cluster step
+		{
+			stage: checkStageName,
+			step:  upgradeClusterInit,
+		},
 	}
 
 	for _, step := range upgradeClusterSteps {
 		if !step.disabled {
-			upgradeClusterManager.AddStep(step.weight, step.step, step.condition)
+			err := upgradeClusterManager.AddStep(step.stage, step.step, step.condition)
+			if err != nil {
+				fatalOnError(err)
+			}
 		}
 	}
 
@@ -64,5 +82,9 @@ func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.Brok
 
 	queue.Run(ctx.Done(), 3)
 
-	return queue
+	// the operations resumed by an administrator are not in the orchestration strategy queue, they are processed by the operations queue
+	operationsQueue := process.NewQueue(upgradeClusterManager, logs)
+	operationsQueue.Run(ctx.Done(), 1)
+
+	return queue, operationsQueue
 }
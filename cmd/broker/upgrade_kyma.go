@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/orchestration/manager"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/provisioning"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/upgrade_kyma"
 	"github.com/kyma-project/kyma-environment-broker/internal/provisioner"
@@ -27,60 +28,74 @@ func NewKymaOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerS
 	runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator, runtimeResolver orchestrationExt.RuntimeResolver,
 	upgradeEvalManager *avs.EvaluationManager, cfg *Config, internalEvalAssistant *avs.InternalEvalAssistant,
 	reconcilerClient reconciler.Client, notificationBuilder notification.BundleBuilder, k8sClientProvider KubeconfigProvider, logs logrus.FieldLogger,
-	cli client.Client, speedFactor int) *process.Queue {
+	cli client.Client, speedFactor int) (*process.Queue, *process.Queue) {
 
-	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, logs.WithField("upgradeKyma", "manager"))
-	upgradeKymaInit := upgrade_kyma.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
-		provisionerClient, inputFactory, upgradeEvalManager, icfg, runtimeVerConfigurator, notificationBuilder)
+	const (
+		upgradeKymaStageName = "upgrade_kyma"
+		checkStageName       = "check"
+	)
 
-	upgradeKymaManager.InitStep(upgradeKymaInit)
+	// the steps check the time limits of the upgrade, a step which needs a retry returns the operation back to the orchestration strategy queue
+	upgradeKymaManager := process.NewStagedManager(db.Operations(), pub, 0, process.StagedManagerConfiguration{}, logs.WithField("upgradeKyma", "manager"))
+	upgradeKymaManager.DefineStages([]string{upgradeKymaStageName, checkStageName})
 	upgradeKymaSteps := []struct {
-		disabled bool
-		weight   int
-		step     upgrade_kyma.Step
-		cnd      upgrade_kyma.StepCondition
+		disabled    bool
+		stage       string
+		step        process.Step
+		condition   process.StepCondition
+		retryPolicy *process.RetryPolicy
 	}{
-		// check cluster configuration is the first step - to not execute other steps, when cluster configuration was applied
-		// this should be moved to the end when we introduce stages like in the provisioning process
-		// (also return operation, 0, nil at the end of apply_cluster_configuration)
 		{
-			weight:   1,
-			disabled: cfg.ReconcilerIntegrationDisabled,
-			step:     upgrade_kyma.NewCheckClusterConfigurationStep(db.Operations(), reconcilerClient, upgradeEvalManager, cfg.Reconciler.ProvisioningTimeout),
-			cnd:      upgrade_kyma.SkipForPreviewPlan,
+			stage: upgradeKymaStageName,
+			step: upgrade_kyma.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
+				provisionerClient, inputFactory, upgradeEvalManager, icfg, runtimeVerConfigurator, notificationBuilder),
 		},
 		{
-			weight: 1,
-			step:   steps.InitKymaTemplateUpgradeKyma(db.Operations()),
+			stage: upgradeKymaStageName,
+			step:  steps.NewInitKymaTemplate(db.Operations()),
 		},
 		{
-			weight:   2,
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			step:     upgrade_kyma.NewApplyKymaStep(db.Operations(), cli),
+			disabled:    cfg.LifecycleManagerIntegrationDisabled,
+			stage:       upgradeKymaStageName,
+			step:        provisioning.NewApplyKymaStep(db.Operations(), cli),
+			retryPolicy: kcpResourceRetryPolicy(),
 		},
 		{
-			weight: 3,
-			cnd:    upgrade_kyma.WhenBTPOperatorCredentialsProvided,
-			step:   upgrade_kyma.NewBTPOperatorOverridesStep(db.Operations()),
+			stage:     upgradeKymaStageName,
+			step:      upgrade_kyma.NewBTPOperatorOverridesStep(db.Operations()),
+			condition: upgrade_kyma.WhenBTPOperatorCredentialsProvided,
 		},
 		{
-			weight: 4,
-			step:   upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
+			stage: upgradeKymaStageName,
+			step:  upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
 		},
 		{
-			weight: 8,
-			step:   upgrade_kyma.NewSendNotificationStep(db.Operations(), notificationBuilder),
+			stage: upgradeKymaStageName,
+			step:  upgrade_kyma.NewSendNotificationStep(db.Operations(), notificationBuilder),
 		},
 		{
-			weight:   10,
-			disabled: cfg.ReconcilerIntegrationDisabled,
-			step:     upgrade_kyma.NewApplyClusterConfigurationStep(db.Operations(), db.RuntimeStates(), reconcilerClient, k8sClientProvider),
-			cnd:      upgrade_kyma.SkipForPreviewPlan,
+			disabled:  cfg.ReconcilerIntegrationDisabled,
+			stage:     upgradeKymaStageName,
+			step:      upgrade_kyma.NewApplyClusterConfigurationStep(db.Operations(), db.RuntimeStates(), reconcilerClient, k8sClientProvider),
+			condition: upgrade_kyma.SkipForPreviewPlan,
+		},
+		{
+			disabled:  cfg.ReconcilerIntegrationDisabled,
+			stage:     checkStageName,
+			step:      upgrade_kyma.NewCheckClusterConfigurationStep(db.Operations(), reconcilerClient, upgradeEvalManager, cfg.Reconciler.ProvisioningTimeout),
+			condition: upgrade_kyma.SkipForPreviewPlan,
 		},
 	}
 	for _, step := range upgradeKymaSteps {
 		if !step.disabled {
-			upgradeKymaManager.AddStep(step.weight, step.step, step.cnd)
+			var opts []process.StepOption
+			if step.retryPolicy != nil {
+				opts = append(opts, process.WithRetryPolicy(*step.retryPolicy))
+			}
+			err := upgradeKymaManager.AddStep(step.stage, step.step, step.condition, opts...)
+			if err != nil {
+				fatalOnError(err)
+			}
 		}
 	}
 
@@ -91,5 +106,9 @@ func NewKymaOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerS
 
 	queue.Run(ctx.Done(), 3)
 
-	return queue
+	// the operations resumed by an administrator are not in the orchestration strategy queue, they are processed by the operations queue
+	operationsQueue := process.NewQueue(upgradeKymaManager, logs)
+	operationsQueue.Run(ctx.Done(), 1)
+
+	return queue, operationsQueue
 }
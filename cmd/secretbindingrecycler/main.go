@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/control-plane/components/schema-migrator/cleaner"
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/secretbindingrecycler"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	log "github.com/sirupsen/logrus"
+	"github.com/vrischmann/envconfig"
+	"k8s.io/client-go/dynamic"
+)
+
+type Config struct {
+	Gardener               gardener.Config
+	Database               storage.Config
+	HyperscalerAccountPool hyperscaler.PoolConfig
+	DryRun                 bool `envconfig:"default=true"`
+	// HyperscalerTypes lists the hyperscaler types whose dirty accounts are recycled, gcp, azure and aws if empty
+	HyperscalerTypes             []string `envconfig:"optional"`
+	ReturnToPoolHyperscalerTypes []string `envconfig:"optional"`
+}
+
+func main() {
+	log.SetFormatter(&log.JSONFormatter{})
+	log.Info("Starting secret binding recycler job")
+
+	// create and fill config
+	var cfg Config
+	err := envconfig.InitWithPrefix(&cfg, "APP")
+	fatalOnError(err)
+
+	if cfg.DryRun {
+		log.Info("Dry run only - no changes")
+	}
+	log.Infof("Hyperscaler types returned to the pool: %v", cfg.ReturnToPoolHyperscalerTypes)
+
+	hyperscalerTypes, err := parseHyperscalerTypes(cfg.HyperscalerTypes)
+	fatalOnError(err)
+
+	// create Gardener client
+	gardenerClusterConfig, err := gardener.NewGardenerClusterConfig(cfg.Gardener.KubeconfigPath)
+	fatalOnError(err)
+	gardenerClient, err := dynamic.NewForConfig(gardenerClusterConfig)
+	fatalOnError(err)
+	gardenerNamespace := fmt.Sprintf("garden-%s", cfg.Gardener.Project)
+
+	// create storage connection
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
+	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher, log.WithField("service", "storage"))
+	fatalOnError(err)
+
+	// the accounts are recycled by the backends used by KEB, the changes of the file backend are not persisted
+	pool, err := hyperscaler.NewConfiguredPoolRegistry(cfg.HyperscalerAccountPool, gardenerClient, gardenerNamespace, db.HyperscalerAccounts(), db.Instances())
+	fatalOnError(err)
+
+	svc := secretbindingrecycler.NewService(pool, gardenerClient, gardenerNamespace, db.Instances(), secretbindingrecycler.Config{
+		DryRun:                       cfg.DryRun,
+		HyperscalerTypes:             hyperscalerTypes,
+		ReturnToPoolHyperscalerTypes: cfg.ReturnToPoolHyperscalerTypes,
+	}, log.WithField("service", "secretBindingRecycler"))
+
+	report, err := svc.Run()
+	fatalOnError(err)
+
+	reportJSON, err := json.Marshal(report)
+	fatalOnError(err)
+	log.Infof("Recycling report: %s", reportJSON)
+	log.Infof("Dirty secret bindings: %d, returned to the pool: %d, flagged for manual cleanup: %d, skipped: %d, failures: %d",
+		len(report.Entries),
+		report.Count(secretbindingrecycler.ReturnedToPool),
+		report.Count(secretbindingrecycler.FlaggedForCleanup),
+		report.Count(secretbindingrecycler.Skipped),
+		report.Count(secretbindingrecycler.Failed))
+
+	log.Info("Secret binding recycler job finished successfully!")
+
+	err = conn.Close()
+	if err != nil {
+		fatalOnError(err)
+	}
+
+	cleaner.HaltIstioSidecar()
+	err = cleaner.Halt()
+	fatalOnError(err)
+}
+
+func parseHyperscalerTypes(keys []string) ([]hyperscaler.Type, error) {
+	if len(keys) == 0 {
+		keys = []string{hyperscaler.GCP().GetKey(), hyperscaler.Azure().GetKey(), hyperscaler.AWS().GetKey()}
+	}
+	types := make([]hyperscaler.Type, 0, len(keys))
+	for _, key := range keys {
+		hyperscalerType, err := hyperscaler.ParseType(key)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, hyperscalerType)
+	}
+	return types, nil
+}
+
+func fatalOnError(err error) {
+	if err != nil {
+		// temporarily we exit with 0 to avoid any side effects - we ignore all errors only logging those
+		log.Error(err)
+		os.Exit(0)
+	}
+}
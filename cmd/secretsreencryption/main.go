@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/schema-migrator/cleaner"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	log "github.com/sirupsen/logrus"
+	"github.com/vrischmann/envconfig"
+)
+
+type Config struct {
+	Database  storage.Config
+	BatchSize int `envconfig:"default=100"`
+}
+
+// reencryptFunc re-encrypts the batch of records following afterID and returns the ID of the last one and the number of the failed records
+type reencryptFunc func(afterID string, batchSize int) (string, int, error)
+
+func main() {
+	log.SetFormatter(&log.JSONFormatter{})
+	log.Info("Starting secrets re-encryption job")
+
+	// create and fill config
+	var cfg Config
+	err := envconfig.InitWithPrefix(&cfg, "APP")
+	fatalOnError(err)
+
+	if cfg.Database.ActiveSecretKeyID == "" {
+		fatalOnError(fmt.Errorf("the active secret key is not set"))
+	}
+	log.Infof("Re-encrypting secrets with the key %s", cfg.Database.ActiveSecretKeyID)
+
+	// create storage connection
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
+	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher, log.WithField("service", "storage"))
+	fatalOnError(err)
+
+	encryptedData := db.EncryptedData()
+	failed := 0
+	for _, table := range []struct {
+		name      string
+		reencrypt reencryptFunc
+	}{
+		{name: "instances", reencrypt: encryptedData.ReencryptInstances},
+		{name: "operations", reencrypt: encryptedData.ReencryptOperations},
+		{name: "provisioner inputs", reencrypt: encryptedData.ReencryptProvisionerInputs},
+		{name: "bindings", reencrypt: encryptedData.ReencryptBindings},
+		{name: "runtime states", reencrypt: encryptedData.ReencryptRuntimeStates},
+	} {
+		tableFailed, err := reencryptAll(table.name, table.reencrypt, cfg.BatchSize)
+		fatalOnError(err)
+		failed += tableFailed
+	}
+	if failed > 0 {
+		fatalOnError(fmt.Errorf("%d records could not be re-encrypted and were left unchanged, see the logs", failed))
+	}
+
+	log.Info("Secrets re-encryption job finished successfully!")
+
+	err = conn.Close()
+	if err != nil {
+		fatalOnError(err)
+	}
+
+	cleaner.HaltIstioSidecar()
+	// do not use defer, close must be done before halting
+	err = cleaner.Halt()
+	fatalOnError(err)
+}
+
+// reencryptAll re-encrypts the records batch by batch, the job can be run again after a failure because the records are re-encrypted in place.
+// The records which cannot be re-encrypted are skipped and counted.
+func reencryptAll(name string, reencrypt reencryptFunc, batchSize int) (int, error) {
+	lastID, batches, failed := "", 0, 0
+	for {
+		next, batchFailed, err := reencrypt(lastID, batchSize)
+		if err != nil {
+			return failed, fmt.Errorf("while re-encrypting %s after %q: %w", name, lastID, err)
+		}
+		failed += batchFailed
+		if next == "" {
+			break
+		}
+		lastID = next
+		batches++
+	}
+	log.Infof("Re-encrypted %s in %d batches, %d failed", name, batches, failed)
+	return failed, nil
+}
+
+func fatalOnError(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -32,6 +32,17 @@ func (b SecretBinding) GetSecretRefNamespace() string {
 	return str
 }
 
+// NewSecretBinding returns the SecretBinding representation of the account which is not stored as a Gardener resource
+func NewSecretBinding(name, namespace, secretName string, labels map[string]string) *SecretBinding {
+	u := unstructured.Unstructured{}
+	u.SetName(name)
+	u.SetNamespace(namespace)
+	u.SetLabels(labels)
+	_ = unstructured.SetNestedField(u.Object, secretName, "secretRef", "name")
+	_ = unstructured.SetNestedField(u.Object, namespace, "secretRef", "namespace")
+	return &SecretBinding{u}
+}
+
 type Shoot struct {
 	unstructured.Unstructured
 }
@@ -72,6 +83,56 @@ func (b Shoot) GetSpecRegion() string {
 	return str
 }
 
+func (b Shoot) GetSpecKubernetesVersion() string {
+	str, _, err := unstructured.NestedString(b.Unstructured.Object, "spec", "kubernetes", "version")
+	if err != nil {
+		// NOTE this is a safety net, gardener v1beta1 API would need to break the contract for this to panic
+		panic(fmt.Sprintf("Shoot missing field '.spec.kubernetes.version': %v", err))
+	}
+	return str
+}
+
+func (b Shoot) GetSpecHibernationEnabled() bool {
+	enabled, _, err := unstructured.NestedBool(b.Unstructured.Object, "spec", "hibernation", "enabled")
+	if err != nil {
+		// NOTE this is a safety net, gardener v1beta1 API would need to break the contract for this to panic
+		panic(fmt.Sprintf("Shoot missing field '.spec.hibernation.enabled': %v", err))
+	}
+	return enabled
+}
+
+// Worker is a worker pool of the shoot
+type Worker struct {
+	Name        string
+	MachineType string
+	Minimum     int64
+	Maximum     int64
+	Zones       []string
+}
+
+func (b Shoot) GetSpecWorkers() []Worker {
+	items, _, err := unstructured.NestedSlice(b.Unstructured.Object, "spec", "provider", "workers")
+	if err != nil {
+		// NOTE this is a safety net, gardener v1beta1 API would need to break the contract for this to panic
+		panic(fmt.Sprintf("Shoot missing field '.spec.provider.workers': %v", err))
+	}
+	workers := make([]Worker, 0, len(items))
+	for _, item := range items {
+		w, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		worker := Worker{}
+		worker.Name, _, _ = unstructured.NestedString(w, "name")
+		worker.MachineType, _, _ = unstructured.NestedString(w, "machine", "type")
+		worker.Minimum, _, _ = unstructured.NestedInt64(w, "minimum")
+		worker.Maximum, _, _ = unstructured.NestedInt64(w, "maximum")
+		worker.Zones, _, _ = unstructured.NestedStringSlice(w, "zones")
+		workers = append(workers, worker)
+	}
+	return workers
+}
+
 var SecretBindingResource = schema.GroupVersionResource{Group: "core.gardener.cloud", Version: "v1beta1", Resource: "secretbindings"}
 var ShootResource = schema.GroupVersionResource{Group: "core.gardener.cloud", Version: "v1beta1", Resource: "shoots"}
 
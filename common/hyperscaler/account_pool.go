@@ -3,6 +3,7 @@ package hyperscaler
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/kyma-project/kyma-environment-broker/common/gardener"
@@ -17,6 +18,29 @@ type AccountPool interface {
 	IsSecretBindingUsed(hyperscalerType Type, tenantName string, euAccess bool) (bool, error)
 	IsSecretBindingDirty(hyperscalerType Type, tenantName string, euAccess bool) (bool, error)
 	IsSecretBindingInternal(hyperscalerType Type, tenantName string, euAccess bool) (bool, error)
+	Status(hyperscalerType Type, euAccess bool) (PoolStatus, error)
+}
+
+// ManualCleanupLabel marks the dirty secret binding whose account must be cleaned up by an operator before it is returned to the pool
+const ManualCleanupLabel = "manualCleanup"
+
+// DirtyAccount is the account marked as dirty after the deprovisioning of the last cluster of its tenant
+type DirtyAccount struct {
+	// Name is the name used by the clusters to refer to the account, that is, the name of the secret binding
+	Name            string
+	HyperscalerType string
+	TenantName      string
+	EUAccess        bool
+	Shared          bool
+	ManualCleanup   bool
+}
+
+// AccountRecycler returns the dirty accounts to the pool. The account is changed only if it is still dirty and assigned
+// to the same tenant as when it was listed.
+type AccountRecycler interface {
+	DirtyAccounts(hyperscalerType Type) ([]DirtyAccount, error)
+	ReturnToPool(hyperscalerType Type, account DirtyAccount) error
+	FlagForManualCleanup(hyperscalerType Type, account DirtyAccount) error
 }
 
 func NewAccountPool(gardenerClient dynamic.Interface, gardenerNamespace string) AccountPool {
@@ -151,6 +175,89 @@ func (p *secretBindingsAccountPool) CredentialsSecretBinding(hyperscalerType Typ
 	return &gardener.SecretBinding{*updatedSecretBinding}, nil
 }
 
+func (p *secretBindingsAccountPool) Status(hyperscalerType Type, euAccess bool) (PoolStatus, error) {
+	labelSelector := fmt.Sprintf("hyperscalerType=%s", hyperscalerType.GetKey())
+	labelSelector = addEuAccessSelector(labelSelector, euAccess)
+	secretBindings, err := p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return PoolStatus{}, fmt.Errorf("listing secret bindings for LabelSelector: %s: %w", labelSelector, err)
+	}
+
+	status := newPoolStatus(hyperscalerType, euAccess)
+	for _, secretBinding := range secretBindings.Items {
+		labels := secretBinding.GetLabels()
+		status.add(labels["shared"] == "true", labels["tenantName"], labels["dirty"] == "true", labels["internal"] == "true")
+	}
+	return status, nil
+}
+
+func (p *secretBindingsAccountPool) DirtyAccounts(hyperscalerType Type) ([]DirtyAccount, error) {
+	labelSelector := fmt.Sprintf("dirty=true, hyperscalerType=%s", hyperscalerType.GetKey())
+	secretBindings, err := p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing secret bindings for LabelSelector: %s: %w", labelSelector, err)
+	}
+
+	accounts := make([]DirtyAccount, 0, len(secretBindings.Items))
+	for _, secretBinding := range secretBindings.Items {
+		labels := secretBinding.GetLabels()
+		accounts = append(accounts, DirtyAccount{
+			Name:            secretBinding.GetName(),
+			HyperscalerType: labels["hyperscalerType"],
+			TenantName:      labels["tenantName"],
+			EUAccess:        labels["euAccess"] == "true",
+			Shared:          labels["shared"] == "true",
+			ManualCleanup:   labels[ManualCleanupLabel] == "true",
+		})
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Name < accounts[j].Name
+	})
+	return accounts, nil
+}
+
+func (p *secretBindingsAccountPool) ReturnToPool(_ Type, account DirtyAccount) error {
+	return p.updateDirtySecretBinding(account, func(labels map[string]string) {
+		delete(labels, "dirty")
+		delete(labels, "tenantName")
+		delete(labels, ManualCleanupLabel)
+	})
+}
+
+func (p *secretBindingsAccountPool) FlagForManualCleanup(_ Type, account DirtyAccount) error {
+	return p.updateDirtySecretBinding(account, func(labels map[string]string) {
+		labels[ManualCleanupLabel] = "true"
+	})
+}
+
+// updateDirtySecretBinding changes the labels of the secret binding if it is still dirty and assigned to the tenant,
+// the update fails with the conflict if the secret binding is changed in the meantime
+func (p *secretBindingsAccountPool) updateDirtySecretBinding(account DirtyAccount, update func(labels map[string]string)) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	secretBinding, err := p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).Get(context.Background(), account.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting secret binding %s: %w", account.Name, err)
+	}
+	labels := secretBinding.GetLabels()
+	if labels["dirty"] != "true" || labels["tenantName"] != account.TenantName {
+		return fmt.Errorf("secret binding %s is no longer dirty or assigned to the tenant %s", account.Name, account.TenantName)
+	}
+
+	update(labels)
+	secretBinding.SetLabels(labels)
+	_, err = p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).Update(context.Background(), secretBinding, v1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("updating secret binding %s: %w", account.Name, err)
+	}
+	return nil
+}
+
 func (p *secretBindingsAccountPool) getSecretBinding(labelSelector string) (*gardener.SecretBinding, error) {
 	secretBindings, err := p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).List(context.Background(), metav1.ListOptions{
 		LabelSelector: labelSelector,
@@ -0,0 +1,96 @@
+package hyperscaler
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"gopkg.in/yaml.v2"
+)
+
+type fileAccount struct {
+	Name            string `yaml:"name"`
+	SecretName      string `yaml:"secretName"`
+	HyperscalerType string `yaml:"hyperscalerType"`
+	TenantName      string `yaml:"tenantName"`
+	Shared          bool   `yaml:"shared"`
+	Dirty           bool   `yaml:"dirty"`
+	Internal        bool   `yaml:"internal"`
+	EUAccess        bool   `yaml:"euAccess"`
+	ManualCleanup   bool   `yaml:"manualCleanup"`
+}
+
+type fileAccounts struct {
+	Accounts []fileAccount `yaml:"accounts"`
+}
+
+// fileAccountStore keeps the accounts read from the YAML file in memory, the assignments of tenants and the dirty flags
+// are not written back to the file. It is suitable for local environments and tests.
+type fileAccountStore struct {
+	mux      sync.Mutex
+	accounts map[string]internal.HyperscalerAccount
+}
+
+func NewFileAccountStore(path string) (*fileAccountStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("while reading accounts file %s: %w", path, err)
+	}
+	var file fileAccounts
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("while unmarshalling accounts file %s: %w", path, err)
+	}
+
+	store := &fileAccountStore{accounts: make(map[string]internal.HyperscalerAccount, len(file.Accounts))}
+	for _, a := range file.Accounts {
+		if a.Name == "" || a.SecretName == "" || a.HyperscalerType == "" {
+			return nil, fmt.Errorf("account in file %s must have name, secretName and hyperscalerType", path)
+		}
+		if _, found := store.accounts[a.Name]; found {
+			return nil, fmt.Errorf("account %s is defined more than once in file %s", a.Name, path)
+		}
+		store.accounts[a.Name] = internal.HyperscalerAccount{
+			Name:            a.Name,
+			SecretName:      a.SecretName,
+			HyperscalerType: a.HyperscalerType,
+			TenantName:      a.TenantName,
+			Shared:          a.Shared,
+			Dirty:           a.Dirty,
+			Internal:        a.Internal,
+			EUAccess:        a.EUAccess,
+			ManualCleanup:   a.ManualCleanup,
+		}
+	}
+	return store, nil
+}
+
+func (s *fileAccountStore) List(hyperscalerType string) ([]internal.HyperscalerAccount, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make([]internal.HyperscalerAccount, 0)
+	for _, account := range s.accounts {
+		if account.HyperscalerType == hyperscalerType {
+			result = append(result, account)
+		}
+	}
+	return result, nil
+}
+
+func (s *fileAccountStore) Update(account internal.HyperscalerAccount) (*internal.HyperscalerAccount, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	old, found := s.accounts[account.Name]
+	if !found {
+		return nil, fmt.Errorf("account %s not found", account.Name)
+	}
+	if old.Version != account.Version {
+		return nil, fmt.Errorf("unable to update account %s - conflict", account.Name)
+	}
+	account.Version = account.Version + 1
+	s.accounts[account.Name] = account
+
+	return &account, nil
+}
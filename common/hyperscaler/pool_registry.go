@@ -0,0 +1,271 @@
+package hyperscaler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// SecretBindingBackend keeps the accounts as Gardener SecretBindings
+	SecretBindingBackend = "secretbinding"
+	// FileBackend reads the accounts from the YAML file
+	FileBackend = "file"
+	// DatabaseBackend keeps the accounts in the KEB database
+	DatabaseBackend = "db"
+
+	// GardenerUsageCounter counts the shoots using the accounts of the file and db backends
+	GardenerUsageCounter = "gardener"
+	// InstancesUsageCounter counts the KEB instances using the accounts of the file and db backends
+	InstancesUsageCounter = "instances"
+)
+
+type PoolConfig struct {
+	// Backend is used for the hyperscaler types not listed in Backends
+	Backend string `envconfig:"default=secretbinding"`
+	// Backends chooses the backend per hyperscaler type, e.g. "aws=db,openstack=file"
+	Backends PoolBackends `envconfig:"optional"`
+	// FilePath is the path to the YAML file with the accounts used by the file backend
+	FilePath string `envconfig:"optional"`
+	// UsageCounter chooses how the file and db backends count the clusters using the accounts, the shoots are counted if not set
+	UsageCounter string `envconfig:"default=gardener"`
+}
+
+// PoolBackends maps the hyperscaler type name to the backend name
+type PoolBackends map[string]string
+
+func (b *PoolBackends) Unmarshal(in string) error {
+	backends := PoolBackends{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		hyperscalerType, backend, found := strings.Cut(entry, "=")
+		if !found {
+			return fmt.Errorf("invalid account pool backend %q, expected <hyperscaler type>=<backend>", entry)
+		}
+		backends[strings.TrimSpace(hyperscalerType)] = strings.TrimSpace(backend)
+	}
+	*b = backends
+	return nil
+}
+
+// Validate checks if all the configured backends are known
+func (c PoolConfig) Validate() error {
+	for _, backend := range append([]string{c.Backend}, c.backendNames()...) {
+		switch backend {
+		case SecretBindingBackend, DatabaseBackend:
+		case FileBackend:
+			if c.FilePath == "" {
+				return fmt.Errorf("the file account pool backend requires the file path")
+			}
+		default:
+			return fmt.Errorf("unknown account pool backend %q", backend)
+		}
+	}
+	switch c.UsageCounter {
+	case "", GardenerUsageCounter, InstancesUsageCounter:
+	default:
+		return fmt.Errorf("unknown account pool usage counter %q", c.UsageCounter)
+	}
+	return nil
+}
+
+// BackendFor returns the name of the backend used for the hyperscaler type
+func (c PoolConfig) BackendFor(hyperscalerType Type) string {
+	if backend, found := c.Backends[hyperscalerType.GetName()]; found {
+		return backend
+	}
+	return c.Backend
+}
+
+// UsedBackends returns the names of all the configured backends
+func (c PoolConfig) UsedBackends() []string {
+	used := []string{c.Backend}
+	for _, backend := range c.backendNames() {
+		if !slices.Contains(used, backend) {
+			used = append(used, backend)
+		}
+	}
+	return used
+}
+
+func (c PoolConfig) backendNames() []string {
+	var names []string
+	for _, backend := range c.Backends {
+		names = append(names, backend)
+	}
+	return names
+}
+
+type pools struct {
+	accountPool AccountPool
+	sharedPool  SharedPool
+}
+
+// PoolRegistry routes the account pool calls to the backend configured for the hyperscaler type
+type PoolRegistry struct {
+	config   PoolConfig
+	backends map[string]pools
+}
+
+func NewPoolRegistry(config PoolConfig) *PoolRegistry {
+	return &PoolRegistry{
+		config:   config,
+		backends: map[string]pools{},
+	}
+}
+
+// NewConfiguredPoolRegistry registers the account pool backends used by the configuration, the backends not used are not created
+func NewConfiguredPoolRegistry(cfg PoolConfig, gardenerClient dynamic.Interface, gardenerNamespace string, accounts AccountStore, instances InstanceLister) (*PoolRegistry, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	registry := NewPoolRegistry(cfg)
+	for _, backend := range cfg.UsedBackends() {
+		switch backend {
+		case SecretBindingBackend:
+			registry.Register(backend,
+				NewAccountPool(gardenerClient, gardenerNamespace),
+				NewSharedGardenerAccountPool(gardenerClient, gardenerNamespace))
+		case FileBackend:
+			store, err := NewFileAccountStore(cfg.FilePath)
+			if err != nil {
+				return nil, err
+			}
+			pool := NewStoreAccountPool(store, newUsageCounter(cfg, store, gardenerClient, gardenerNamespace, instances), gardenerNamespace)
+			registry.Register(backend, pool, pool)
+		case DatabaseBackend:
+			pool := NewStoreAccountPool(accounts, newUsageCounter(cfg, accounts, gardenerClient, gardenerNamespace, instances), gardenerNamespace)
+			registry.Register(backend, pool, pool)
+		}
+	}
+	return registry, nil
+}
+
+// newUsageCounter returns the usage counter of the file and db backends, the instances counter does not need Gardener
+func newUsageCounter(cfg PoolConfig, store AccountStore, gardenerClient dynamic.Interface, gardenerNamespace string, instances InstanceLister) UsageCounter {
+	if cfg.UsageCounter == InstancesUsageCounter {
+		return NewInstanceUsageCounter(store, instances)
+	}
+	return NewSecretBindingUsageCounter(gardenerClient, gardenerNamespace)
+}
+
+// Register adds the implementation of the backend
+func (r *PoolRegistry) Register(backend string, accountPool AccountPool, sharedPool SharedPool) {
+	r.backends[backend] = pools{accountPool: accountPool, sharedPool: sharedPool}
+}
+
+func (r *PoolRegistry) backendFor(hyperscalerType Type) (pools, error) {
+	name := r.config.BackendFor(hyperscalerType)
+	backend, found := r.backends[name]
+	if !found {
+		return pools{}, fmt.Errorf("account pool backend %q for hyperscaler %s is not registered", name, hyperscalerType.GetKey())
+	}
+	return backend, nil
+}
+
+func (r *PoolRegistry) CredentialsSecretBinding(hyperscalerType Type, tenantName string, euAccess bool) (*gardener.SecretBinding, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return nil, err
+	}
+	return backend.accountPool.CredentialsSecretBinding(hyperscalerType, tenantName, euAccess)
+}
+
+func (r *PoolRegistry) MarkSecretBindingAsDirty(hyperscalerType Type, tenantName string, euAccess bool) error {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return err
+	}
+	return backend.accountPool.MarkSecretBindingAsDirty(hyperscalerType, tenantName, euAccess)
+}
+
+func (r *PoolRegistry) IsSecretBindingUsed(hyperscalerType Type, tenantName string, euAccess bool) (bool, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return false, err
+	}
+	return backend.accountPool.IsSecretBindingUsed(hyperscalerType, tenantName, euAccess)
+}
+
+func (r *PoolRegistry) IsSecretBindingDirty(hyperscalerType Type, tenantName string, euAccess bool) (bool, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return false, err
+	}
+	return backend.accountPool.IsSecretBindingDirty(hyperscalerType, tenantName, euAccess)
+}
+
+func (r *PoolRegistry) IsSecretBindingInternal(hyperscalerType Type, tenantName string, euAccess bool) (bool, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return false, err
+	}
+	return backend.accountPool.IsSecretBindingInternal(hyperscalerType, tenantName, euAccess)
+}
+
+func (r *PoolRegistry) SharedCredentialsSecretBinding(hyperscalerType Type, euAccess bool) (*gardener.SecretBinding, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return nil, err
+	}
+	return backend.sharedPool.SharedCredentialsSecretBinding(hyperscalerType, euAccess)
+}
+
+func (r *PoolRegistry) Status(hyperscalerType Type, euAccess bool) (PoolStatus, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return PoolStatus{}, err
+	}
+	return backend.accountPool.Status(hyperscalerType, euAccess)
+}
+
+func (r *PoolRegistry) SharedUsage(hyperscalerType Type) ([]SharedAccountUsage, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return nil, err
+	}
+	return backend.sharedPool.SharedUsage(hyperscalerType)
+}
+
+func (r *PoolRegistry) DirtyAccounts(hyperscalerType Type) ([]DirtyAccount, error) {
+	recycler, err := r.recyclerFor(hyperscalerType)
+	if err != nil {
+		return nil, err
+	}
+	return recycler.DirtyAccounts(hyperscalerType)
+}
+
+func (r *PoolRegistry) ReturnToPool(hyperscalerType Type, account DirtyAccount) error {
+	recycler, err := r.recyclerFor(hyperscalerType)
+	if err != nil {
+		return err
+	}
+	return recycler.ReturnToPool(hyperscalerType, account)
+}
+
+func (r *PoolRegistry) FlagForManualCleanup(hyperscalerType Type, account DirtyAccount) error {
+	recycler, err := r.recyclerFor(hyperscalerType)
+	if err != nil {
+		return err
+	}
+	return recycler.FlagForManualCleanup(hyperscalerType, account)
+}
+
+func (r *PoolRegistry) recyclerFor(hyperscalerType Type) (AccountRecycler, error) {
+	backend, err := r.backendFor(hyperscalerType)
+	if err != nil {
+		return nil, err
+	}
+	recycler, ok := backend.accountPool.(AccountRecycler)
+	if !ok {
+		return nil, fmt.Errorf("account pool backend %q for hyperscaler %s does not support recycling", r.config.BackendFor(hyperscalerType), hyperscalerType.GetKey())
+	}
+	return recycler, nil
+}
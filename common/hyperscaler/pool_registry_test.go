@@ -0,0 +1,75 @@
+package hyperscaler
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolBackends_Unmarshal(t *testing.T) {
+	t.Run("should parse backends", func(t *testing.T) {
+		// given
+		var backends PoolBackends
+
+		// when
+		err := backends.Unmarshal("aws=db, openstack = file,,")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, PoolBackends{"aws": DatabaseBackend, "openstack": FileBackend}, backends)
+	})
+
+	t.Run("should return error for invalid entry", func(t *testing.T) {
+		// given
+		var backends PoolBackends
+
+		// when
+		err := backends.Unmarshal("aws")
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestPoolConfig_Validate(t *testing.T) {
+	assert.NoError(t, PoolConfig{Backend: SecretBindingBackend}.Validate())
+	assert.NoError(t, PoolConfig{Backend: SecretBindingBackend, Backends: PoolBackends{"aws": FileBackend}, FilePath: "accounts.yaml"}.Validate())
+	assert.EqualError(t, PoolConfig{Backend: SecretBindingBackend, Backends: PoolBackends{"aws": FileBackend}}.Validate(), "the file account pool backend requires the file path")
+	assert.EqualError(t, PoolConfig{Backend: "vault"}.Validate(), `unknown account pool backend "vault"`)
+	assert.EqualError(t, PoolConfig{Backend: SecretBindingBackend, UsageCounter: "vault"}.Validate(), `unknown account pool usage counter "vault"`)
+}
+
+func TestPoolRegistry(t *testing.T) {
+	// given
+	config := PoolConfig{Backend: SecretBindingBackend, Backends: PoolBackends{"aws": FileBackend, "azure": DatabaseBackend}}
+	assert.ElementsMatch(t, []string{SecretBindingBackend, FileBackend, DatabaseBackend}, config.UsedBackends())
+
+	filePool := newTestStoreAccountPool(t, nil)
+	gardenerPool := newTestAccountPool()
+
+	registry := NewPoolRegistry(config)
+	registry.Register(FileBackend, filePool, filePool)
+	registry.Register(SecretBindingBackend, gardenerPool, NewSharedGardenerAccountPool(gardener.NewDynamicFakeClient(), testNamespace))
+
+	t.Run("should route to the backend configured for the hyperscaler type", func(t *testing.T) {
+		// when
+		awsBinding, err := registry.CredentialsSecretBinding(AWS(), "tenant1", false)
+		require.NoError(t, err)
+		gcpBinding, err := registry.CredentialsSecretBinding(GCP(), "tenant1", false)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "aws-1", awsBinding.GetName())
+		assert.Equal(t, "secretBinding1", gcpBinding.GetName())
+	})
+
+	t.Run("should return error for the backend not registered", func(t *testing.T) {
+		// when
+		_, err := registry.CredentialsSecretBinding(Azure(), "tenant1", false)
+
+		// then
+		assert.EqualError(t, err, `account pool backend "db" for hyperscaler azure is not registered`)
+	})
+}
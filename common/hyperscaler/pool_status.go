@@ -0,0 +1,75 @@
+package hyperscaler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PoolStatus describes the capacity of the account pool for the hyperscaler type and the EU access flag
+type PoolStatus struct {
+	HyperscalerType string `json:"hyperscalerType"`
+	EUAccess        bool   `json:"euAccess"`
+	// Free is the number of accounts which can be assigned to a new tenant
+	Free int `json:"free"`
+	// Used is the number of accounts assigned to tenants and not marked as dirty
+	Used     int `json:"used"`
+	Dirty    int `json:"dirty"`
+	Internal int `json:"internal"`
+	Shared   int `json:"shared"`
+	// LeastUsedShared lists the shared accounts, the least used first
+	LeastUsedShared []SharedAccountUsage `json:"leastUsedShared"`
+}
+
+// SharedAccountUsage is the number of shoots which use the shared account
+type SharedAccountUsage struct {
+	Name   string `json:"name"`
+	Shoots int    `json:"shoots"`
+}
+
+func newPoolStatus(hyperscalerType Type, euAccess bool) PoolStatus {
+	return PoolStatus{
+		HyperscalerType: hyperscalerType.GetKey(),
+		EUAccess:        euAccess,
+		LeastUsedShared: []SharedAccountUsage{},
+	}
+}
+
+// add counts the account with the given state using the same rules as the account pools when assigning accounts
+func (s *PoolStatus) add(shared bool, tenantName string, dirty, internal bool) {
+	if internal {
+		s.Internal++
+	}
+	switch {
+	case shared:
+		s.Shared++
+	case dirty:
+		s.Dirty++
+	case tenantName != "":
+		s.Used++
+	default:
+		s.Free++
+	}
+}
+
+// sortByUsage orders the shared accounts from the least used one, the accounts with the same usage keep their order
+func sortByUsage(usage []SharedAccountUsage) {
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].Shoots < usage[j].Shoots
+	})
+}
+
+// ParseType returns the hyperscaler type of the given key, e.g. "aws" or "openstack_eu-de-1"
+func ParseType(key string) (Type, error) {
+	switch {
+	case key == GCP().GetKey():
+		return GCP(), nil
+	case key == Azure().GetKey():
+		return Azure(), nil
+	case key == AWS().GetKey():
+		return AWS(), nil
+	case strings.HasPrefix(key, "openstack_"):
+		return SapConvergedCloud(strings.TrimPrefix(key, "openstack_")), nil
+	}
+	return Type{}, fmt.Errorf("unknown hyperscaler type %q", key)
+}
@@ -0,0 +1,91 @@
+package hyperscaler
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretBindingsAccountPool_Status(t *testing.T) {
+	// given
+	assigned := newSecretBinding("sb2", "s2", "aws", false, false)
+	assigned.SetLabels(map[string]string{"hyperscalerType": "aws", "tenantName": "tenant1", "internal": "true"})
+	dirty := newSecretBinding("sb3", "s3", "aws", false, false)
+	dirty.SetLabels(map[string]string{"hyperscalerType": "aws", "tenantName": "tenant2", "dirty": "true"})
+	gardenerFake := gardener.NewDynamicFakeClient(
+		newSecretBinding("sb1", "s1", "aws", false, false),
+		assigned,
+		dirty,
+		newSecretBinding("sb4", "s4", "aws", true, false),
+		newSecretBinding("sb5", "s5", "aws", false, true),
+		newSecretBinding("sb6", "s6", "gcp", false, false),
+	)
+	pool := NewAccountPool(gardenerFake, testNamespace)
+
+	// when
+	status, err := pool.Status(AWS(), false)
+	require.NoError(t, err)
+	euStatus, err := pool.Status(AWS(), true)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, PoolStatus{HyperscalerType: "aws", Free: 1, Used: 1, Dirty: 1, Internal: 1, Shared: 1, LeastUsedShared: []SharedAccountUsage{}}, status)
+	assert.Equal(t, PoolStatus{HyperscalerType: "aws", EUAccess: true, Free: 1, LeastUsedShared: []SharedAccountUsage{}}, euStatus)
+}
+
+func TestSharedPool_SharedUsage(t *testing.T) {
+	// given
+	gardenerFake := gardener.NewDynamicFakeClient(
+		newSecretBinding("sb1", "s1", "aws", true, false),
+		newSecretBinding("sb2", "s2", "aws", true, false),
+		newSecretBinding("sb3", "s3", "aws", true, false),
+		newSecretBinding("sb4", "s4", "gcp", true, false),
+		newShoot("sh1", "sb1"),
+		newShoot("sh2", "sb1"),
+		newShoot("sh3", "sb3"),
+	)
+	pool := NewSharedGardenerAccountPool(gardenerFake, testNamespace)
+
+	// when
+	usage, err := pool.SharedUsage(AWS())
+	require.NoError(t, err)
+	empty, err := pool.SharedUsage(Azure())
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []SharedAccountUsage{{Name: "sb2", Shoots: 0}, {Name: "sb3", Shoots: 1}, {Name: "sb1", Shoots: 2}}, usage)
+	assert.Empty(t, empty)
+}
+
+func TestStoreAccountPool_Status(t *testing.T) {
+	// given
+	pool := newTestStoreAccountPool(t, fakeUsageCounter{"gcp-shared-1": 2})
+
+	// when
+	status, err := pool.Status(AWS(), false)
+	require.NoError(t, err)
+	usage, err := pool.SharedUsage(GCP())
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, PoolStatus{HyperscalerType: "aws", Free: 1, Used: 1, Dirty: 1, Internal: 1, LeastUsedShared: []SharedAccountUsage{}}, status)
+	assert.Equal(t, []SharedAccountUsage{{Name: "gcp-shared-2", Shoots: 0}, {Name: "gcp-shared-1", Shoots: 2}}, usage)
+}
+
+func TestParseType(t *testing.T) {
+	for key, expected := range map[string]Type{
+		"gcp":               GCP(),
+		"azure":             Azure(),
+		"aws":               AWS(),
+		"openstack_eu-de-1": SapConvergedCloud("eu-de-1"),
+	} {
+		hyperscalerType, err := ParseType(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, hyperscalerType)
+	}
+
+	_, err := ParseType("alicloud")
+	assert.EqualError(t, err, `unknown hyperscaler type "alicloud"`)
+}
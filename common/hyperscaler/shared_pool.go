@@ -12,6 +12,7 @@ import (
 
 type SharedPool interface {
 	SharedCredentialsSecretBinding(hyperscalerType Type, euAccess bool) (*gardener.SecretBinding, error)
+	SharedUsage(hyperscalerType Type) ([]SharedAccountUsage, error)
 }
 
 func NewSharedGardenerAccountPool(gardenerClient dynamic.Interface, gardenerNamespace string) SharedPool {
@@ -36,6 +37,22 @@ func (sp *sharedAccountPool) SharedCredentialsSecretBinding(hyperscalerType Type
 	return sp.getLeastUsed(secretBindings)
 }
 
+// SharedUsage returns the shared secret bindings of the hyperscaler type, the least used first
+func (sp *sharedAccountPool) SharedUsage(hyperscalerType Type) ([]SharedAccountUsage, error) {
+	labelSelector := fmt.Sprintf("shared=true,hyperscalerType=%s", hyperscalerType.GetKey())
+	secretBindings, err := sp.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(sp.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing secret bindings for %s label selector: %w", labelSelector, err)
+	}
+	if len(secretBindings.Items) == 0 {
+		return []SharedAccountUsage{}, nil
+	}
+
+	return sp.countUsage(secretBindings.Items)
+}
+
 func (sp *sharedAccountPool) getSecretBindings(labelSelector string) ([]unstructured.Unstructured, error) {
 	secretBindings, err := sp.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(sp.namespace).List(context.Background(), metav1.ListOptions{
 		LabelSelector: labelSelector,
@@ -53,6 +70,21 @@ func (sp *sharedAccountPool) getSecretBindings(labelSelector string) ([]unstruct
 }
 
 func (sp *sharedAccountPool) getLeastUsed(secretBindings []unstructured.Unstructured) (*gardener.SecretBinding, error) {
+	usage, err := sp.countUsage(secretBindings)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sb := range secretBindings {
+		if sb.GetName() == usage[0].Name {
+			return &gardener.SecretBinding{sb}, nil
+		}
+	}
+	return &gardener.SecretBinding{secretBindings[0]}, nil
+}
+
+// countUsage returns the number of shoots using the secret bindings, the least used first
+func (sp *sharedAccountPool) countUsage(secretBindings []unstructured.Unstructured) ([]SharedAccountUsage, error) {
 	usageCount := make(map[string]int, len(secretBindings))
 	for _, s := range secretBindings {
 		usageCount[s.GetName()] = 0
@@ -63,29 +95,23 @@ func (sp *sharedAccountPool) getLeastUsed(secretBindings []unstructured.Unstruct
 		return nil, fmt.Errorf("error while listing Shoots: %w", err)
 	}
 
-	if shoots == nil || len(shoots.Items) == 0 {
-		return &gardener.SecretBinding{secretBindings[0]}, nil
-	}
+	if shoots != nil {
+		for _, shoot := range shoots.Items {
+			s := gardener.Shoot{shoot}
+			count, found := usageCount[s.GetSpecSecretBindingName()]
+			if !found {
+				continue
+			}
 
-	for _, shoot := range shoots.Items {
-		s := gardener.Shoot{shoot}
-		count, found := usageCount[s.GetSpecSecretBindingName()]
-		if !found {
-			continue
+			usageCount[s.GetSpecSecretBindingName()] = count + 1
 		}
-
-		usageCount[s.GetSpecSecretBindingName()] = count + 1
 	}
 
-	min := usageCount[secretBindings[0].GetName()]
-	minIndex := 0
-
-	for i, sb := range secretBindings {
-		if usageCount[sb.GetName()] < min {
-			min = usageCount[sb.GetName()]
-			minIndex = i
-		}
+	usage := make([]SharedAccountUsage, 0, len(secretBindings))
+	for _, sb := range secretBindings {
+		usage = append(usage, SharedAccountUsage{Name: sb.GetName(), Shoots: usageCount[sb.GetName()]})
 	}
+	sortByUsage(usage)
 
-	return &gardener.SecretBinding{secretBindings[minIndex]}, nil
+	return usage, nil
 }
@@ -0,0 +1,341 @@
+package hyperscaler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+)
+
+// maxUpdateAttempts limits the updates of the accounts repeated after the conflicts with other KEB instances
+const maxUpdateAttempts = 5
+
+// AccountStore keeps the accounts of the pool which are not stored as Gardener bindings
+type AccountStore interface {
+	List(hyperscalerType string) ([]internal.HyperscalerAccount, error)
+	Update(account internal.HyperscalerAccount) (*internal.HyperscalerAccount, error)
+}
+
+// UsageCounter returns the number of clusters which use the account, by the account name
+type UsageCounter interface {
+	CountUsage() (map[string]int, error)
+}
+
+// NewStoreAccountPool returns the account pool which keeps the accounts in the given store. The usage counter is optional,
+// without it none of the accounts is considered used.
+func NewStoreAccountPool(store AccountStore, usage UsageCounter, namespace string) *storeAccountPool {
+	return &storeAccountPool{
+		store:     store,
+		usage:     usage,
+		namespace: namespace,
+	}
+}
+
+type storeAccountPool struct {
+	store     AccountStore
+	usage     UsageCounter
+	namespace string
+	mux       sync.Mutex
+}
+
+type accountPredicate func(account internal.HyperscalerAccount) bool
+
+func (p *storeAccountPool) CredentialsSecretBinding(hyperscalerType Type, tenantName string, euAccess bool) (*gardener.SecretBinding, error) {
+	// the account claimed by another KEB instance in the meantime causes the conflict, the claim is repeated with fresh accounts
+	for attempt := 1; ; attempt++ {
+		binding, err := p.claimAccount(hyperscalerType, tenantName, euAccess)
+		if isConflict(err) && attempt < maxUpdateAttempts {
+			continue
+		}
+		return binding, err
+	}
+}
+
+func (p *storeAccountPool) claimAccount(hyperscalerType Type, tenantName string, euAccess bool) (*gardener.SecretBinding, error) {
+	account, err := p.find(hyperscalerType, euAccess, func(a internal.HyperscalerAccount) bool {
+		return a.TenantName == tenantName && !a.Dirty
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting account: %w", err)
+	}
+	if account != nil {
+		return p.toSecretBinding(*account), nil
+	}
+
+	// lock so that only one thread can fetch an unassigned account and assign it
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	account, err = p.find(hyperscalerType, euAccess, func(a internal.HyperscalerAccount) bool {
+		return !a.Shared && a.TenantName == "" && !a.Dirty
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting account: %w", err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("failed to find unassigned account for hyperscalerType: %s", hyperscalerType.GetKey())
+	}
+
+	account.TenantName = tenantName
+	account.UpdatedAt = time.Now()
+	updated, err := p.store.Update(*account)
+	if err != nil {
+		return nil, fmt.Errorf("updating account with tenantName: %s: %w", tenantName, err)
+	}
+
+	return p.toSecretBinding(*updated), nil
+}
+
+func (p *storeAccountPool) MarkSecretBindingAsDirty(hyperscalerType Type, tenantName string, euAccess bool) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		err := p.markAsDirty(hyperscalerType, tenantName, euAccess)
+		if isConflict(err) && attempt < maxUpdateAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+func (p *storeAccountPool) markAsDirty(hyperscalerType Type, tenantName string, euAccess bool) error {
+	account, err := p.find(hyperscalerType, euAccess, func(a internal.HyperscalerAccount) bool {
+		return !a.Shared && a.TenantName == tenantName
+	})
+	if err != nil {
+		return fmt.Errorf("marking account as dirty: failed to find account used by the tenant %s and hyperscaler %s: %w", tenantName, hyperscalerType.GetKey(), err)
+	}
+	// if there is no matching account - do nothing
+	if account == nil {
+		return nil
+	}
+
+	account.Dirty = true
+	account.UpdatedAt = time.Now()
+	_, err = p.store.Update(*account)
+	if err != nil {
+		return fmt.Errorf("marking account as dirty: failed to update account for tenant: %s and hyperscaler: %s: %w", tenantName, hyperscalerType.GetKey(), err)
+	}
+	return nil
+}
+
+func (p *storeAccountPool) IsSecretBindingUsed(hyperscalerType Type, tenantName string, euAccess bool) (bool, error) {
+	account, err := p.find(hyperscalerType, euAccess, func(a internal.HyperscalerAccount) bool {
+		return a.TenantName == tenantName
+	})
+	if err != nil {
+		return false, fmt.Errorf("counting subscription usage: could not find account used by the tenant %s and hyperscaler %s: %w", tenantName, hyperscalerType.GetKey(), err)
+	}
+	if account == nil || p.usage == nil {
+		return false, nil
+	}
+
+	usage, err := p.usage.CountUsage()
+	if err != nil {
+		return false, fmt.Errorf("counting account usage: %w", err)
+	}
+	return usage[account.Name] > 0, nil
+}
+
+func (p *storeAccountPool) IsSecretBindingDirty(hyperscalerType Type, tenantName string, euAccess bool) (bool, error) {
+	account, err := p.find(hyperscalerType, euAccess, func(a internal.HyperscalerAccount) bool {
+		return !a.Shared && a.Dirty && a.TenantName == tenantName
+	})
+	if err != nil {
+		return false, fmt.Errorf("looking for an account used by the tenant %s and hyperscaler %s: %w", tenantName, hyperscalerType.GetKey(), err)
+	}
+	return account != nil, nil
+}
+
+func (p *storeAccountPool) IsSecretBindingInternal(hyperscalerType Type, tenantName string, euAccess bool) (bool, error) {
+	account, err := p.find(hyperscalerType, euAccess, func(a internal.HyperscalerAccount) bool {
+		return a.Internal && a.TenantName == tenantName
+	})
+	if err != nil {
+		return false, fmt.Errorf("looking for an account used by the tenant %s and hyperscaler %s: %w", tenantName, hyperscalerType.GetKey(), err)
+	}
+	return account != nil, nil
+}
+
+func (p *storeAccountPool) SharedCredentialsSecretBinding(hyperscalerType Type, euAccess bool) (*gardener.SecretBinding, error) {
+	accounts, err := p.store.List(hyperscalerType.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts: %w", err)
+	}
+	usage, err := p.countSharedUsage(accounts)
+	if err != nil {
+		return nil, err
+	}
+	if len(usage) == 0 {
+		return nil, fmt.Errorf("no shared account found for hyperscaler %s", hyperscalerType.GetKey())
+	}
+
+	for _, account := range accounts {
+		if account.Name == usage[0].Name {
+			return p.toSecretBinding(account), nil
+		}
+	}
+	return nil, fmt.Errorf("shared account %s not found", usage[0].Name)
+}
+
+func (p *storeAccountPool) Status(hyperscalerType Type, euAccess bool) (PoolStatus, error) {
+	accounts, err := p.store.List(hyperscalerType.GetKey())
+	if err != nil {
+		return PoolStatus{}, fmt.Errorf("listing accounts: %w", err)
+	}
+	status := newPoolStatus(hyperscalerType, euAccess)
+	for _, account := range accounts {
+		if account.EUAccess == euAccess {
+			status.add(account.Shared, account.TenantName, account.Dirty, account.Internal)
+		}
+	}
+	return status, nil
+}
+
+// SharedUsage returns the shared accounts of the hyperscaler type, the least used first
+func (p *storeAccountPool) SharedUsage(hyperscalerType Type) ([]SharedAccountUsage, error) {
+	accounts, err := p.store.List(hyperscalerType.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts: %w", err)
+	}
+	return p.countSharedUsage(accounts)
+}
+
+func (p *storeAccountPool) countSharedUsage(accounts []internal.HyperscalerAccount) ([]SharedAccountUsage, error) {
+	sortAccounts(accounts)
+
+	var err error
+	counts := map[string]int{}
+	if p.usage != nil {
+		counts, err = p.usage.CountUsage()
+		if err != nil {
+			return nil, fmt.Errorf("counting account usage: %w", err)
+		}
+	}
+	usage := make([]SharedAccountUsage, 0)
+	for _, account := range accounts {
+		if account.Shared {
+			usage = append(usage, SharedAccountUsage{Name: account.Name, Shoots: counts[account.Name]})
+		}
+	}
+	sortByUsage(usage)
+
+	return usage, nil
+}
+
+func (p *storeAccountPool) DirtyAccounts(hyperscalerType Type) ([]DirtyAccount, error) {
+	accounts, err := p.store.List(hyperscalerType.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts: %w", err)
+	}
+	sortAccounts(accounts)
+
+	dirty := make([]DirtyAccount, 0)
+	for _, account := range accounts {
+		if account.Dirty {
+			dirty = append(dirty, DirtyAccount{
+				Name:            account.Name,
+				HyperscalerType: account.HyperscalerType,
+				TenantName:      account.TenantName,
+				EUAccess:        account.EUAccess,
+				Shared:          account.Shared,
+				ManualCleanup:   account.ManualCleanup,
+			})
+		}
+	}
+	return dirty, nil
+}
+
+func (p *storeAccountPool) ReturnToPool(hyperscalerType Type, account DirtyAccount) error {
+	return p.updateDirtyAccount(hyperscalerType, account, func(a *internal.HyperscalerAccount) {
+		a.Dirty = false
+		a.TenantName = ""
+		a.ManualCleanup = false
+	})
+}
+
+func (p *storeAccountPool) FlagForManualCleanup(hyperscalerType Type, account DirtyAccount) error {
+	return p.updateDirtyAccount(hyperscalerType, account, func(a *internal.HyperscalerAccount) {
+		a.ManualCleanup = true
+	})
+}
+
+// updateDirtyAccount changes the account if it is still dirty and assigned to the tenant, the update fails with the
+// conflict if the account is changed by another KEB instance in the meantime
+func (p *storeAccountPool) updateDirtyAccount(hyperscalerType Type, account DirtyAccount, update func(a *internal.HyperscalerAccount)) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	stored, err := p.find(hyperscalerType, account.EUAccess, func(a internal.HyperscalerAccount) bool {
+		return a.Name == account.Name
+	})
+	if err != nil {
+		return fmt.Errorf("getting account %s: %w", account.Name, err)
+	}
+	if stored == nil || !stored.Dirty || stored.TenantName != account.TenantName {
+		return fmt.Errorf("account %s is no longer dirty or assigned to the tenant %s", account.Name, account.TenantName)
+	}
+
+	update(stored)
+	stored.UpdatedAt = time.Now()
+	if _, err := p.store.Update(*stored); err != nil {
+		return fmt.Errorf("updating account %s: %w", account.Name, err)
+	}
+	return nil
+}
+
+// find returns the first account, by name, of the hyperscaler type matching the predicate and the EU access
+func (p *storeAccountPool) find(hyperscalerType Type, euAccess bool, predicate accountPredicate) (*internal.HyperscalerAccount, error) {
+	accounts, err := p.store.List(hyperscalerType.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts: %w", err)
+	}
+	sortAccounts(accounts)
+	for _, account := range accounts {
+		if account.EUAccess == euAccess && predicate(account) {
+			return &account, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *storeAccountPool) toSecretBinding(account internal.HyperscalerAccount) *gardener.SecretBinding {
+	labels := map[string]string{"hyperscalerType": account.HyperscalerType}
+	if account.TenantName != "" {
+		labels["tenantName"] = account.TenantName
+	}
+	if account.Shared {
+		labels["shared"] = "true"
+	}
+	if account.Dirty {
+		labels["dirty"] = "true"
+	}
+	if account.Internal {
+		labels["internal"] = "true"
+	}
+	if account.EUAccess {
+		labels["euAccess"] = "true"
+	}
+	if account.ManualCleanup {
+		labels[ManualCleanupLabel] = "true"
+	}
+	return gardener.NewSecretBinding(account.Name, p.namespace, account.SecretName, labels)
+}
+
+// isConflict checks if the account update failed because the account was updated by another KEB instance
+func isConflict(err error) bool {
+	var dbErr dberr.Error
+	return errors.As(err, &dbErr) && dbErr.Code() == dberr.CodeConflict
+}
+
+func sortAccounts(accounts []internal.HyperscalerAccount) {
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Name < accounts[j].Name
+	})
+}
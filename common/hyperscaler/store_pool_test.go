@@ -0,0 +1,264 @@
+package hyperscaler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/driver/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccountsFile = `
+accounts:
+  - name: aws-1
+    secretName: secret-aws-1
+    hyperscalerType: aws
+    tenantName: tenant1
+  - name: aws-2
+    secretName: secret-aws-2
+    hyperscalerType: aws
+  - name: aws-3
+    secretName: secret-aws-3
+    hyperscalerType: aws
+    euAccess: true
+  - name: aws-dirty
+    secretName: secret-aws-dirty
+    hyperscalerType: aws
+    tenantName: tenant2
+    dirty: true
+    internal: true
+  - name: gcp-shared-1
+    secretName: secret-gcp-shared-1
+    hyperscalerType: gcp
+    shared: true
+  - name: gcp-shared-2
+    secretName: secret-gcp-shared-2
+    hyperscalerType: gcp
+    shared: true
+`
+
+type fakeUsageCounter map[string]int
+
+func (c fakeUsageCounter) CountUsage() (map[string]int, error) {
+	return c, nil
+}
+
+func TestStoreAccountPool_CredentialsSecretBinding(t *testing.T) {
+	t.Run("should return the account assigned to the tenant", func(t *testing.T) {
+		// given
+		pool := newTestStoreAccountPool(t, nil)
+
+		// when
+		binding, err := pool.CredentialsSecretBinding(AWS(), "tenant1", false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "aws-1", binding.GetName())
+		assert.Equal(t, "secret-aws-1", binding.GetSecretRefName())
+		assert.Equal(t, testNamespace, binding.GetNamespace())
+	})
+
+	t.Run("should assign the free account to the tenant", func(t *testing.T) {
+		// given
+		pool := newTestStoreAccountPool(t, nil)
+
+		// when
+		binding, err := pool.CredentialsSecretBinding(AWS(), "tenant3", false)
+		require.NoError(t, err)
+		again, err := pool.CredentialsSecretBinding(AWS(), "tenant3", false)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "aws-2", binding.GetName())
+		assert.Equal(t, "tenant3", binding.GetLabels()["tenantName"])
+		assert.Equal(t, "aws-2", again.GetName())
+	})
+
+	t.Run("should assign the EU access account", func(t *testing.T) {
+		// given
+		pool := newTestStoreAccountPool(t, nil)
+
+		// when
+		binding, err := pool.CredentialsSecretBinding(AWS(), "tenant3", true)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "aws-3", binding.GetName())
+		assert.Equal(t, "true", binding.GetLabels()["euAccess"])
+	})
+
+	t.Run("should return error when there is no free account", func(t *testing.T) {
+		// given
+		pool := newTestStoreAccountPool(t, nil)
+
+		// when
+		_, err := pool.CredentialsSecretBinding(Azure(), "tenant3", false)
+
+		// then
+		assert.EqualError(t, err, "failed to find unassigned account for hyperscalerType: azure")
+	})
+}
+
+func TestStoreAccountPool_MarkSecretBindingAsDirty(t *testing.T) {
+	// given
+	pool := newTestStoreAccountPool(t, nil)
+
+	// when
+	err := pool.MarkSecretBindingAsDirty(AWS(), "tenant1", false)
+	require.NoError(t, err)
+
+	// then
+	dirty, err := pool.IsSecretBindingDirty(AWS(), "tenant1", false)
+	require.NoError(t, err)
+	assert.True(t, dirty)
+
+	// the dirty account is not returned to the tenant anymore
+	binding, err := pool.CredentialsSecretBinding(AWS(), "tenant1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "aws-2", binding.GetName())
+
+	err = pool.MarkSecretBindingAsDirty(AWS(), "not-existing", false)
+	assert.NoError(t, err)
+}
+
+func TestStoreAccountPool_Flags(t *testing.T) {
+	// given
+	pool := newTestStoreAccountPool(t, fakeUsageCounter{"aws-1": 1})
+
+	// when
+	used, err := pool.IsSecretBindingUsed(AWS(), "tenant1", false)
+	require.NoError(t, err)
+	unused, err := pool.IsSecretBindingUsed(AWS(), "tenant2", false)
+	require.NoError(t, err)
+	internal, err := pool.IsSecretBindingInternal(AWS(), "tenant2", false)
+	require.NoError(t, err)
+	dirty, err := pool.IsSecretBindingDirty(AWS(), "tenant2", false)
+	require.NoError(t, err)
+
+	// then
+	assert.True(t, used)
+	assert.False(t, unused)
+	assert.True(t, internal)
+	assert.True(t, dirty)
+}
+
+func TestStoreAccountPool_SharedCredentialsSecretBinding(t *testing.T) {
+	t.Run("should return the least used shared account", func(t *testing.T) {
+		// given
+		pool := newTestStoreAccountPool(t, fakeUsageCounter{"gcp-shared-1": 3, "gcp-shared-2": 1})
+
+		// when
+		binding, err := pool.SharedCredentialsSecretBinding(GCP(), false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gcp-shared-2", binding.GetName())
+		assert.Equal(t, "true", binding.GetLabels()["shared"])
+	})
+
+	t.Run("should return error when there is no shared account", func(t *testing.T) {
+		// given
+		pool := newTestStoreAccountPool(t, nil)
+
+		// when
+		_, err := pool.SharedCredentialsSecretBinding(AWS(), false)
+
+		// then
+		assert.EqualError(t, err, "no shared account found for hyperscaler aws")
+	})
+}
+
+func TestNewFileAccountStore(t *testing.T) {
+	for name, content := range map[string]string{
+		"missing secret name": "accounts:\n  - name: a\n    hyperscalerType: aws\n",
+		"duplicated account":  "accounts:\n  - name: a\n    secretName: s\n    hyperscalerType: aws\n  - name: a\n    secretName: s\n    hyperscalerType: aws\n",
+		"invalid YAML":        "accounts: {",
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			_, err := NewFileAccountStore(writeAccountsFile(t, content))
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func newTestStoreAccountPool(t *testing.T, usage UsageCounter) *storeAccountPool {
+	store, err := NewFileAccountStore(writeAccountsFile(t, testAccountsFile))
+	require.NoError(t, err)
+	return NewStoreAccountPool(store, usage, testNamespace)
+}
+
+func writeAccountsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "accounts.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestStoreAccountPool_Conflict(t *testing.T) {
+	// given
+	accounts := memory.NewHyperscalerAccounts()
+	for _, name := range []string{"aws-1", "aws-2"} {
+		require.NoError(t, accounts.Insert(internal.HyperscalerAccount{Name: name, SecretName: "secret-" + name, HyperscalerType: "aws"}))
+	}
+	pool := NewStoreAccountPool(&claimingStore{HyperscalerAccounts: accounts, tenantName: "other-tenant"}, nil, testNamespace)
+
+	// when
+	binding, err := pool.CredentialsSecretBinding(AWS(), "tenant1", false)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "aws-2", binding.GetName())
+	assert.Equal(t, "tenant1", binding.GetLabels()["tenantName"])
+}
+
+func TestInstanceUsageCounter(t *testing.T) {
+	// given
+	store, err := NewFileAccountStore(writeAccountsFile(t, testAccountsFile))
+	require.NoError(t, err)
+	instances := fakeInstanceLister{
+		{InstanceID: "instance-1", GlobalAccountID: "tenant1", Provider: internal.AWS},
+		{InstanceID: "instance-2", GlobalAccountID: "other", SubscriptionGlobalAccountID: "tenant1", Provider: internal.AWS},
+		{InstanceID: "instance-3", GlobalAccountID: "tenant3", Provider: internal.AWS},
+		{InstanceID: "instance-4", GlobalAccountID: "tenant1"},
+	}
+	counter := NewInstanceUsageCounter(store, instances)
+
+	// when
+	usage, err := counter.CountUsage()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"aws-1": 2}, usage)
+}
+
+// claimingStore assigns the account to another tenant before the first update, as another KEB instance would do
+type claimingStore struct {
+	storage.HyperscalerAccounts
+	tenantName string
+	claimed    bool
+}
+
+func (s *claimingStore) Update(account internal.HyperscalerAccount) (*internal.HyperscalerAccount, error) {
+	if !s.claimed {
+		s.claimed = true
+		claimed := account
+		claimed.TenantName = s.tenantName
+		if _, err := s.HyperscalerAccounts.Update(claimed); err != nil {
+			return nil, err
+		}
+	}
+	return s.HyperscalerAccounts.Update(account)
+}
+
+type fakeInstanceLister []internal.Instance
+
+func (l fakeInstanceLister) List(_ dbmodel.InstanceFilter) ([]internal.Instance, int, int, error) {
+	return l, len(l), len(l), nil
+}
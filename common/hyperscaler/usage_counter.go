@@ -0,0 +1,106 @@
+package hyperscaler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// secretBindingUsageCounter counts the Gardener shoots which refer to the accounts by the SecretBinding name
+type secretBindingUsageCounter struct {
+	gardenerClient dynamic.Interface
+	namespace      string
+}
+
+func NewSecretBindingUsageCounter(gardenerClient dynamic.Interface, gardenerNamespace string) UsageCounter {
+	return &secretBindingUsageCounter{
+		gardenerClient: gardenerClient,
+		namespace:      gardenerNamespace,
+	}
+}
+
+func (c *secretBindingUsageCounter) CountUsage() (map[string]int, error) {
+	shoots, err := c.gardenerClient.Resource(gardener.ShootResource).Namespace(c.namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing Gardener shoots: %w", err)
+	}
+
+	usage := make(map[string]int)
+	for _, shoot := range shoots.Items {
+		name := gardener.Shoot{Unstructured: shoot}.GetSpecSecretBindingName()
+		if name != "" {
+			usage[name]++
+		}
+	}
+	return usage, nil
+}
+
+// InstanceLister lists the instances stored by KEB
+type InstanceLister interface {
+	List(filter dbmodel.InstanceFilter) ([]internal.Instance, int, int, error)
+}
+
+// the instances which can have a cluster, the instances being deprovisioned do not use the accounts anymore
+var instanceStatesUsingAccounts = []dbmodel.InstanceState{
+	dbmodel.InstanceSucceeded,
+	dbmodel.InstanceFailed,
+	dbmodel.InstanceError,
+	dbmodel.InstanceProvisioning,
+	dbmodel.InstanceUpgrading,
+	dbmodel.InstanceUpdating,
+}
+
+// instanceUsageCounter counts the KEB instances which use the accounts assigned to their tenants, so the pool does not
+// need Gardener. The instances do not record which shared account they use, so the shared accounts are not counted.
+type instanceUsageCounter struct {
+	store     AccountStore
+	instances InstanceLister
+}
+
+func NewInstanceUsageCounter(store AccountStore, instances InstanceLister) UsageCounter {
+	return &instanceUsageCounter{
+		store:     store,
+		instances: instances,
+	}
+}
+
+func (c *instanceUsageCounter) CountUsage() (map[string]int, error) {
+	instances, _, _, err := c.instances.List(dbmodel.InstanceFilter{States: instanceStatesUsingAccounts})
+	if err != nil {
+		return nil, fmt.Errorf("listing instances: %w", err)
+	}
+
+	accounts := map[string][]internal.HyperscalerAccount{}
+	usage := make(map[string]int)
+	for _, instance := range instances {
+		if instance.Provider == "" {
+			continue
+		}
+		hyperscalerType, err := HypTypeFromCloudProviderWithRegion(instance.Provider, &instance.ProviderRegion)
+		if err != nil {
+			continue
+		}
+		typeAccounts, found := accounts[hyperscalerType.GetKey()]
+		if !found {
+			typeAccounts, err = c.store.List(hyperscalerType.GetKey())
+			if err != nil {
+				return nil, fmt.Errorf("listing accounts: %w", err)
+			}
+			accounts[hyperscalerType.GetKey()] = typeAccounts
+		}
+		tenantName := instance.GetSubscriptionGlobalAccoundID()
+		euAccess := internal.IsEuAccess(instance.Parameters.PlatformRegion)
+		for _, account := range typeAccounts {
+			if !account.Shared && account.TenantName == tenantName && account.EUAccess == euAccess {
+				usage[account.Name]++
+				break
+			}
+		}
+	}
+	return usage, nil
+}
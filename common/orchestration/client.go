@@ -29,6 +29,7 @@ type Client interface {
 	UpgradeKyma(params Parameters) (UpgradeResponse, error)
 	UpgradeCluster(params Parameters) (UpgradeResponse, error)
 	CancelOrchestration(orchestrationID string) error
+	ResumeOrchestration(orchestrationID string) error
 	RetryOrchestration(orchestrationID string, operationIDs []string, now bool) (RetryResponse, error)
 }
 
@@ -102,8 +103,15 @@ func (c client) ListOrchestrations(params ListParameters) (StatusResponseList, e
 		orchestrations.Count += srl.Count
 		orchestrations.Data = append(orchestrations.Data, srl.Data...)
 		if getAll {
-			params.Page++
 			fetchedAll = orchestrations.Count >= orchestrations.TotalCount
+			switch {
+			case srl.NextCursor != "":
+				params.Cursor = srl.NextCursor
+			case params.Cursor == "":
+				params.Page++
+			default:
+				fetchedAll = true
+			}
 		} else {
 			fetchedAll = true
 		}
@@ -208,8 +216,15 @@ func (c client) ListOperations(orchestrationID string, params ListParameters) (O
 
 		operations.Data = append(operations.Data, orl.Data...)
 		if getAll {
-			params.Page++
 			fetchedAll = operations.Count >= operations.TotalCount
+			switch {
+			case orl.NextCursor != "":
+				params.Cursor = orl.NextCursor
+			case params.Cursor == "":
+				params.Page++
+			default:
+				fetchedAll = true
+			}
 		} else {
 			fetchedAll = true
 		}
@@ -429,9 +444,45 @@ func (c client) CancelOrchestration(orchestrationID string) error {
 	return nil
 }
 
+func (c client) ResumeOrchestration(orchestrationID string) error {
+	url := fmt.Sprintf("%s/orchestrations/%s/resume", c.url, orchestrationID)
+
+	req, err := http.NewRequest(http.MethodPut, url, nil)
+	if err != nil {
+		return fmt.Errorf("while creating resume request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling %s: %w", url, err)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling %s returned %s status", url, resp.Status)
+	}
+
+	return nil
+}
+
 func setQuery(url *url.URL, params ListParameters) {
 	query := url.Query()
-	query.Add(pagination.PageParam, strconv.Itoa(params.Page))
+	if params.Cursor != "" {
+		query.Add(pagination.CursorParam, params.Cursor)
+	} else {
+		query.Add(pagination.PageParam, strconv.Itoa(params.Page))
+	}
 	query.Add(pagination.PageSizeParam, strconv.Itoa(params.PageSize))
 	setParamList(query, StateParam, params.States)
 	url.RawQuery = query.Encode()
@@ -20,6 +20,34 @@ type Parameters struct {
 	RetryOperation RetryOperationParameters `json:"retryoperation,omitempty"`
 	// customer notification
 	Notification bool `json:"notification,omitempty"`
+	// FailureThreshold pauses the orchestration when too many operations fail
+	FailureThreshold *FailureThresholdParameters `json:"failureThreshold,omitempty"`
+}
+
+// FailureThresholdParameters defines the number of failed operations which pauses the orchestration.
+// If both Count and Percentage are set, the orchestration is paused when any of them is crossed.
+type FailureThresholdParameters struct {
+	// Count is the number of failed operations
+	Count int `json:"count,omitempty"`
+	// Percentage is the percentage of failed operations out of all operations of the orchestration
+	Percentage int `json:"percentage,omitempty"`
+	// IgnoredFailures is the number of failed operations at the time the orchestration was resumed, it is set by KEB
+	IgnoredFailures int `json:"ignoredFailures,omitempty"`
+}
+
+// Crossed returns true if the number of failed operations since the last resume crosses the threshold
+func (t *FailureThresholdParameters) Crossed(failed, total int) bool {
+	if t == nil || total == 0 {
+		return false
+	}
+	failed -= t.IgnoredFailures
+	if failed <= 0 {
+		return false
+	}
+	if t.Count > 0 && failed >= t.Count {
+		return true
+	}
+	return t.Percentage > 0 && failed*100 >= t.Percentage*total
 }
 
 type RetryOperationParameters struct {
@@ -48,6 +76,7 @@ const (
 	Pending    = "pending"
 	InProgress = "in progress"
 	Canceling  = "canceling"
+	Paused     = "paused"   // the failure threshold was crossed, pending operations are not scheduled until resume
 	Retrying   = "retrying" // to signal a retry sign before marking it to pending
 	Canceled   = "canceled"
 	Succeeded  = "succeeded"
@@ -58,7 +87,9 @@ const (
 type ListParameters struct {
 	Page     int
 	PageSize int
-	States   []string
+	// Cursor is the continuation token returned in the previous response, the Page is ignored if the Cursor is set
+	Cursor string
+	States []string
 }
 
 // TargetAll all SKRs provisioned successfully and not deprovisioning
@@ -83,6 +114,12 @@ type RuntimeTarget struct {
 	Shoot string `json:"shoot,omitempty"`
 	// InstanceID is used to identify an instance by it's instance ID
 	InstanceID string `json:"instanceID,omitempty"`
+	// KymaVersion is a semver constraint to match against the runtime's Kyma version. E.g. ">=2.0.0, <2.5.0"
+	KymaVersion string `json:"kymaVersion,omitempty"`
+	// KubernetesVersion is a semver constraint to match against the shoot cluster's Kubernetes version. E.g. "~1.25"
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// LabelSelector is a Kubernetes label selector to match against the shoot cluster's labels. E.g. "env in (dev,stage),!legacy"
+	LabelSelector string `json:"labelSelector,omitempty"`
 }
 
 type Type string
@@ -96,6 +133,7 @@ type StrategyType string
 
 const (
 	ParallelStrategy StrategyType = "parallel"
+	CanaryStrategy   StrategyType = "canary"
 )
 
 type ScheduleType string
@@ -111,6 +149,21 @@ type ParallelStrategySpec struct {
 	Workers int `json:"workers"`
 }
 
+// CanaryStrategySpec defines parameters for the canary orchestration strategy, which executes operations in growing waves.
+// Every wave is executed with the workers defined in the parallel strategy spec.
+type CanaryStrategySpec struct {
+	// FirstWave is the number of runtimes in the first wave
+	FirstWave int `json:"firstWave,omitempty"`
+	// FirstWavePercentage is the percentage of runtimes in the first wave, used if FirstWave is not set
+	FirstWavePercentage int `json:"firstWavePercentage,omitempty"`
+	// GrowthFactor multiplies the size of every next wave, 2 by default
+	GrowthFactor int `json:"growthFactor,omitempty"`
+	// SoakPeriod is the time to wait after a wave has finished before its results are evaluated, e.g. 30m
+	SoakPeriod string `json:"soakPeriod,omitempty"`
+	// SuccessThreshold is the minimal percentage of succeeded operations in a wave to continue with the next wave, 100 by default
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+}
+
 // StrategySpec is the strategy part common for all orchestration trigger/status API
 type StrategySpec struct {
 	Type              StrategyType `json:"type"`
@@ -118,6 +171,7 @@ type StrategySpec struct {
 	ScheduleTime      time.Time
 	MaintenanceWindow bool                 `json:"maintenanceWindow,omitempty"`
 	Parallel          ParallelStrategySpec `json:"parallel,omitempty"`
+	Canary            CanaryStrategySpec   `json:"canary,omitempty"`
 }
 
 // TargetSpec is the targets part common for all orchestration trigger/status API
@@ -151,12 +205,14 @@ type OperationResponse struct {
 	MaintenanceWindowEnd   time.Time `json:"maintenanceWindowEnd"`
 	State                  string    `json:"state"`
 	Description            string    `json:"description"`
+	MatchReasons           []string  `json:"matchReasons,omitempty"`
 }
 
 type OperationResponseList struct {
 	Data       []OperationResponse `json:"data"`
 	Count      int                 `json:"count"`
 	TotalCount int                 `json:"totalCount"`
+	NextCursor string              `json:"nextCursor,omitempty"`
 }
 
 type OperationDetailResponse struct {
@@ -170,6 +226,7 @@ type StatusResponseList struct {
 	Data       []StatusResponse `json:"data"`
 	Count      int              `json:"count"`
 	TotalCount int              `json:"totalCount"`
+	NextCursor string           `json:"nextCursor,omitempty"`
 }
 
 type UpgradeResponse struct {
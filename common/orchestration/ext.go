@@ -19,6 +19,8 @@ type Runtime struct {
 	MaintenanceDays      []string  `json:"maintenanceDays"`
 	Plan                 string    `json:"plan"`
 	Region               string    `json:"region"`
+	// MatchReasons describe which runtime target criteria matched the runtime
+	MatchReasons []string `json:"matchReasons,omitempty"`
 }
 
 // RuntimeOperation holds information about operation performed on a runtime
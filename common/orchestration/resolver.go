@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Masterminds/semver"
 	"github.com/kyma-project/kyma-environment-broker/common/gardener"
 	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/sirupsen/logrus"
@@ -14,6 +15,7 @@ import (
 	brokerapi "github.com/pivotal-cf/brokerapi/v8/domain"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/dynamic"
 )
 
@@ -22,6 +24,8 @@ import (
 //go:generate mockery --name=RuntimeLister --output=. --outpkg=orchestration --case=underscore --structname RuntimeListerMock --filename runtime_lister_mock.go
 type RuntimeLister interface {
 	ListAllRuntimes() ([]runtime.RuntimeDTO, error)
+	// GetKymaVersion returns the Kyma version of the runtime, an empty string if the version is unknown
+	GetKymaVersion(runtimeID string) (string, error)
 }
 
 // GardenerRuntimeResolver is the default resolver which implements the RuntimeResolver interface.
@@ -132,8 +136,57 @@ func (resolver *GardenerRuntimeResolver) getRuntime(runtimeID string) (runtime.R
 	return rt, ok
 }
 
+// runtimeTargetMatcher holds the parsed version constraints and label selector of a runtime target
+type runtimeTargetMatcher struct {
+	kymaVersion       *semver.Constraints
+	kubernetesVersion *semver.Constraints
+	labelSelector     labels.Selector
+}
+
+func newRuntimeTargetMatcher(rt RuntimeTarget) (runtimeTargetMatcher, error) {
+	matcher := runtimeTargetMatcher{}
+	var err error
+	if rt.KymaVersion != "" {
+		matcher.kymaVersion, err = semver.NewConstraint(rt.KymaVersion)
+		if err != nil {
+			return matcher, fmt.Errorf("while parsing kymaVersion constraint %q: %w", rt.KymaVersion, err)
+		}
+	}
+	if rt.KubernetesVersion != "" {
+		matcher.kubernetesVersion, err = semver.NewConstraint(rt.KubernetesVersion)
+		if err != nil {
+			return matcher, fmt.Errorf("while parsing kubernetesVersion constraint %q: %w", rt.KubernetesVersion, err)
+		}
+	}
+	if rt.LabelSelector != "" {
+		matcher.labelSelector, err = labels.Parse(rt.LabelSelector)
+		if err != nil {
+			return matcher, fmt.Errorf("while parsing labelSelector %q: %w", rt.LabelSelector, err)
+		}
+	}
+	return matcher, nil
+}
+
+// ValidateRuntimeTarget checks if the version constraints and the label selector of the runtime target are valid
+func ValidateRuntimeTarget(rt RuntimeTarget) error {
+	_, err := newRuntimeTargetMatcher(rt)
+	return err
+}
+
+func versionSatisfies(constraint *semver.Constraints, version string) bool {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	return constraint.Check(v)
+}
+
 func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget, shoots []unstructured.Unstructured) ([]Runtime, error) {
 	runtimes := []Runtime{}
+	matcher, err := newRuntimeTargetMatcher(rt)
+	if err != nil {
+		return nil, err
+	}
 	// Iterate over all shoots. Evaluate target specs. If multiple are specified, all must match for a given shoot.
 	for _, s := range shoots {
 		shoot := &gardener.Shoot{s}
@@ -157,6 +210,11 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			resolver.logger.Infof("Skipping Shoot %s (runtimeID: %s, instanceID %s) due to %s state: %s", shoot.GetName(), runtimeID, r.InstanceID, lastOp.Type, lastOp.State)
 			continue
 		}
+		// Skip hibernated runtimes, the cluster cannot be upgraded until it is woken up
+		if r.Status.State == runtime.StateHibernated || shoot.GetSpecHibernationEnabled() {
+			resolver.logger.Infof("Skipping Shoot %s (runtimeID: %s, instanceID %s) because it is hibernated", shoot.GetName(), runtimeID, r.InstanceID)
+			continue
+		}
 		maintenanceWindowBegin, err := time.Parse(maintenanceWindowFormat, shoot.GetSpecMaintenanceTimeWindowBegin())
 		if err != nil {
 			resolver.logger.Errorf("Failed to parse maintenanceWindowBegin value %s of shoot %s ", shoot.GetSpecMaintenanceTimeWindowBegin(), shoot.GetName())
@@ -171,21 +229,26 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 		// Match exact shoot by runtimeID
 		if rt.RuntimeID != "" {
 			if rt.RuntimeID == runtimeID {
-				runtimes = append(runtimes, resolver.runtimeFromDTO(r, shoot.GetName(), maintenanceWindowBegin, maintenanceWindowEnd))
+				runtimes = append(runtimes, resolver.runtimeFromDTO(r, shoot.GetName(), maintenanceWindowBegin, maintenanceWindowEnd, []string{fmt.Sprintf("runtimeID is %s", runtimeID)}))
 			}
 			continue
 		}
+		var reasons []string
 
 		// Match exact shoot by instanceID
 		if rt.InstanceID != "" {
 			if rt.InstanceID != r.InstanceID {
 				continue
 			}
+			reasons = append(reasons, fmt.Sprintf("instanceID is %s", r.InstanceID))
 		}
 
 		// Match exact shoot by name
-		if rt.Shoot != "" && rt.Shoot != shoot.GetName() {
-			continue
+		if rt.Shoot != "" {
+			if rt.Shoot != shoot.GetName() {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("shoot is %s", shoot.GetName()))
 		}
 
 		// Perform match against a specific PlanName
@@ -193,6 +256,7 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			if rt.PlanName != r.ServicePlanName {
 				continue
 			}
+			reasons = append(reasons, fmt.Sprintf("plan is %s", r.ServicePlanName))
 		}
 
 		// Perform match against GlobalAccount regexp
@@ -201,6 +265,7 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			if err != nil || !matched {
 				continue
 			}
+			reasons = append(reasons, fmt.Sprintf("globalAccount %s matches %q", shoot.GetLabels()[globalAccountLabel], rt.GlobalAccount))
 		}
 
 		// Perform match against SubAccount regexp
@@ -209,6 +274,7 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			if err != nil || !matched {
 				continue
 			}
+			reasons = append(reasons, fmt.Sprintf("subAccount %s matches %q", shoot.GetLabels()[subAccountLabel], rt.SubAccount))
 		}
 
 		// Perform match against Region regexp
@@ -217,20 +283,54 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			if err != nil || !matched {
 				continue
 			}
+			reasons = append(reasons, fmt.Sprintf("region %s matches %q", shoot.GetSpecRegion(), rt.Region))
+		}
+
+		// Perform match against Kyma version constraint, runtimes with unknown or non-semver Kyma version never match.
+		// The version is looked up only for the runtimes matching the other criteria, it is not part of the listed runtimes.
+		if matcher.kymaVersion != nil {
+			kymaVersion, err := resolver.runtimeLister.GetKymaVersion(runtimeID)
+			if err != nil {
+				resolver.logger.Errorf("Failed to get Kyma version of runtime %s: %s", runtimeID, err)
+				continue
+			}
+			if !versionSatisfies(matcher.kymaVersion, kymaVersion) {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("kymaVersion %s satisfies %q", kymaVersion, rt.KymaVersion))
+		}
+
+		// Perform match against Kubernetes version constraint
+		if matcher.kubernetesVersion != nil {
+			if !versionSatisfies(matcher.kubernetesVersion, shoot.GetSpecKubernetesVersion()) {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("kubernetesVersion %s satisfies %q", shoot.GetSpecKubernetesVersion(), rt.KubernetesVersion))
+		}
+
+		// Perform match against shoot labels
+		if matcher.labelSelector != nil {
+			if !matcher.labelSelector.Matches(labels.Set(shoot.GetLabels())) {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("labels match %q", rt.LabelSelector))
 		}
 
 		// Check if target: all is specified
-		if rt.Target != "" && rt.Target != TargetAll {
-			continue
+		if rt.Target != "" {
+			if rt.Target != TargetAll {
+				continue
+			}
+			reasons = append(reasons, "target is all")
 		}
 
-		runtimes = append(runtimes, resolver.runtimeFromDTO(r, shoot.GetName(), maintenanceWindowBegin, maintenanceWindowEnd))
+		runtimes = append(runtimes, resolver.runtimeFromDTO(r, shoot.GetName(), maintenanceWindowBegin, maintenanceWindowEnd, reasons))
 	}
 
 	return runtimes, nil
 }
 
-func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shootName string, windowBegin, windowEnd time.Time) Runtime {
+func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shootName string, windowBegin, windowEnd time.Time, reasons []string) Runtime {
 	return Runtime{
 		InstanceID:             runtime.InstanceID,
 		RuntimeID:              runtime.RuntimeID,
@@ -242,5 +342,6 @@ func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shoot
 		MaintenanceWindowBegin: windowBegin,
 		MaintenanceWindowEnd:   windowEnd,
 		MaintenanceDays:        []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+		MatchReasons:           reasons,
 	}
 }
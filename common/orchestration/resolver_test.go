@@ -201,6 +201,106 @@ func TestResolver_Resolve(t *testing.T) {
 	}
 }
 
+func TestResolver_Resolve_VersionsAndLabels(t *testing.T) {
+	// given
+	shootA := fixShootWithVersionAndLabels(1, "1.25.4", map[string]interface{}{"env": "dev"})
+	shootB := fixShootWithVersionAndLabels(2, "1.26.1", map[string]interface{}{"env": "prod"})
+	shootC := fixShootWithVersionAndLabels(3, "1.25.9", map[string]interface{}{"env": "prod", "legacy": "true"})
+	client := gardener.NewDynamicFakeClient(&shootA, &shootB, &shootC)
+
+	runtimeA := fixRuntimeDTO(1, globalAccountID1, plan1, runtimeOpState{provision: string(brokerapi.Succeeded)})
+	runtimeB := fixRuntimeDTO(2, globalAccountID1, plan1, runtimeOpState{provision: string(brokerapi.Succeeded)})
+	runtimeC := fixRuntimeDTO(3, globalAccountID1, plan1, runtimeOpState{provision: string(brokerapi.Succeeded)})
+	lister := &RuntimeListerMock{}
+	lister.On("ListAllRuntimes").Return([]runtime.RuntimeDTO{runtimeA, runtimeB, runtimeC}, nil)
+	lister.On("GetKymaVersion", runtimeA.RuntimeID).Return("2.3.0", nil)
+	lister.On("GetKymaVersion", runtimeB.RuntimeID).Return("2.8.1", nil)
+	lister.On("GetKymaVersion", runtimeC.RuntimeID).Return("PR-1234", nil)
+	resolver := NewGardenerRuntimeResolver(client, shootNamespace, lister, newLogDummy())
+
+	for tn, tc := range map[string]struct {
+		Target          RuntimeTarget
+		ExpectedRuntime []string
+		ExpectedReasons []string
+	}{
+		"KymaVersion": {
+			Target:          RuntimeTarget{KymaVersion: ">=2.0.0, <2.5.0"},
+			ExpectedRuntime: []string{"runtime-id-1"},
+			ExpectedReasons: []string{`kymaVersion 2.3.0 satisfies ">=2.0.0, <2.5.0"`},
+		},
+		"KubernetesVersion": {
+			Target:          RuntimeTarget{KubernetesVersion: "~1.25"},
+			ExpectedRuntime: []string{"runtime-id-1", "runtime-id-3"},
+			ExpectedReasons: []string{`kubernetesVersion 1.25.4 satisfies "~1.25"`},
+		},
+		"LabelSelector": {
+			Target:          RuntimeTarget{LabelSelector: "env=prod,!legacy"},
+			ExpectedRuntime: []string{"runtime-id-2"},
+			ExpectedReasons: []string{`labels match "env=prod,!legacy"`},
+		},
+		"Combined": {
+			Target:          RuntimeTarget{PlanName: plan1, KubernetesVersion: "<1.26", LabelSelector: "env in (dev,stage)"},
+			ExpectedRuntime: []string{"runtime-id-1"},
+			ExpectedReasons: []string{"plan is azure", `kubernetesVersion 1.25.4 satisfies "<1.26"`, `labels match "env in (dev,stage)"`},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			runtimes, err := resolver.Resolve(TargetSpec{Include: []RuntimeTarget{tc.Target}})
+
+			// then
+			require.NoError(t, err)
+			var runtimeIDs []string
+			for _, r := range runtimes {
+				runtimeIDs = append(runtimeIDs, r.RuntimeID)
+			}
+			assert.ElementsMatch(t, tc.ExpectedRuntime, runtimeIDs)
+			for _, r := range runtimes {
+				if r.RuntimeID == tc.ExpectedRuntime[0] {
+					assert.Equal(t, tc.ExpectedReasons, r.MatchReasons)
+				}
+			}
+		})
+	}
+
+	t.Run("InvalidTarget", func(t *testing.T) {
+		for _, target := range []RuntimeTarget{
+			{KymaVersion: "not a version"},
+			{KubernetesVersion: ">>1.25"},
+			{LabelSelector: "env in dev"},
+		} {
+			_, err := resolver.Resolve(TargetSpec{Include: []RuntimeTarget{target}})
+			assert.Error(t, err)
+			assert.Error(t, ValidateRuntimeTarget(target))
+		}
+	})
+}
+
+func TestResolver_Resolve_Hibernated(t *testing.T) {
+	// given
+	shootA := fixShoot(1, globalAccountID1, region1)
+	shootB := fixShoot(2, globalAccountID1, region1)
+	shootC := fixShoot(3, globalAccountID1, region1)
+	shootC.Object["spec"].(map[string]interface{})["hibernation"] = map[string]interface{}{"enabled": true}
+	client := gardener.NewDynamicFakeClient(&shootA, &shootB, &shootC)
+
+	runtimeA := fixRuntimeDTO(1, globalAccountID1, plan1, runtimeOpState{provision: string(brokerapi.Succeeded)})
+	runtimeB := fixRuntimeDTO(2, globalAccountID1, plan1, runtimeOpState{provision: string(brokerapi.Succeeded)})
+	runtimeB.Status.State = runtime.StateHibernated
+	runtimeC := fixRuntimeDTO(3, globalAccountID1, plan1, runtimeOpState{provision: string(brokerapi.Succeeded)})
+	lister := &RuntimeListerMock{}
+	lister.On("ListAllRuntimes").Return([]runtime.RuntimeDTO{runtimeA, runtimeB, runtimeC}, nil)
+	resolver := NewGardenerRuntimeResolver(client, shootNamespace, lister, newLogDummy())
+
+	// when
+	runtimes, err := resolver.Resolve(TargetSpec{Include: []RuntimeTarget{{Target: TargetAll}}})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, runtimes, 1)
+	assert.Equal(t, "runtime-id-1", runtimes[0].RuntimeID)
+}
+
 func TestResolver_Resolve_GardenerFailure(t *testing.T) {
 	// given
 	fake := k8stesting.Fake{}
@@ -311,6 +411,17 @@ func fixShoot(id int, globalAccountID, region string) unstructured.Unstructured
 	}
 }
 
+func fixShootWithVersionAndLabels(id int, kubernetesVersion string, labels map[string]interface{}) unstructured.Unstructured {
+	shoot := fixShoot(id, globalAccountID1, region1)
+	for k, v := range labels {
+		shoot.Object["metadata"].(map[string]interface{})["labels"].(map[string]interface{})[k] = v
+	}
+	shoot.Object["spec"].(map[string]interface{})["kubernetes"] = map[string]interface{}{
+		"version": kubernetesVersion,
+	}
+	return shoot
+}
+
 type runtimeOpState struct {
 	provision    string
 	deprovision  string
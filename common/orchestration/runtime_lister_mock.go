@@ -13,6 +13,27 @@ type RuntimeListerMock struct {
 	mock.Mock
 }
 
+// GetKymaVersion provides a mock function with given fields: runtimeID
+func (_m *RuntimeListerMock) GetKymaVersion(runtimeID string) (string, error) {
+	ret := _m.Called(runtimeID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(runtimeID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(runtimeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAllRuntimes provides a mock function with given fields:
 func (_m *RuntimeListerMock) ListAllRuntimes() ([]runtime.RuntimeDTO, error) {
 	ret := _m.Called()
@@ -0,0 +1,101 @@
+package strategies
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+)
+
+const (
+	defaultCanaryGrowthFactor     = 2
+	defaultCanarySuccessThreshold = 100
+)
+
+// CanaryPlan splits operations of an orchestration into growing waves. Every wave is executed with the parallel strategy,
+// the next wave starts after the soak period if the success threshold of the previous wave is reached.
+type CanaryPlan struct {
+	firstWave           int
+	firstWavePercentage int
+	growthFactor        int
+	soakPeriod          time.Duration
+	successThreshold    int
+}
+
+// NewCanaryPlan validates the canary strategy spec and returns the plan with defaults applied
+func NewCanaryPlan(spec orchestration.CanaryStrategySpec) (CanaryPlan, error) {
+	plan := CanaryPlan{
+		firstWave:           spec.FirstWave,
+		firstWavePercentage: spec.FirstWavePercentage,
+		growthFactor:        spec.GrowthFactor,
+		successThreshold:    spec.SuccessThreshold,
+	}
+
+	if spec.FirstWave < 0 {
+		return plan, fmt.Errorf("canary firstWave must not be negative")
+	}
+	if spec.FirstWavePercentage < 0 || spec.FirstWavePercentage > 100 {
+		return plan, fmt.Errorf("canary firstWavePercentage must be between 0 and 100")
+	}
+	if spec.FirstWave == 0 && spec.FirstWavePercentage == 0 {
+		return plan, fmt.Errorf("canary firstWave or firstWavePercentage must be set")
+	}
+	if spec.GrowthFactor < 0 || spec.GrowthFactor == 1 {
+		return plan, fmt.Errorf("canary growthFactor must be greater than 1")
+	}
+	if spec.SuccessThreshold < 0 || spec.SuccessThreshold > 100 {
+		return plan, fmt.Errorf("canary successThreshold must be between 0 and 100")
+	}
+	if spec.SoakPeriod != "" {
+		soakPeriod, err := time.ParseDuration(spec.SoakPeriod)
+		if err != nil || soakPeriod < 0 {
+			return plan, fmt.Errorf("canary soakPeriod %q is not a valid duration", spec.SoakPeriod)
+		}
+		plan.soakPeriod = soakPeriod
+	}
+
+	if plan.growthFactor == 0 {
+		plan.growthFactor = defaultCanaryGrowthFactor
+	}
+	if plan.successThreshold == 0 {
+		plan.successThreshold = defaultCanarySuccessThreshold
+	}
+
+	return plan, nil
+}
+
+// WaveSize returns the number of operations in the given wave, the first wave has number 1
+func (p CanaryPlan) WaveSize(wave, total int) int {
+	size := p.firstWave
+	if size == 0 {
+		size = total * p.firstWavePercentage / 100
+	}
+	if size < 1 {
+		size = 1
+	}
+	for i := 1; i < wave && size < total; i++ {
+		size *= p.growthFactor
+	}
+	if size > total {
+		size = total
+	}
+	return size
+}
+
+// SoakPeriod returns the time to wait after a wave has finished before its results are evaluated
+func (p CanaryPlan) SoakPeriod() time.Duration {
+	return p.soakPeriod
+}
+
+// Passed returns true if the number of succeeded operations in a wave reaches the success threshold
+func (p CanaryPlan) Passed(succeeded, total int) bool {
+	if total == 0 {
+		return true
+	}
+	return succeeded*100 >= p.successThreshold*total
+}
+
+// SuccessThreshold returns the minimal percentage of succeeded operations in a wave
+func (p CanaryPlan) SuccessThreshold() int {
+	return p.successThreshold
+}
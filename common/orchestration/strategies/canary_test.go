@@ -0,0 +1,84 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanaryPlan_WaveSize(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		spec     orchestration.CanaryStrategySpec
+		total    int
+		expected []int
+	}{
+		"fixed first wave with default growth": {
+			spec:     orchestration.CanaryStrategySpec{FirstWave: 2},
+			total:    20,
+			expected: []int{2, 4, 8, 16, 20},
+		},
+		"percentage first wave": {
+			spec:     orchestration.CanaryStrategySpec{FirstWavePercentage: 10, GrowthFactor: 3},
+			total:    100,
+			expected: []int{10, 30, 90, 100},
+		},
+		"percentage smaller than a single runtime": {
+			spec:     orchestration.CanaryStrategySpec{FirstWavePercentage: 1},
+			total:    10,
+			expected: []int{1, 2, 4, 8, 10},
+		},
+		"first wave greater than total": {
+			spec:     orchestration.CanaryStrategySpec{FirstWave: 50},
+			total:    3,
+			expected: []int{3},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			plan, err := NewCanaryPlan(tc.spec)
+			require.NoError(t, err)
+
+			// when
+			var sizes []int
+			for wave := 1; wave <= len(tc.expected); wave++ {
+				sizes = append(sizes, plan.WaveSize(wave, tc.total))
+			}
+
+			// then
+			assert.Equal(t, tc.expected, sizes)
+		})
+	}
+}
+
+func TestCanaryPlan_Validation(t *testing.T) {
+	for tn, spec := range map[string]orchestration.CanaryStrategySpec{
+		"missing first wave":     {},
+		"negative first wave":    {FirstWave: -1},
+		"percentage over 100":    {FirstWavePercentage: 101},
+		"growth factor 1":        {FirstWave: 1, GrowthFactor: 1},
+		"threshold over 100":     {FirstWave: 1, SuccessThreshold: 101},
+		"malformed soak period":  {FirstWave: 1, SoakPeriod: "one hour"},
+		"negative soak duration": {FirstWave: 1, SoakPeriod: "-1h"},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			_, err := NewCanaryPlan(spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCanaryPlan_Passed(t *testing.T) {
+	// given
+	plan, err := NewCanaryPlan(orchestration.CanaryStrategySpec{FirstWave: 1, SoakPeriod: "30m", SuccessThreshold: 80})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, 30*time.Minute, plan.SoakPeriod())
+	assert.True(t, plan.Passed(8, 10))
+	assert.False(t, plan.Passed(7, 10))
+	assert.True(t, plan.Passed(0, 0))
+}
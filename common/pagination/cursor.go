@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const CursorParam = "cursor"
+
+// Cursor points to the last item of a page in the keyset ordered by the creation time and the ID of the items.
+// The next page starts with the first item following the cursor.
+type Cursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+}
+
+// Precedes returns true if the item with the given creation time and ID is located after the cursor
+func (c Cursor) Precedes(createdAt time.Time, id string) bool {
+	if !c.CreatedAt.Equal(createdAt) {
+		return c.CreatedAt.Before(createdAt)
+	}
+	return c.ID < id
+}
+
+// EncodeCursor returns the opaque continuation token of the cursor
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes the continuation token returned by EncodeCursor
+func DecodeCursor(token string) (Cursor, error) {
+	var cursor Cursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, fmt.Errorf("cursor is malformed")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return cursor, fmt.Errorf("cursor is malformed")
+	}
+	return cursor, nil
+}
+
+// NextCursor returns the continuation token of the next page or an empty string if the page is not full
+func NextCursor(pageSize, count int, lastCreatedAt time.Time, lastID string) string {
+	if count == 0 || count < pageSize {
+		return ""
+	}
+	return EncodeCursor(Cursor{CreatedAt: lastCreatedAt, ID: lastID})
+}
+
+// ExtractCursorFromRequest returns the cursor from the request, the cursor is nil if the request does not contain it
+func ExtractCursorFromRequest(req *http.Request) (*Cursor, error) {
+	params := req.URL.Query()
+	cursorArr, ok := params[CursorParam]
+	if !ok {
+		return nil, nil
+	}
+	if len(cursorArr) > 1 {
+		return nil, fmt.Errorf("cursor has to be one parameter")
+	}
+	if _, ok := params[PageParam]; ok {
+		return nil, fmt.Errorf("page and cursor cannot be used together")
+	}
+	cursor, err := DecodeCursor(cursorArr[0])
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
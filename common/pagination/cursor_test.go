@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	t.Run("should encode and decode cursor", func(t *testing.T) {
+		// given
+		cursor := Cursor{CreatedAt: createdAt, ID: "id-1"}
+
+		// when
+		decoded, err := DecodeCursor(EncodeCursor(cursor))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+		assert.Equal(t, cursor.ID, decoded.ID)
+	})
+
+	t.Run("should reject malformed cursor", func(t *testing.T) {
+		for _, token := range []string{"!!!", EncodeCursor(Cursor{CreatedAt: createdAt})} {
+			_, err := DecodeCursor(token)
+			assert.EqualError(t, err, "cursor is malformed")
+		}
+	})
+
+	t.Run("should order items after the cursor", func(t *testing.T) {
+		cursor := Cursor{CreatedAt: createdAt, ID: "b"}
+
+		assert.True(t, cursor.Precedes(createdAt.Add(time.Second), "a"))
+		assert.True(t, cursor.Precedes(createdAt, "c"))
+		assert.False(t, cursor.Precedes(createdAt, "b"))
+		assert.False(t, cursor.Precedes(createdAt, "a"))
+		assert.False(t, cursor.Precedes(createdAt.Add(-time.Second), "c"))
+	})
+
+	t.Run("should return next cursor only for a full page", func(t *testing.T) {
+		assert.Empty(t, NextCursor(10, 0, createdAt, "a"))
+		assert.Empty(t, NextCursor(10, 9, createdAt, "a"))
+		assert.Equal(t, EncodeCursor(Cursor{CreatedAt: createdAt, ID: "a"}), NextCursor(10, 10, createdAt, "a"))
+	})
+}
+
+func TestExtractCursorFromRequest(t *testing.T) {
+	token := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "id"})
+
+	for tn, tc := range map[string]struct {
+		query       string
+		expectNil   bool
+		expectedErr string
+	}{
+		"no cursor": {
+			query:     "?page=2",
+			expectNil: true,
+		},
+		"cursor": {
+			query: "?cursor=" + token,
+		},
+		"cursor with page": {
+			query:       "?page=2&cursor=" + token,
+			expectedErr: "page and cursor cannot be used together",
+		},
+		"multiple cursors": {
+			query:       "?cursor=" + token + "&cursor=" + token,
+			expectedErr: "cursor has to be one parameter",
+		},
+		"malformed cursor": {
+			query:       "?cursor=abc",
+			expectedErr: "cursor is malformed",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			req, err := http.NewRequest(http.MethodGet, "/runtimes"+tc.query, nil)
+			require.NoError(t, err)
+
+			// when
+			cursor, err := ExtractCursorFromRequest(req)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectNil, cursor == nil)
+		})
+	}
+}
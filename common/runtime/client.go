@@ -37,6 +37,7 @@ func NewClient(url string, httpClient *http.Client) Client {
 
 // ListRuntimes fetches the runtimes from KEB according to the given parameters.
 // If params.Page or params.PageSize is not set (zero), the client will fetch and return all runtimes.
+// The client follows the continuation tokens returned by KEB and falls back to the page numbers if KEB does not return them.
 func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 	runtimes := RuntimesPage{}
 	getAll := false
@@ -86,8 +87,15 @@ func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 		runtimes.Count += rp.Count
 		runtimes.Data = append(runtimes.Data, rp.Data...)
 		if getAll {
-			params.Page++
 			fetchedAll = runtimes.Count >= runtimes.TotalCount
+			switch {
+			case rp.NextCursor != "":
+				params.Cursor = rp.NextCursor
+			case params.Cursor == "":
+				params.Page++
+			default:
+				fetchedAll = true
+			}
 		} else {
 			fetchedAll = true
 		}
@@ -98,7 +106,11 @@ func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 
 func setQuery(url *url.URL, params ListParameters) {
 	query := url.Query()
-	query.Add(pagination.PageParam, strconv.Itoa(params.Page))
+	if params.Cursor != "" {
+		query.Add(pagination.CursorParam, params.Cursor)
+	} else {
+		query.Add(pagination.PageParam, strconv.Itoa(params.Page))
+	}
 	query.Add(pagination.PageSizeParam, strconv.Itoa(params.PageSize))
 	if params.OperationDetail != "" {
 		query.Add(OperationDetailParam, string(params.OperationDetail))
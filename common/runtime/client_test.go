@@ -118,6 +118,44 @@ func TestClient_ListRuntimes(t *testing.T) {
 		assert.Equal(t, 4, rp.TotalCount)
 		assert.Len(t, rp.Data, 4)
 	})
+
+	t.Run("test cursor pagination", func(t *testing.T) {
+		called := 0
+		params := ListParameters{
+			PageSize: 2,
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			query := r.URL.Query()
+
+			var rp RuntimesPage
+			switch called {
+			case 1:
+				assert.ElementsMatch(t, []string{"1"}, query[pagination.PageParam])
+				assert.Empty(t, query[pagination.CursorParam])
+				rp = RuntimesPage{Data: []RuntimeDTO{runtime1, runtime2}, Count: 2, TotalCount: 3, NextCursor: "next"}
+			default:
+				assert.ElementsMatch(t, []string{"next"}, query[pagination.CursorParam])
+				assert.Empty(t, query[pagination.PageParam])
+				rp = RuntimesPage{Data: []RuntimeDTO{runtime3}, Count: 1, TotalCount: 3}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			err := json.NewEncoder(w).Encode(rp)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(ts.URL, oauth2.NewClient(context.Background(), fixToken))
+
+		//when
+		rp, err := client.ListRuntimes(params)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, called)
+		assert.Equal(t, 3, rp.Count)
+		assert.Len(t, rp.Data, 3)
+		assert.Equal(t, runtime3.InstanceID, rp.Data[2].InstanceID)
+	})
 }
 
 func fixRuntimeDTO(id string) RuntimeDTO {
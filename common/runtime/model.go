@@ -28,12 +28,24 @@ const (
 	StateUpgrading State = "upgrading"
 	// StateUpdating means the runtime configuration is being updated (i.e. OIDC is reconfigured).
 	StateUpdating State = "updating"
-	// StateSuspended means that the trial runtime is suspended (i.e. deprovisioned).
+	// StateSuspended means that the runtime of the deactivated subaccount is suspended, the SuspensionReason tells how.
 	StateSuspended State = "suspended"
+	// StateHibernated means that the last operation of the runtime has succeeded and the cluster is hibernated by the hibernation schedule.
+	StateHibernated State = "hibernated"
 	// AllState is a virtual state only used as query parameter in ListParameters to indicate "include all runtimes, which are excluded by default without state filters".
 	AllState State = "all"
 )
 
+// SuspensionReason tells how the runtime of the deactivated subaccount is suspended
+type SuspensionReason string
+
+const (
+	// SuspensionReasonSuspended means that the cluster is deprovisioned and provisioned again on the unsuspension.
+	SuspensionReasonSuspended SuspensionReason = "suspended"
+	// SuspensionReasonHibernated means that the cluster is hibernated and woken up on the unsuspension.
+	SuspensionReasonHibernated SuspensionReason = "hibernated"
+)
+
 type RuntimeDTO struct {
 	InstanceID                  string                         `json:"instanceID"`
 	RuntimeID                   string                         `json:"runtimeID"`
@@ -54,14 +66,29 @@ type RuntimeDTO struct {
 	KymaVersion                 string                         `json:"kymaVersion,omitempty"`
 	KymaConfig                  *gqlschema.KymaConfigInput     `json:"kymaConfig,omitempty"`
 	ClusterConfig               *gqlschema.GardenerConfigInput `json:"clusterConfig,omitempty"`
+	Drift                       *RuntimeDrift                  `json:"drift,omitempty"`
+}
+
+// RuntimeDrift lists the attributes of the shoot which differ from the parameters of the instance
+type RuntimeDrift struct {
+	DetectedAt time.Time      `json:"detectedAt"`
+	Findings   []DriftFinding `json:"findings"`
+}
+
+type DriftFinding struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
 }
 
 type RuntimeStatus struct {
 	CreatedAt        time.Time                 `json:"createdAt"`
 	ModifiedAt       time.Time                 `json:"modifiedAt"`
 	ExpiredAt        *time.Time                `json:"expiredAt,omitempty"`
+	HibernatedAt     *time.Time                `json:"hibernatedAt,omitempty"`
 	DeletedAt        *time.Time                `json:"deletedAt,omitempty"`
 	State            State                     `json:"state"`
+	SuspensionReason SuspensionReason          `json:"suspensionReason,omitempty"`
 	Provisioning     *Operation                `json:"provisioning,omitempty"`
 	Deprovisioning   *Operation                `json:"deprovisioning,omitempty"`
 	UpgradingKyma    *OperationsData           `json:"upgradingKyma,omitempty"`
@@ -91,22 +118,33 @@ type OperationsData struct {
 }
 
 type Operation struct {
-	State                        string        `json:"state"`
-	Type                         OperationType `json:"type,omitempty"`
-	Description                  string        `json:"description"`
-	CreatedAt                    time.Time     `json:"createdAt"`
-	UpdatedAt                    time.Time     `json:"updatedAt"`
-	OperationID                  string        `json:"operationID"`
-	OrchestrationID              string        `json:"orchestrationID,omitempty"`
-	FinishedStages               []string      `json:"finishedStages"`
-	ExecutedButNotCompletedSteps []string      `json:"executedButNotCompletedSteps,omitempty"`
-	RuntimeVersion               string        `json:"runtimeVersion"`
+	State                        string          `json:"state"`
+	Type                         OperationType   `json:"type,omitempty"`
+	Description                  string          `json:"description"`
+	CreatedAt                    time.Time       `json:"createdAt"`
+	UpdatedAt                    time.Time       `json:"updatedAt"`
+	OperationID                  string          `json:"operationID"`
+	OrchestrationID              string          `json:"orchestrationID,omitempty"`
+	FinishedStages               []string        `json:"finishedStages"`
+	ExecutedButNotCompletedSteps []string        `json:"executedButNotCompletedSteps,omitempty"`
+	RuntimeVersion               string          `json:"runtimeVersion"`
+	Error                        *OperationError `json:"error,omitempty"`
+}
+
+// OperationError describes the failure of the operation with the stable error code and the suggested action
+type OperationError struct {
+	Code      string `json:"code"`
+	Reason    string `json:"reason,omitempty"`
+	Component string `json:"component,omitempty"`
+	Action    string `json:"action"`
+	Hint      string `json:"hint"`
 }
 
 type RuntimesPage struct {
 	Data       []RuntimeDTO `json:"data"`
 	Count      int          `json:"count"`
 	TotalCount int          `json:"totalCount"`
+	NextCursor string       `json:"nextCursor,omitempty"`
 }
 
 const (
@@ -123,6 +161,7 @@ const (
 	ClusterConfigParam   = "cluster_config"
 	ExpiredParam         = "expired"
 	GardenerConfigParam  = "gardener_config"
+	DriftParam           = "drift"
 )
 
 type OperationDetail string
@@ -137,6 +176,8 @@ type ListParameters struct {
 	Page int
 	// PageSize specifies the count of matching runtimes returned in a response
 	PageSize int
+	// Cursor specifies the continuation token returned in the previous response, the results start after the cursor and the Page is ignored
+	Cursor string
 	// OperationDetail specifies whether the server should respond with all operations, or only the last operation. If not set, the server by default sends all operations
 	OperationDetail OperationDetail
 	// KymaConfig specifies whether kyma configuration details should be included in the response for each runtime
@@ -2,11 +2,16 @@ package broker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/kyma-incubator/compass/components/director/pkg/jsonschema"
+	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
@@ -15,30 +20,116 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	bindOperationData = "bind"
+	bindingTimeout    = 2 * time.Minute
+)
+
 type BindingConfig struct {
 	Enabled       bool        `envconfig:"default=false"`
 	BindablePlans EnablePlans `envconfig:"default=aws"`
+	ClusterRole   string      `envconfig:"default=cluster-admin"`
+	// ExpirationSeconds is used when the binding parameters do not specify the expiration,
+	// MinExpirationSeconds and MaxExpirationSeconds limit the expiration accepted in the parameters of bindable plans
+	ExpirationSeconds    int `envconfig:"default=600"`
+	MinExpirationSeconds int `envconfig:"default=600"`
+	MaxExpirationSeconds int `envconfig:"default=7200"`
+	// PlanExpirationLimits overrides the expiration limits for the listed plans
+	PlanExpirationLimits PlanExpirationLimits `envconfig:"optional"`
+}
+
+// ExpirationLimits limit the expiration accepted in the binding parameters
+type ExpirationLimits struct {
+	MinExpirationSeconds int
+	MaxExpirationSeconds int
+}
+
+// PlanExpirationLimits maps the plan names to their expiration limits
+type PlanExpirationLimits map[string]ExpirationLimits
+
+// Unmarshal provides custom parsing of the expiration limits in the format <plan name>:<min seconds>:<max seconds> separated by commas.
+// Implements envconfig.Unmarshal interface.
+func (l *PlanExpirationLimits) Unmarshal(in string) error {
+	limits := PlanExpirationLimits{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid expiration limits %q, expected <plan name>:<min seconds>:<max seconds>", entry)
+		}
+		plan := strings.TrimSpace(parts[0])
+		if _, exists := PlanIDsMapping[plan]; !exists {
+			return fmt.Errorf("unrecognized %v plan name", plan)
+		}
+		minSeconds, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return fmt.Errorf("invalid minimum expiration of the %s plan: %w", plan, err)
+		}
+		maxSeconds, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil {
+			return fmt.Errorf("invalid maximum expiration of the %s plan: %w", plan, err)
+		}
+		if minSeconds <= 0 || minSeconds > maxSeconds {
+			return fmt.Errorf("invalid expiration limits of the %s plan, the minimum must be positive and not greater than the maximum", plan)
+		}
+		limits[plan] = ExpirationLimits{MinExpirationSeconds: minSeconds, MaxExpirationSeconds: maxSeconds}
+	}
+
+	*l = limits
+	return nil
+}
+
+// ExpirationLimitsForPlan returns the expiration limits and the default expiration of the given plan name.
+// The global limits are used for the plans which are not listed, the default expiration is kept within the limits.
+func (c BindingConfig) ExpirationLimitsForPlan(planName string) (ExpirationLimits, int) {
+	limits := ExpirationLimits{MinExpirationSeconds: c.MinExpirationSeconds, MaxExpirationSeconds: c.MaxExpirationSeconds}
+	for plan, planLimits := range c.PlanExpirationLimits {
+		if strings.EqualFold(plan, planName) {
+			limits = planLimits
+			break
+		}
+	}
+	expirationSeconds := c.ExpirationSeconds
+	if expirationSeconds < limits.MinExpirationSeconds {
+		expirationSeconds = limits.MinExpirationSeconds
+	}
+	if expirationSeconds > limits.MaxExpirationSeconds {
+		expirationSeconds = limits.MaxExpirationSeconds
+	}
+	return limits, expirationSeconds
 }
 
 type BindEndpoint struct {
 	config           BindingConfig
 	instancesStorage storage.Instances
+	bindingsStorage  storage.Bindings
+	bindingsManager  BindingsManager
 
 	log logrus.FieldLogger
 }
 
-func NewBind(cfg BindingConfig, instanceStorage storage.Instances, log logrus.FieldLogger) *BindEndpoint {
-	return &BindEndpoint{config: cfg, instancesStorage: instanceStorage, log: log.WithField("service", "BindEndpoint")}
+func NewBind(cfg BindingConfig, instanceStorage storage.Instances, bindingsStorage storage.Bindings, bindingsManager BindingsManager, log logrus.FieldLogger) *BindEndpoint {
+	return &BindEndpoint{
+		config:           cfg,
+		instancesStorage: instanceStorage,
+		bindingsStorage:  bindingsStorage,
+		bindingsManager:  bindingsManager,
+		log:              log.WithField("service", "BindEndpoint"),
+	}
 }
 
-type BindingData struct {
-	Username string
-	Password string
+// Credentials are returned to the platform for the binding
+type Credentials struct {
+	Kubeconfig string    `json:"kubeconfig"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
 
-var dummyCredentials = BindingData{
-	Username: "admin",
-	Password: "admin1234",
+// BindingParameters are the parameters of the binding request
+type BindingParameters struct {
+	ExpirationSeconds int `json:"expiration_seconds,omitempty"`
 }
 
 // Bind creates a new service binding
@@ -68,9 +159,56 @@ func (b *BindEndpoint) Bind(ctx context.Context, instanceID, bindingID string, d
 		).WithErrorKey("BindingNotSupported").Build()
 	}
 
+	parameters, err := b.parseParameters(details.RawParameters, instance.ServicePlanName)
+	if err != nil {
+		return domain.Binding{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+	}
+
+	if instance.RuntimeID == "" {
+		return domain.Binding{}, apiresponses.NewFailureResponse(fmt.Errorf("instance %s has no runtime", instanceID), http.StatusUnprocessableEntity, "instance has no runtime")
+	}
+
+	binding, err := b.bindingsStorage.Get(instanceID, bindingID)
+	switch {
+	case err == nil:
+		return b.existingBinding(binding)
+	case !dberr.IsNotFound(err):
+		return domain.Binding{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to get binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to get binding %s", bindingID))
+	}
+
+	now := time.Now()
+	binding = &internal.Binding{
+		ID:          bindingID,
+		InstanceID:  instanceID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now,
+		State:       domain.InProgress,
+		Description: "Binding is being created",
+	}
+	err = b.bindingsStorage.Insert(binding)
+	switch {
+	case dberr.IsAlreadyExists(err):
+		return domain.Binding{}, apiresponses.ErrBindingAlreadyExists
+	case err != nil:
+		return domain.Binding{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to store binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to store binding %s", bindingID))
+	}
+
+	if asyncAllowed {
+		go b.createBinding(instance, binding, parameters.ExpirationSeconds)
+		return domain.Binding{
+			IsAsync:       true,
+			OperationData: bindOperationData,
+		}, nil
+	}
+
+	b.createBinding(instance, binding, parameters.ExpirationSeconds)
+	if binding.State != domain.Succeeded {
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(binding.Description), http.StatusInternalServerError, binding.Description)
+	}
+
 	return domain.Binding{
-		IsAsync:     false,
-		Credentials: dummyCredentials,
+		Credentials: Credentials{Kubeconfig: binding.Kubeconfig, ExpiresAt: binding.ExpiresAt},
 	}, nil
 }
 
@@ -83,3 +221,77 @@ func (b *BindEndpoint) IsPlanBindable(planName string) bool {
 	}
 	return false
 }
+
+func (b *BindEndpoint) parseParameters(rawParameters []byte, planName string) (BindingParameters, error) {
+	if len(rawParameters) == 0 {
+		rawParameters = []byte("{}")
+	}
+	limits, expirationSeconds := b.config.ExpirationLimitsForPlan(planName)
+	schema := BindingSchema(limits.MinExpirationSeconds, limits.MaxExpirationSeconds, expirationSeconds)
+	validator, err := jsonschema.NewValidatorFromStringSchema(string(Marshal(schema)))
+	if err != nil {
+		return BindingParameters{}, fmt.Errorf("while creating binding parameters validator: %w", err)
+	}
+	result, err := validator.ValidateString(string(rawParameters))
+	if err != nil {
+		return BindingParameters{}, fmt.Errorf("while executing JSON schema validator: %w", err)
+	}
+	if !result.Valid {
+		return BindingParameters{}, fmt.Errorf("while validating binding parameters: %w", result.Error)
+	}
+
+	var parameters BindingParameters
+	if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		return BindingParameters{}, fmt.Errorf("while unmarshalling binding parameters: %w", err)
+	}
+	if parameters.ExpirationSeconds == 0 {
+		parameters.ExpirationSeconds = expirationSeconds
+	}
+	return parameters, nil
+}
+
+// existingBinding returns the binding created by the previous request with the same binding ID
+func (b *BindEndpoint) existingBinding(binding *internal.Binding) (domain.Binding, error) {
+	// the credentials of the expired binding are revoked, the binding ID cannot be reused
+	if binding.IsExpired() {
+		return domain.Binding{}, apiresponses.ErrBindingAlreadyExists
+	}
+	switch binding.State {
+	case domain.Succeeded:
+		return domain.Binding{
+			AlreadyExists: true,
+			Credentials:   Credentials{Kubeconfig: binding.Kubeconfig, ExpiresAt: binding.ExpiresAt},
+		}, nil
+	case domain.InProgress:
+		return domain.Binding{
+			IsAsync:       true,
+			OperationData: bindOperationData,
+		}, nil
+	default:
+		return domain.Binding{}, apiresponses.ErrBindingAlreadyExists
+	}
+}
+
+// createBinding issues the credentials in the runtime and stores the result of the binding creation
+func (b *BindEndpoint) createBinding(instance *internal.Instance, binding *internal.Binding, expirationSeconds int) {
+	log := b.log.WithField("instanceID", binding.InstanceID).WithField("bindingID", binding.ID)
+	ctx, cancel := context.WithTimeout(context.Background(), bindingTimeout)
+	defer cancel()
+
+	kubeconfig, expiresAt, err := b.bindingsManager.Create(ctx, instance, binding.ID, expirationSeconds)
+	if err != nil {
+		log.Errorf("while creating binding: %s", err)
+		binding.State = domain.Failed
+		binding.Description = fmt.Sprintf("Binding creation failed: %s", err)
+	} else {
+		binding.State = domain.Succeeded
+		binding.Description = "Binding created"
+		binding.Kubeconfig = kubeconfig
+		binding.ExpiresAt = expiresAt
+	}
+	binding.UpdatedAt = time.Now()
+
+	if err := b.bindingsStorage.Update(binding); err != nil {
+		log.Errorf("while updating binding: %s", err)
+	}
+}
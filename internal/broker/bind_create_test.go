@@ -0,0 +1,338 @@
+package broker_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bindingInstanceID = "binding-instance-id"
+	bindingID         = "binding-id"
+)
+
+func TestBindEndpoint_Bind(t *testing.T) {
+	cfg := broker.BindingConfig{
+		Enabled:              true,
+		BindablePlans:        []string{"azure"},
+		ExpirationSeconds:    600,
+		MinExpirationSeconds: 600,
+		MaxExpirationSeconds: 7200,
+	}
+
+	t.Run("should create binding and return the kubeconfig", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		manager := &fakeBindingsManager{}
+		svc := broker.NewBind(cfg, st.Instances(), st.Bindings(), manager, logrus.New())
+
+		// when
+		resp, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, resp.AlreadyExists)
+		binding, err := st.Bindings().Get(bindingInstanceID, bindingID)
+		require.NoError(t, err)
+		assert.Equal(t, broker.Credentials{Kubeconfig: "kubeconfig-" + bindingID, ExpiresAt: binding.ExpiresAt}, resp.Credentials)
+		assert.Equal(t, domain.Succeeded, binding.State)
+		assert.Equal(t, "kubeconfig-"+bindingID, binding.Kubeconfig)
+		assert.Equal(t, []string{bindingID}, manager.created)
+		assert.Equal(t, []int{600}, manager.expirationSeconds)
+
+		// when
+		resp, err = svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, resp.AlreadyExists)
+		assert.Equal(t, []string{bindingID}, manager.created)
+	})
+
+	t.Run("should return 400 for unknown parameters", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		svc := broker.NewBind(cfg, st.Instances(), st.Bindings(), &fakeBindingsManager{}, logrus.New())
+
+		// when
+		_, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{RawParameters: json.RawMessage(`{"unknown": true}`)}, false)
+
+		// then
+		assertFailureStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("should create binding with the requested expiration", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		manager := &fakeBindingsManager{}
+		svc := broker.NewBind(cfg, st.Instances(), st.Bindings(), manager, logrus.New())
+
+		// when
+		resp, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{RawParameters: json.RawMessage(`{"expiration_seconds": 3600}`)}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []int{3600}, manager.expirationSeconds)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), resp.Credentials.(broker.Credentials).ExpiresAt, time.Minute)
+	})
+
+	for name, parameters := range map[string]string{
+		"below the minimum": `{"expiration_seconds": 60}`,
+		"above the maximum": `{"expiration_seconds": 86400}`,
+	} {
+		t.Run(fmt.Sprintf("should return 400 when the expiration is %s", name), func(t *testing.T) {
+			// given
+			st := fixBindingStorage(t)
+			svc := broker.NewBind(cfg, st.Instances(), st.Bindings(), &fakeBindingsManager{}, logrus.New())
+
+			// when
+			_, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{RawParameters: json.RawMessage(parameters)}, false)
+
+			// then
+			assertFailureStatusCode(t, err, http.StatusBadRequest)
+		})
+	}
+
+	t.Run("should return 409 when the binding expired", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		fixBinding(t, st, domain.Succeeded)
+		binding, err := st.Bindings().Get(bindingInstanceID, bindingID)
+		require.NoError(t, err)
+		expiredAt := time.Now()
+		binding.ExpiredAt = &expiredAt
+		require.NoError(t, st.Bindings().Update(binding))
+		svc := broker.NewBind(cfg, st.Instances(), st.Bindings(), &fakeBindingsManager{}, logrus.New())
+
+		// when
+		_, err = svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		assert.Equal(t, apiresponses.ErrBindingAlreadyExists, err)
+	})
+
+	t.Run("should return 409 when the binding creation failed before", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		svc := broker.NewBind(cfg, st.Instances(), st.Bindings(), &fakeBindingsManager{err: fmt.Errorf("runtime is not reachable")}, logrus.New())
+		_, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+		assertFailureStatusCode(t, err, http.StatusInternalServerError)
+
+		// when
+		_, err = svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		assert.Equal(t, apiresponses.ErrBindingAlreadyExists, err)
+	})
+
+	t.Run("should apply the expiration limits of the plan", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		manager := &fakeBindingsManager{}
+		planCfg := cfg
+		planCfg.PlanExpirationLimits = broker.PlanExpirationLimits{"azure": {MinExpirationSeconds: 3600, MaxExpirationSeconds: 86400}}
+		svc := broker.NewBind(planCfg, st.Instances(), st.Bindings(), manager, logrus.New())
+
+		// when
+		_, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{RawParameters: json.RawMessage(`{"expiration_seconds": 600}`)}, false)
+
+		// then
+		assertFailureStatusCode(t, err, http.StatusBadRequest)
+
+		// when
+		_, err = svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []int{3600}, manager.expirationSeconds)
+	})
+
+	t.Run("should return 422 when the plan is not bindable", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		svc := broker.NewBind(broker.BindingConfig{Enabled: true, BindablePlans: []string{"aws"}}, st.Instances(), st.Bindings(), &fakeBindingsManager{}, logrus.New())
+
+		// when
+		_, err := svc.Bind(context.Background(), bindingInstanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		assertFailureStatusCode(t, err, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestUnbindEndpoint_Unbind(t *testing.T) {
+	t.Run("should revoke and delete the binding", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		fixBinding(t, st, domain.Succeeded)
+		manager := &fakeBindingsManager{}
+		svc := broker.NewUnbind(st.Instances(), st.Bindings(), manager, logrus.New())
+
+		// when
+		_, err := svc.Unbind(context.Background(), bindingInstanceID, bindingID, domain.UnbindDetails{}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{bindingID}, manager.deleted)
+		_, err = broker.NewGetBinding(st.Bindings(), logrus.New()).GetBinding(context.Background(), bindingInstanceID, bindingID, domain.FetchBindingDetails{})
+		assertFailureStatusCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("should return 410 when the binding does not exist", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		svc := broker.NewUnbind(st.Instances(), st.Bindings(), &fakeBindingsManager{}, logrus.New())
+
+		// when
+		_, err := svc.Unbind(context.Background(), bindingInstanceID, bindingID, domain.UnbindDetails{}, false)
+
+		// then
+		assert.Equal(t, apiresponses.ErrBindingDoesNotExist, err)
+	})
+
+	t.Run("should return 422 when the binding is being created", func(t *testing.T) {
+		// given
+		st := fixBindingStorage(t)
+		fixBinding(t, st, domain.InProgress)
+		svc := broker.NewUnbind(st.Instances(), st.Bindings(), &fakeBindingsManager{}, logrus.New())
+
+		// when
+		_, err := svc.Unbind(context.Background(), bindingInstanceID, bindingID, domain.UnbindDetails{}, false)
+
+		// then
+		assert.Equal(t, apiresponses.ErrConcurrentInstanceAccess, err)
+	})
+}
+
+func TestGetBindingEndpoint_GetBinding(t *testing.T) {
+	// given
+	st := fixBindingStorage(t)
+	fixBinding(t, st, domain.Succeeded)
+	binding, err := st.Bindings().Get(bindingInstanceID, bindingID)
+	require.NoError(t, err)
+	svc := broker.NewGetBinding(st.Bindings(), logrus.New())
+
+	// when
+	resp, err := svc.GetBinding(context.Background(), bindingInstanceID, bindingID, domain.FetchBindingDetails{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, broker.Credentials{Kubeconfig: "kubeconfig", ExpiresAt: binding.ExpiresAt}, resp.Credentials)
+}
+
+func TestLastBindingOperationEndpoint_LastBindingOperation(t *testing.T) {
+	// given
+	st := fixBindingStorage(t)
+	fixBinding(t, st, domain.InProgress)
+	svc := broker.NewLastBindingOperation(st.Bindings(), logrus.New())
+
+	// when
+	resp, err := svc.LastBindingOperation(context.Background(), bindingInstanceID, bindingID, domain.PollDetails{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, resp.State)
+
+	// when
+	_, err = svc.LastBindingOperation(context.Background(), bindingInstanceID, "other-binding", domain.PollDetails{})
+
+	// then
+	assert.Equal(t, apiresponses.ErrBindingDoesNotExist, err)
+}
+
+func TestPlanExpirationLimits_Unmarshal(t *testing.T) {
+	t.Run("should parse the expiration limits of the plans", func(t *testing.T) {
+		// given
+		limits := broker.PlanExpirationLimits{}
+
+		// when
+		err := limits.Unmarshal("azure:3600:86400, aws:600:3600")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, broker.PlanExpirationLimits{
+			"azure": {MinExpirationSeconds: 3600, MaxExpirationSeconds: 86400},
+			"aws":   {MinExpirationSeconds: 600, MaxExpirationSeconds: 3600},
+		}, limits)
+	})
+
+	for name, in := range map[string]string{
+		"unknown plan":            "unknown:600:3600",
+		"missing maximum":         "azure:600",
+		"minimum above maximum":   "azure:3600:600",
+		"not a number of seconds": "azure:1h:2h",
+	} {
+		t.Run(fmt.Sprintf("should fail for %s", name), func(t *testing.T) {
+			// given
+			limits := broker.PlanExpirationLimits{}
+
+			// when
+			err := limits.Unmarshal(in)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func fixBindingStorage(t *testing.T) storage.BrokerStorage {
+	st := storage.NewMemoryStorage()
+	instance := fixture.FixInstance(bindingInstanceID)
+	instance.ServicePlanName = "azure"
+	require.NoError(t, st.Instances().Insert(instance))
+	return st
+}
+
+func fixBinding(t *testing.T, st storage.BrokerStorage, state domain.LastOperationState) {
+	now := time.Now()
+	err := st.Bindings().Insert(&internal.Binding{
+		ID:         bindingID,
+		InstanceID: bindingInstanceID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ExpiresAt:  now.Add(10 * time.Minute),
+		Kubeconfig: "kubeconfig",
+		State:      state,
+	})
+	require.NoError(t, err)
+}
+
+func assertFailureStatusCode(t *testing.T, err error, statusCode int) {
+	require.Error(t, err)
+	require.IsType(t, &apiresponses.FailureResponse{}, err)
+	assert.Equal(t, statusCode, err.(*apiresponses.FailureResponse).ValidatedStatusCode(nil))
+}
+
+type fakeBindingsManager struct {
+	err               error
+	created           []string
+	expirationSeconds []int
+	deleted           []string
+}
+
+func (m *fakeBindingsManager) Create(_ context.Context, _ *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error) {
+	if m.err != nil {
+		return "", time.Time{}, m.err
+	}
+	m.created = append(m.created, bindingID)
+	m.expirationSeconds = append(m.expirationSeconds, expirationSeconds)
+	return "kubeconfig-" + bindingID, time.Now().Add(time.Duration(expirationSeconds) * time.Second), nil
+}
+
+func (m *fakeBindingsManager) Delete(_ context.Context, _ *internal.Instance, bindingID string) error {
+	m.deleted = append(m.deleted, bindingID)
+	return m.err
+}
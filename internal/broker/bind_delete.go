@@ -2,17 +2,31 @@ package broker
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
 	"github.com/sirupsen/logrus"
 )
 
 type UnbindEndpoint struct {
+	instancesStorage storage.Instances
+	bindingsStorage  storage.Bindings
+	bindingsManager  BindingsManager
+
 	log logrus.FieldLogger
 }
 
-func NewUnbind(log logrus.FieldLogger) *UnbindEndpoint {
-	return &UnbindEndpoint{log: log.WithField("service", "UnbindEndpoint")}
+func NewUnbind(instancesStorage storage.Instances, bindingsStorage storage.Bindings, bindingsManager BindingsManager, log logrus.FieldLogger) *UnbindEndpoint {
+	return &UnbindEndpoint{
+		instancesStorage: instancesStorage,
+		bindingsStorage:  bindingsStorage,
+		bindingsManager:  bindingsManager,
+		log:              log.WithField("service", "UnbindEndpoint"),
+	}
 }
 
 // Unbind deletes an existing service binding
@@ -23,6 +37,35 @@ func (b *UnbindEndpoint) Unbind(ctx context.Context, instanceID, bindingID strin
 	b.log.Infof("Unbind details: %+v", details)
 	b.log.Infof("Unbind asyncAllowed: %v", asyncAllowed)
 
+	binding, err := b.bindingsStorage.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		return domain.UnbindSpec{}, apiresponses.ErrBindingDoesNotExist
+	case err != nil:
+		return domain.UnbindSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to get binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to get binding %s", bindingID))
+	}
+	if binding.State == domain.InProgress {
+		return domain.UnbindSpec{}, apiresponses.ErrConcurrentInstanceAccess
+	}
+
+	instance, err := b.instancesStorage.GetByID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		// the runtime is already gone together with the service account
+		b.log.Infof("Instance %s not found, removing binding %s", instanceID, bindingID)
+	case err != nil:
+		return domain.UnbindSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to get instance %s", instanceID), http.StatusInternalServerError, fmt.Sprintf("failed to get instance %s", instanceID))
+	default:
+		if err := b.bindingsManager.Delete(ctx, instance, bindingID); err != nil {
+			b.log.Errorf("while revoking binding %s: %s", bindingID, err)
+			return domain.UnbindSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to revoke binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to revoke binding %s", bindingID))
+		}
+	}
+
+	if err := b.bindingsStorage.Delete(instanceID, bindingID); err != nil {
+		return domain.UnbindSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to delete binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to delete binding %s", bindingID))
+	}
+
 	return domain.UnbindSpec{
 		IsAsync: false,
 	}, nil
@@ -2,17 +2,24 @@ package broker
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
 	"github.com/sirupsen/logrus"
 )
 
 type GetBindingEndpoint struct {
+	bindingsStorage storage.Bindings
+
 	log logrus.FieldLogger
 }
 
-func NewGetBinding(log logrus.FieldLogger) *GetBindingEndpoint {
-	return &GetBindingEndpoint{log: log.WithField("service", "GetBindingEndpoint")}
+func NewGetBinding(bindingsStorage storage.Bindings, log logrus.FieldLogger) *GetBindingEndpoint {
+	return &GetBindingEndpoint{bindingsStorage: bindingsStorage, log: log.WithField("service", "GetBindingEndpoint")}
 }
 
 // GetBinding fetches an existing service binding
@@ -22,7 +29,20 @@ func (b *GetBindingEndpoint) GetBinding(_ context.Context, instanceID, bindingID
 	b.log.Infof("GetBinding instanceID: %s", instanceID)
 	b.log.Infof("GetBinding bindingID: %s", bindingID)
 
+	binding, err := b.bindingsStorage.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		return domain.GetBindingSpec{}, apiresponses.ErrBindingNotFound
+	case err != nil:
+		return domain.GetBindingSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to get binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to get binding %s", bindingID))
+	}
+
+	// the binding which is being created or failed is not retrievable
+	if binding.State != domain.Succeeded {
+		return domain.GetBindingSpec{}, apiresponses.ErrBindingNotFound
+	}
+
 	return domain.GetBindingSpec{
-		Credentials: dummyCredentials,
+		Credentials: Credentials{Kubeconfig: binding.Kubeconfig, ExpiresAt: binding.ExpiresAt},
 	}, nil
 }
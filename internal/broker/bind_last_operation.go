@@ -3,17 +3,23 @@ package broker
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
 	"github.com/sirupsen/logrus"
 )
 
 type LastBindingOperationEndpoint struct {
+	bindingsStorage storage.Bindings
+
 	log logrus.FieldLogger
 }
 
-func NewLastBindingOperation(log logrus.FieldLogger) *LastBindingOperationEndpoint {
-	return &LastBindingOperationEndpoint{log: log.WithField("service", "LastBindingOperationEndpoint")}
+func NewLastBindingOperation(bindingsStorage storage.Bindings, log logrus.FieldLogger) *LastBindingOperationEndpoint {
+	return &LastBindingOperationEndpoint{bindingsStorage: bindingsStorage, log: log.WithField("service", "LastBindingOperationEndpoint")}
 }
 
 // LastBindingOperation fetches last operation state for a service binding
@@ -24,5 +30,16 @@ func (b *LastBindingOperationEndpoint) LastBindingOperation(ctx context.Context,
 	b.log.Infof("LastBindingOperation bindingID: %s", bindingID)
 	b.log.Infof("LastBindingOperation details: %+v", details)
 
-	return domain.LastOperation{}, fmt.Errorf("not supported")
+	binding, err := b.bindingsStorage.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		return domain.LastOperation{}, apiresponses.ErrBindingDoesNotExist
+	case err != nil:
+		return domain.LastOperation{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to get binding %s", bindingID), http.StatusInternalServerError, fmt.Sprintf("failed to get binding %s", bindingID))
+	}
+
+	return domain.LastOperation{
+		State:       binding.State,
+		Description: binding.Description,
+	}, nil
 }
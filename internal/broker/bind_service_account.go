@@ -0,0 +1,141 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	bindingNamespace      = "kyma-system"
+	bindingResourcePrefix = "kyma-binding-"
+	bindingIDLabel        = "kyma-project.io/binding-id"
+)
+
+type K8sClientProvider interface {
+	K8sClientForRuntimeID(rid string) (client.Client, error)
+}
+
+type ServiceAccountKubeconfigBuilder interface {
+	BuildFromServiceAccountToken(instance *internal.Instance, token string) (string, error)
+}
+
+// BindingsManager issues and revokes the credentials of service bindings in the runtime
+type BindingsManager interface {
+	Create(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error)
+	Delete(ctx context.Context, instance *internal.Instance, bindingID string) error
+}
+
+// ServiceAccountBindingsManager creates a dedicated ServiceAccount bound to the configured ClusterRole for every binding
+// and returns a kubeconfig with a time-bound token of the ServiceAccount. Deleting the ServiceAccount revokes all its tokens.
+type ServiceAccountBindingsManager struct {
+	clientProvider    K8sClientProvider
+	kubeconfigBuilder ServiceAccountKubeconfigBuilder
+	clusterRole       string
+}
+
+func NewServiceAccountBindingsManager(clientProvider K8sClientProvider, kubeconfigBuilder ServiceAccountKubeconfigBuilder, clusterRole string) *ServiceAccountBindingsManager {
+	return &ServiceAccountBindingsManager{
+		clientProvider:    clientProvider,
+		kubeconfigBuilder: kubeconfigBuilder,
+		clusterRole:       clusterRole,
+	}
+}
+
+func (m *ServiceAccountBindingsManager) Create(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error) {
+	k8sClient, err := m.clientProvider.K8sClientForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while getting k8s client for runtime %s: %w", instance.RuntimeID, err)
+	}
+
+	name := bindingResourceName(bindingID)
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker",
+		bindingIDLabel:                 strings.ToLower(bindingID),
+	}
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: bindingNamespace,
+			Labels:    labels,
+		},
+	}
+	err = k8sClient.Create(ctx, serviceAccount)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", time.Time{}, fmt.Errorf("while creating service account %s: %w", name, err)
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     m.clusterRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      name,
+				Namespace: bindingNamespace,
+			},
+		},
+	}
+	err = k8sClient.Create(ctx, clusterRoleBinding)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", time.Time{}, fmt.Errorf("while creating cluster role binding %s: %w", name, err)
+	}
+
+	expiration := int64(expirationSeconds)
+	tokenRequest := &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			ExpirationSeconds: &expiration,
+		},
+	}
+	err = k8sClient.SubResource("token").Create(ctx, serviceAccount, tokenRequest)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating token for service account %s: %w", name, err)
+	}
+
+	kubeconfig, err := m.kubeconfigBuilder.BuildFromServiceAccountToken(instance, tokenRequest.Status.Token)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while building kubeconfig: %w", err)
+	}
+
+	return kubeconfig, tokenRequest.Status.ExpirationTimestamp.Time, nil
+}
+
+func (m *ServiceAccountBindingsManager) Delete(ctx context.Context, instance *internal.Instance, bindingID string) error {
+	k8sClient, err := m.clientProvider.K8sClientForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return fmt.Errorf("while getting k8s client for runtime %s: %w", instance.RuntimeID, err)
+	}
+
+	name := bindingResourceName(bindingID)
+	err = k8sClient.Delete(ctx, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("while deleting cluster role binding %s: %w", name, err)
+	}
+	err = k8sClient.Delete(ctx, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: bindingNamespace}})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("while deleting service account %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func bindingResourceName(bindingID string) string {
+	return bindingResourcePrefix + strings.ToLower(bindingID)
+}
@@ -0,0 +1,56 @@
+package broker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceAccountBindingsManager(t *testing.T) {
+	// given
+	k8sClient := fake.NewClientBuilder().WithScheme(internal.NewSchemeForTests()).Build()
+	manager := broker.NewServiceAccountBindingsManager(kubeconfig.NewFakeK8sClientProvider(kubeconfig.NewFakeTokenRequestClient(k8sClient)), &fakeKubeconfigBuilder{}, "cluster-admin")
+	instance := fixture.FixInstance(bindingInstanceID)
+	name := client.ObjectKey{Namespace: "kyma-system", Name: "kyma-binding-binding-id"}
+
+	// when
+	kubeconfig, expiresAt, err := manager.Create(context.Background(), &instance, bindingID, 600)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "token-kyma-system-kyma-binding-binding-id", kubeconfig)
+	assert.False(t, expiresAt.IsZero())
+	serviceAccount := &corev1.ServiceAccount{}
+	require.NoError(t, k8sClient.Get(context.Background(), name, serviceAccount))
+	assert.Equal(t, "binding-id", serviceAccount.Labels["kyma-project.io/binding-id"])
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: name.Name}, clusterRoleBinding))
+	assert.Equal(t, "cluster-admin", clusterRoleBinding.RoleRef.Name)
+	assert.Equal(t, name.Name, clusterRoleBinding.Subjects[0].Name)
+
+	// when
+	err = manager.Delete(context.Background(), &instance, bindingID)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(context.Background(), name, &corev1.ServiceAccount{})))
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(context.Background(), client.ObjectKey{Name: name.Name}, &rbacv1.ClusterRoleBinding{})))
+	require.NoError(t, manager.Delete(context.Background(), &instance, bindingID))
+}
+
+type fakeKubeconfigBuilder struct{}
+
+func (b *fakeKubeconfigBuilder) BuildFromServiceAccountToken(_ *internal.Instance, token string) (string, error) {
+	return token, nil
+}
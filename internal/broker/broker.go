@@ -43,7 +43,8 @@ type Config struct {
 	TrialDocsURL                            string `envconfig:"default="`
 	IncludeNewMachineTypesInSchema          bool   `envconfig:"default=false"`
 
-	Binding BindingConfig
+	Binding   BindingConfig
+	Preflight PreflightConfig
 }
 
 type ServicesConfig map[string]Service
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -66,6 +67,8 @@ type ProvisionEndpoint struct {
 	euAccessWhitelist        euaccess.WhitelistSet
 	euAccessRejectionMessage string
 
+	preflightCheckers []PreflightChecker
+
 	log logrus.FieldLogger
 }
 
@@ -109,6 +112,11 @@ func NewProvision(cfg Config,
 	}
 }
 
+// UsePreflightCheckers makes the endpoint reject the provisioning requests which do not pass the given checks
+func (b *ProvisionEndpoint) UsePreflightCheckers(checkers ...PreflightChecker) {
+	b.preflightCheckers = checkers
+}
+
 // Provision creates a new service instance
 //
 //	PUT /v2/service_instances/{instance_id}
@@ -158,6 +166,17 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 		return b.handleExistingOperation(existingOperation, provisioningParameters)
 	}
 
+	err = b.checkPreflight(provisioningParameters, logger)
+	var preflightErr *PreflightError
+	if errors.As(err, &preflightErr) {
+		errMsg := fmt.Sprintf("[instanceID: %s] %s", instanceID, err)
+		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, errMsg)
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("[instanceID: %s] %s", instanceID, err)
+		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, errMsg)
+	}
+
 	shootName := gardener.CreateShootName()
 	shootDomainSuffix := strings.Trim(b.shootDomain, ".")
 
@@ -272,6 +291,12 @@ func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails,
 			return ersContext, parameters, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 	}
+	if err := validateHibernation(details.PlanID, parameters.Hibernation); err != nil {
+		return ersContext, parameters, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+	}
+	if parameters.Hibernation != nil {
+		parameters.Hibernation = parameters.Hibernation.Schedule()
+	}
 
 	planValidator, err := b.validator(&details, provider, ctx)
 	if err != nil {
@@ -341,11 +366,46 @@ func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails,
 	return ersContext, parameters, nil
 }
 
+// checkPreflight runs the pre-flight checks of the new instance, the repeated requests of the existing instance are not checked
+func (b *ProvisionEndpoint) checkPreflight(provisioningParameters internal.ProvisioningParameters, logger logrus.FieldLogger) error {
+	if len(b.preflightCheckers) == 0 {
+		return nil
+	}
+	defaults, err := b.planDefaults(provisioningParameters.PlanID, provisioningParameters.PlatformProvider, provisioningParameters.Parameters.Provider)
+	if err != nil {
+		return fmt.Errorf("while obtaining plan defaults: %w", err)
+	}
+	request := PreflightRequest{
+		PlanID:         provisioningParameters.PlanID,
+		PlatformRegion: provisioningParameters.PlatformRegion,
+		ERSContext:     provisioningParameters.ErsContext,
+		Parameters:     provisioningParameters.Parameters,
+		Defaults:       defaults,
+	}
+	for _, checker := range b.preflightCheckers {
+		if err := checker.Check(request, logger); err != nil {
+			logger.Infof("Provisioning rejected by the pre-flight check: %s", err)
+			return &PreflightError{err: err}
+		}
+	}
+	return nil
+}
+
 func isEuRestrictedAccess(ctx context.Context) bool {
 	platformRegion, _ := middleware.RegionFromContext(ctx)
 	return euaccess.IsEURestrictedAccess(platformRegion)
 }
 
+func validateHibernation(planID string, hibernation *internal.HibernationDTO) error {
+	if hibernation == nil || hibernation.IsEmpty() {
+		return nil
+	}
+	if !IsHibernationSupportedPlan(planID) {
+		return fmt.Errorf("hibernation is not supported for the %s plan", PlanNamesMapping[planID])
+	}
+	return hibernation.Validate()
+}
+
 // Rudimentary kubeconfig validation
 func validateKubeconfig(kubeconfig string) error {
 	config, err := clientcmd.Load([]byte(kubeconfig))
@@ -0,0 +1,227 @@
+package broker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+)
+
+type PreflightConfig struct {
+	// AccountPool rejects the requests when no hyperscaler account can be assigned to the global account
+	AccountPool bool `envconfig:"default=false"`
+	// AccountPoolStatusTTL specifies how long the account pool status is reused before it is read again from Gardener
+	AccountPoolStatusTTL time.Duration `envconfig:"default=1m"`
+	// MaxInstancesPerGlobalAccount limits the number of the instances of the global account, 0 means no limit
+	MaxInstancesPerGlobalAccount int `envconfig:"default=0"`
+	// AllowedMachineTypes restricts the machine types of the listed global accounts
+	AllowedMachineTypes GlobalAccountMachineTypes `envconfig:"optional"`
+}
+
+// GlobalAccountMachineTypes maps the global account IDs to the machine types allowed for them
+type GlobalAccountMachineTypes map[string][]string
+
+// Unmarshal provides custom parsing of the allowed machine types in the format <global account ID>=<machine type>;<machine type> separated by commas.
+// Implements envconfig.Unmarshal interface.
+func (m *GlobalAccountMachineTypes) Unmarshal(in string) error {
+	machineTypes := GlobalAccountMachineTypes{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		globalAccountID, types, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(types) == "" {
+			return fmt.Errorf("invalid allowed machine types %q, expected <global account ID>=<machine type>;<machine type>", entry)
+		}
+		globalAccountID = strings.TrimSpace(globalAccountID)
+		for _, machineType := range strings.Split(types, ";") {
+			machineTypes[globalAccountID] = append(machineTypes[globalAccountID], strings.TrimSpace(machineType))
+		}
+	}
+	*m = machineTypes
+	return nil
+}
+
+// AccountPoolStatus provides the capacity of the hyperscaler account pools
+type AccountPoolStatus interface {
+	Status(hyperscalerType hyperscaler.Type, euAccess bool) (hyperscaler.PoolStatus, error)
+}
+
+// PreflightRequest is the provisioning request verified by the pre-flight checks
+type PreflightRequest struct {
+	PlanID         string
+	PlatformRegion string
+	ERSContext     internal.ERSContext
+	Parameters     internal.ProvisioningParametersDTO
+	Defaults       *gqlschema.ClusterConfigInput
+}
+
+// PreflightChecker rejects the provisioning requests which are certain to fail.
+// The returned error is sent to the client, the checkers should only log the errors which prevent the check.
+type PreflightChecker interface {
+	Check(request PreflightRequest, log logrus.FieldLogger) error
+}
+
+// PreflightError is returned by the provisioning for the requests rejected by the pre-flight checks
+type PreflightError struct {
+	err error
+}
+
+func (e *PreflightError) Error() string {
+	return e.err.Error()
+}
+
+func (e *PreflightError) Unwrap() error {
+	return e.err
+}
+
+// NewPreflightCheckers creates the checkers enabled in the configuration
+func NewPreflightCheckers(cfg PreflightConfig, instances storage.Instances, accountPool AccountPoolStatus) []PreflightChecker {
+	var checkers []PreflightChecker
+	if cfg.AccountPool {
+		checkers = append(checkers, newAccountPoolChecker(accountPool, instances, cfg.AccountPoolStatusTTL))
+	}
+	if cfg.MaxInstancesPerGlobalAccount > 0 {
+		checkers = append(checkers, &instancesLimitChecker{instances: instances, limit: cfg.MaxInstancesPerGlobalAccount})
+	}
+	if len(cfg.AllowedMachineTypes) > 0 {
+		checkers = append(checkers, &machineTypesChecker{allowed: cfg.AllowedMachineTypes})
+	}
+	return checkers
+}
+
+// accountPoolChecker verifies that a hyperscaler account can be assigned to the global account.
+// The global account which already has an instance on the hyperscaler keeps its account, so a free account is not needed.
+type accountPoolChecker struct {
+	accountPool AccountPoolStatus
+	instances   storage.Instances
+	statusTTL   time.Duration
+
+	mu       sync.Mutex
+	statuses map[accountPoolKey]cachedPoolStatus
+	now      func() time.Time
+}
+
+type accountPoolKey struct {
+	hyperscalerType string
+	euAccess        bool
+}
+
+type cachedPoolStatus struct {
+	status    hyperscaler.PoolStatus
+	expiresAt time.Time
+}
+
+func newAccountPoolChecker(accountPool AccountPoolStatus, instances storage.Instances, statusTTL time.Duration) *accountPoolChecker {
+	return &accountPoolChecker{
+		accountPool: accountPool,
+		instances:   instances,
+		statusTTL:   statusTTL,
+		statuses:    map[accountPoolKey]cachedPoolStatus{},
+		now:         time.Now,
+	}
+}
+
+func (c *accountPoolChecker) Check(request PreflightRequest, log logrus.FieldLogger) error {
+	// the trial and the sap-converged-cloud plans use the shared accounts, the own_cluster plan does not need any account
+	if IsTrialPlan(request.PlanID) || IsSapConvergedCloudPlan(request.PlanID) || IsOwnClusterPlan(request.PlanID) {
+		return nil
+	}
+	if request.Defaults == nil || request.Defaults.GardenerConfig == nil {
+		return nil
+	}
+	hyperscalerType, err := hyperscaler.ParseType(request.Defaults.GardenerConfig.Provider)
+	if err != nil {
+		log.Warnf("skipping the account pool check: %s", err)
+		return nil
+	}
+	euAccess := internal.IsEuAccess(request.PlatformRegion)
+
+	status, err := c.status(hyperscalerType, euAccess)
+	if err != nil {
+		log.Warnf("skipping the account pool check, unable to get the %s account pool status: %s", hyperscalerType.GetKey(), err)
+		return nil
+	}
+	if status.Free > 0 {
+		return nil
+	}
+
+	instances, _, _, err := c.instances.List(dbmodel.InstanceFilter{GlobalAccountIDs: []string{request.ERSContext.GlobalAccountID}})
+	if err != nil {
+		log.Warnf("skipping the account pool check, unable to list the instances of the global account: %s", err)
+		return nil
+	}
+	for _, instance := range instances {
+		if IsTrialPlan(instance.ServicePlanID) || IsSapConvergedCloudPlan(instance.ServicePlanID) {
+			continue
+		}
+		if strings.EqualFold(string(instance.Provider), hyperscalerType.GetName()) && internal.IsEuAccess(instance.Parameters.PlatformRegion) == euAccess {
+			return nil
+		}
+	}
+	return fmt.Errorf("no %s hyperscaler account is available for the global account %s", hyperscalerType.GetKey(), request.ERSContext.GlobalAccountID)
+}
+
+// status returns the account pool status read from Gardener at most once in the TTL, the errors are not cached
+func (c *accountPoolChecker) status(hyperscalerType hyperscaler.Type, euAccess bool) (hyperscaler.PoolStatus, error) {
+	key := accountPoolKey{hyperscalerType: hyperscalerType.GetKey(), euAccess: euAccess}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, found := c.statuses[key]; found && c.now().Before(cached.expiresAt) {
+		return cached.status, nil
+	}
+	status, err := c.accountPool.Status(hyperscalerType, euAccess)
+	if err != nil {
+		return hyperscaler.PoolStatus{}, err
+	}
+	c.statuses[key] = cachedPoolStatus{status: status, expiresAt: c.now().Add(c.statusTTL)}
+	return status, nil
+}
+
+// instancesLimitChecker limits the number of the instances of the global account
+type instancesLimitChecker struct {
+	instances storage.Instances
+	limit     int
+}
+
+func (c *instancesLimitChecker) Check(request PreflightRequest, log logrus.FieldLogger) error {
+	count, err := c.instances.GetNumberOfInstancesForGlobalAccountID(request.ERSContext.GlobalAccountID)
+	if err != nil {
+		log.Warnf("skipping the instances limit check, unable to count the instances of the global account: %s", err)
+		return nil
+	}
+	if count >= c.limit {
+		return fmt.Errorf("the global account %s reached the limit of %d instances", request.ERSContext.GlobalAccountID, c.limit)
+	}
+	return nil
+}
+
+// machineTypesChecker restricts the machine types of the global accounts, the machine type of the plan is used if it is not provided
+type machineTypesChecker struct {
+	allowed GlobalAccountMachineTypes
+}
+
+func (c *machineTypesChecker) Check(request PreflightRequest, _ logrus.FieldLogger) error {
+	allowed, found := c.allowed[request.ERSContext.GlobalAccountID]
+	if !found {
+		return nil
+	}
+	machineType := valueOfPtr(request.Parameters.MachineType)
+	if machineType == "" && request.Defaults != nil && request.Defaults.GardenerConfig != nil {
+		machineType = request.Defaults.GardenerConfig.MachineType
+	}
+	if machineType == "" || slices.Contains(allowed, machineType) {
+		return nil
+	}
+	return fmt.Errorf("the machine type %s is not allowed for the global account %s, the allowed machine types: %s",
+		machineType, request.ERSContext.GlobalAccountID, strings.Join(allowed, ", "))
+}
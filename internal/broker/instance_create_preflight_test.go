@@ -0,0 +1,311 @@
+package broker_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker/automock"
+	"github.com/kyma-project/kyma-environment-broker/internal/euaccess"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvision_PreflightChecks(t *testing.T) {
+	t.Run("should reject the request exceeding the instances limit with 422", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixture.FixInstance(otherInstanceID)
+		instance.GlobalAccountID = globalAccountID
+		require.NoError(t, memoryStorage.Instances().Insert(instance))
+
+		queue := &automock.Queue{}
+		provisionEndpoint := newProvisionEndpointWithPreflight(memoryStorage, queue,
+			broker.PreflightConfig{MaxInstancesPerGlobalAccount: 1}, &fakeAccountPool{})
+
+		// when
+		_, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, fixPreflightProvisionDetails(""), true)
+
+		// then
+		require.Error(t, err)
+		require.IsType(t, &apiresponses.FailureResponse{}, err)
+		apiErr := err.(*apiresponses.FailureResponse)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.ValidatedStatusCode(nil))
+		assert.Contains(t, apiErr.Error(), "reached the limit of 1 instances")
+		queue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
+	t.Run("should accept the repeated request of the instance at the instances limit", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		queue := &automock.Queue{}
+		queue.On("Add", mock.AnythingOfType("string"))
+		provisionEndpoint := newProvisionEndpointWithPreflight(memoryStorage, queue,
+			broker.PreflightConfig{MaxInstancesPerGlobalAccount: 1}, &fakeAccountPool{})
+		first, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, fixPreflightProvisionDetails(""), true)
+		require.NoError(t, err)
+
+		// when
+		repeated, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, fixPreflightProvisionDetails(""), true)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, first.OperationData, repeated.OperationData)
+		queue.AssertNumberOfCalls(t, "Add", 1)
+	})
+
+	t.Run("should provision when all checks pass", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		queue := &automock.Queue{}
+		queue.On("Add", mock.AnythingOfType("string"))
+		provisionEndpoint := newProvisionEndpointWithPreflight(memoryStorage, queue, broker.PreflightConfig{
+			AccountPool:                  true,
+			MaxInstancesPerGlobalAccount: 1,
+			AllowedMachineTypes:          broker.GlobalAccountMachineTypes{globalAccountID: {"Standard_D4_v3", "Standard_D8_v3"}},
+		}, &fakeAccountPool{free: 1})
+
+		// when
+		response, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, fixPreflightProvisionDetails("Standard_D8_v3"), true)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, response.IsAsync)
+		queue.AssertNumberOfCalls(t, "Add", 1)
+	})
+}
+
+func TestPreflightCheckers(t *testing.T) {
+	request := broker.PreflightRequest{
+		PlanID:         broker.AzurePlanID,
+		PlatformRegion: "cf-eu10",
+		ERSContext:     internal.ERSContext{GlobalAccountID: globalAccountID},
+		Defaults:       &gqlschema.ClusterConfigInput{GardenerConfig: &gqlschema.GardenerConfigInput{Provider: "azure", MachineType: "Standard_D4_v3"}},
+	}
+
+	t.Run("should reject the request when the account pool is exhausted", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{AccountPool: true}, memoryStorage.Instances(), &fakeAccountPool{})
+
+		// when
+		err := runPreflightCheckers(checkers, request)
+
+		// then
+		assert.EqualError(t, err, "no azure hyperscaler account is available for the global account "+globalAccountID)
+	})
+
+	t.Run("should accept the global account which already has the account assigned", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixture.FixInstance(otherInstanceID)
+		instance.GlobalAccountID = globalAccountID
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.Provider = internal.Azure
+		instance.Parameters.PlatformRegion = "cf-eu10"
+		require.NoError(t, memoryStorage.Instances().Insert(instance))
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{AccountPool: true}, memoryStorage.Instances(), &fakeAccountPool{})
+
+		// when
+		err := runPreflightCheckers(checkers, request)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should skip the account pool check for the trial plan", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{AccountPool: true}, memoryStorage.Instances(), &fakeAccountPool{})
+		trialRequest := request
+		trialRequest.PlanID = broker.TrialPlanID
+
+		// when
+		err := runPreflightCheckers(checkers, trialRequest)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reuse the account pool status within the TTL", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		accountPool := &fakeAccountPool{free: 1}
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{AccountPool: true, AccountPoolStatusTTL: time.Hour}, memoryStorage.Instances(), accountPool)
+
+		// when
+		require.NoError(t, runPreflightCheckers(checkers, request))
+		require.NoError(t, runPreflightCheckers(checkers, request))
+
+		// then
+		assert.Equal(t, 1, accountPool.calls)
+	})
+
+	t.Run("should read the account pool status again after the TTL", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		accountPool := &fakeAccountPool{free: 1}
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{AccountPool: true}, memoryStorage.Instances(), accountPool)
+
+		// when
+		require.NoError(t, runPreflightCheckers(checkers, request))
+		require.NoError(t, runPreflightCheckers(checkers, request))
+
+		// then
+		assert.Equal(t, 2, accountPool.calls)
+	})
+
+	t.Run("should skip the account pool check when the pool status is not available", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{AccountPool: true}, memoryStorage.Instances(), &fakeAccountPool{err: fmt.Errorf("gardener not available")})
+
+		// when
+		err := runPreflightCheckers(checkers, request)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject the default machine type not allowed for the global account", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{
+			AllowedMachineTypes: broker.GlobalAccountMachineTypes{globalAccountID: {"Standard_D8_v3"}},
+		}, memoryStorage.Instances(), &fakeAccountPool{})
+
+		// when
+		err := runPreflightCheckers(checkers, request)
+
+		// then
+		assert.EqualError(t, err, fmt.Sprintf("the machine type Standard_D4_v3 is not allowed for the global account %s, the allowed machine types: Standard_D8_v3", globalAccountID))
+	})
+
+	t.Run("should not restrict the machine types of the global accounts not listed", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{
+			AllowedMachineTypes: broker.GlobalAccountMachineTypes{"other-global-account": {"Standard_D8_v3"}},
+		}, memoryStorage.Instances(), &fakeAccountPool{})
+		customRequest := request
+		customRequest.Parameters.MachineType = ptr.String("Standard_D48_v3")
+
+		// when
+		err := runPreflightCheckers(checkers, customRequest)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not create the disabled checkers", func(t *testing.T) {
+		// when
+		checkers := broker.NewPreflightCheckers(broker.PreflightConfig{}, storage.NewMemoryStorage().Instances(), &fakeAccountPool{})
+
+		// then
+		assert.Empty(t, checkers)
+	})
+}
+
+func TestGlobalAccountMachineTypes_Unmarshal(t *testing.T) {
+	t.Run("should parse the machine types of the global accounts", func(t *testing.T) {
+		// given
+		machineTypes := broker.GlobalAccountMachineTypes{}
+
+		// when
+		err := machineTypes.Unmarshal("ga-1=m6i.large;m6i.xlarge, ga-2=Standard_D4_v3")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, broker.GlobalAccountMachineTypes{
+			"ga-1": {"m6i.large", "m6i.xlarge"},
+			"ga-2": {"Standard_D4_v3"},
+		}, machineTypes)
+	})
+
+	t.Run("should fail for the global account without machine types", func(t *testing.T) {
+		// given
+		machineTypes := broker.GlobalAccountMachineTypes{}
+
+		// when
+		err := machineTypes.Unmarshal("ga-1")
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func newProvisionEndpointWithPreflight(memoryStorage storage.BrokerStorage, queue *automock.Queue, cfg broker.PreflightConfig, accountPool broker.AccountPoolStatus) *broker.ProvisionEndpoint {
+	factoryBuilder := &automock.PlanValidator{}
+	factoryBuilder.On("IsPlanSupport", planID).Return(true)
+	planDefaults := func(planID string, platformProvider internal.CloudProvider, provider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error) {
+		return &gqlschema.ClusterConfigInput{GardenerConfig: &gqlschema.GardenerConfigInput{Provider: "azure", MachineType: "Standard_D4_v3"}}, nil
+	}
+
+	provisionEndpoint := broker.NewProvision(
+		broker.Config{EnablePlans: []string{"azure"}, URL: brokerURL},
+		gardener.Config{Project: "test", ShootDomain: "example.com", DNSProviders: fixDNSProviders()},
+		memoryStorage.Operations(),
+		memoryStorage.Instances(),
+		queue,
+		factoryBuilder,
+		broker.PlansConfig{},
+		false,
+		planDefaults,
+		euaccess.WhitelistSet{},
+		"request rejected, your globalAccountId is not whitelisted",
+		logrus.StandardLogger(),
+		dashboardConfig,
+	)
+	provisionEndpoint.UsePreflightCheckers(broker.NewPreflightCheckers(cfg, memoryStorage.Instances(), accountPool)...)
+	return provisionEndpoint
+}
+
+func fixPreflightProvisionDetails(machineType string) domain.ProvisionDetails {
+	parameters := fmt.Sprintf(`{"name": "%s", "region": "%s"}`, clusterName, clusterRegion)
+	if machineType != "" {
+		parameters = fmt.Sprintf(`{"name": "%s", "region": "%s", "machineType": "%s"}`, clusterName, clusterRegion, machineType)
+	}
+	return domain.ProvisionDetails{
+		ServiceID:     serviceID,
+		PlanID:        planID,
+		RawParameters: json.RawMessage(parameters),
+		RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+	}
+}
+
+func runPreflightCheckers(checkers []broker.PreflightChecker, request broker.PreflightRequest) error {
+	for _, checker := range checkers {
+		if err := checker.Check(request, logrus.New()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeAccountPool struct {
+	free  int
+	err   error
+	calls int
+}
+
+func (p *fakeAccountPool) Status(hyperscalerType hyperscaler.Type, euAccess bool) (hyperscaler.PoolStatus, error) {
+	p.calls++
+	if p.err != nil {
+		return hyperscaler.PoolStatus{}, p.err
+	}
+	return hyperscaler.PoolStatus{HyperscalerType: hyperscalerType.GetKey(), EUAccess: euAccess, Free: p.free}, nil
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -72,6 +74,25 @@ func (b *LastOperationEndpoint) LastOperation(ctx context.Context, instanceID st
 	}, nil
 }
 
+// LastOperationError returns the details of the error of the failed operation, the last operation of the instance
+// is used if the operation ID is empty. It returns nil if the operation has not failed.
+func (b *LastOperationEndpoint) LastOperationError(instanceID, operationID string) (*kebError.ErrorDetails, error) {
+	var operation *internal.Operation
+	var err error
+	if operationID == "" {
+		operation, err = b.operationStorage.GetLastOperation(instanceID)
+	} else {
+		operation, err = b.operationStorage.GetOperationByID(operationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while getting operation from storage: %w", err)
+	}
+	if operation.InstanceID != instanceID || operation.State != domain.Failed {
+		return nil, nil
+	}
+	return kebError.Details(operation.LastError), nil
+}
+
 func mapStateToOSBCompliantState(opState domain.LastOperationState) domain.LastOperationState {
 	switch {
 	case opState == orchestration.Pending || opState == orchestration.Retrying:
@@ -222,6 +222,10 @@ func (b *UpdateEndpoint) processUpdateParameters(instance *internal.Instance, de
 			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 	}
+	if err := validateHibernation(instance.ServicePlanID, params.Hibernation); err != nil {
+		logger.Errorf("invalid hibernation parameters: %s", err.Error())
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+	}
 
 	operationID := uuid.New().String()
 	logger = logger.WithField("operationID", operationID)
@@ -270,6 +274,14 @@ func (b *UpdateEndpoint) processUpdateParameters(instance *internal.Instance, de
 	if params.MachineType != nil && *params.MachineType != "" {
 		instance.Parameters.Parameters.MachineType = params.MachineType
 	}
+	if params.Hibernation != nil {
+		instance.Parameters.Parameters.Hibernation = params.Hibernation.Schedule()
+		updateStorage = append(updateStorage, "Hibernation")
+	}
+	if params.Modules != nil {
+		instance.Parameters.Parameters.Modules = params.Modules
+		updateStorage = append(updateStorage, "Modules")
+	}
 	if len(updateStorage) > 0 {
 		if err := wait.PollImmediate(500*time.Millisecond, 2*time.Second, func() (bool, error) {
 			instance, err = b.instanceStorage.Update(*instance)
@@ -0,0 +1,92 @@
+package broker
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/gorilla/mux"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
+)
+
+// LastOperationErrorProvider returns the details of the error of the failed operation
+type LastOperationErrorProvider interface {
+	LastOperationError(instanceID, operationID string) (*kebError.ErrorDetails, error)
+}
+
+// LastOperationResponse extends the OSB last operation response with the details of the error of the failed operation
+type LastOperationResponse struct {
+	apiresponses.LastOperationResponse
+	Error *kebError.ErrorDetails `json:"error,omitempty"`
+}
+
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// lastOperationWithError adds the error details to the response of the last operation handler if the operation has failed.
+// The response of the handler is not changed if the details cannot be fetched.
+func lastOperationWithError(next http.HandlerFunc, provider LastOperationErrorProvider, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		buffered := &bufferedResponseWriter{header: http.Header{}, status: http.StatusOK}
+		next(buffered, req)
+
+		body := buffered.body.Bytes()
+		if buffered.status == http.StatusOK {
+			body = appendLastOperationError(body, provider, mux.Vars(req)["instance_id"], req.FormValue("operation"), logger)
+		}
+
+		for key, values := range buffered.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(buffered.status)
+		if _, err := w.Write(body); err != nil {
+			logger.Error("writing last operation response", err)
+		}
+	}
+}
+
+func appendLastOperationError(body []byte, provider LastOperationErrorProvider, instanceID, operationID string, logger lager.Logger) []byte {
+	var response LastOperationResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		logger.Error("decoding last operation response", err)
+		return body
+	}
+	if response.State != domain.Failed {
+		return body
+	}
+
+	details, err := provider.LastOperationError(instanceID, operationID)
+	if err != nil {
+		logger.Error("getting last operation error", err, lager.Data{"instanceID": instanceID, "operationID": operationID})
+		return body
+	}
+	if details == nil {
+		return body
+	}
+	response.Error = details
+
+	extended, err := json.Marshal(response)
+	if err != nil {
+		logger.Error("encoding last operation response", err)
+		return body
+	}
+	return append(extended, '\n')
+}
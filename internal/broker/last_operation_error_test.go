@@ -0,0 +1,118 @@
+package broker_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastOperation_LastOperationError(t *testing.T) {
+	t.Run("Should return error details of the failed operation", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperation()
+		operation.State = domain.Failed
+		operation.LastError = kebError.TimeoutError("operation has reached the time limit")
+		err := memoryStorage.Operations().InsertOperation(operation)
+		require.NoError(t, err)
+
+		lastOperationEndpoint := broker.NewLastOperation(memoryStorage.Operations(), logrus.StandardLogger())
+
+		// when
+		details, err := lastOperationEndpoint.LastOperationError(instID, operationID)
+		require.NoError(t, err)
+
+		// then
+		require.NotNil(t, details)
+		assert.Equal(t, kebError.CodeTimeout, details.Code)
+		assert.Equal(t, kebError.ErrKEBTimeOut, details.Reason)
+		assert.Equal(t, kebError.ErrKEB, details.Component)
+		assert.Equal(t, kebError.ActionRetry, details.Action)
+		assert.NotEmpty(t, details.Hint)
+	})
+	t.Run("Should not return error details of the succeeded operation", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		err := memoryStorage.Operations().InsertOperation(fixOperation())
+		require.NoError(t, err)
+
+		lastOperationEndpoint := broker.NewLastOperation(memoryStorage.Operations(), logrus.StandardLogger())
+
+		// when
+		details, err := lastOperationEndpoint.LastOperationError(instID, "")
+		require.NoError(t, err)
+
+		// then
+		assert.Nil(t, details)
+	})
+}
+
+func TestLastOperationRoute(t *testing.T) {
+	for name, tc := range map[string]struct {
+		state         domain.LastOperationState
+		expectedError *kebError.ErrorDetails
+	}{
+		"failed operation with error details": {
+			state: domain.Failed,
+			expectedError: &kebError.ErrorDetails{
+				Code:      kebError.CodeInvalidParameters,
+				Reason:    kebError.ErrInvalidParameters,
+				Component: kebError.ErrKEB,
+				Action:    kebError.ActionFixParameters,
+			},
+		},
+		"succeeded operation without error details": {
+			state: domain.Succeeded,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixOperation()
+			operation.State = tc.state
+			operation.LastError = kebError.InvalidParametersError("cannot map planID")
+			err := memoryStorage.Operations().InsertOperation(operation)
+			require.NoError(t, err)
+
+			kymaEnvBroker := &broker.KymaEnvironmentBroker{
+				LastOperationEndpoint: broker.NewLastOperation(memoryStorage.Operations(), logrus.StandardLogger()),
+			}
+			router := broker.AttachRoutes(mux.NewRouter(), kymaEnvBroker, lager.NewLogger("test"))
+
+			req := httptest.NewRequest(http.MethodGet, "/v2/service_instances/"+instID+"/last_operation?operation="+operationID, nil)
+			req.Header.Set("X-Broker-API-Version", "2.14")
+			rr := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rr, req)
+
+			// then
+			require.Equal(t, http.StatusOK, rr.Code)
+			var response broker.LastOperationResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.state, response.State)
+			assert.Equal(t, operationDescription, response.Description)
+			if tc.expectedError == nil {
+				assert.Nil(t, response.Error)
+				return
+			}
+			require.NotNil(t, response.Error)
+			assert.Equal(t, tc.expectedError.Code, response.Error.Code)
+			assert.Equal(t, tc.expectedError.Reason, response.Error.Reason)
+			assert.Equal(t, tc.expectedError.Component, response.Error.Component)
+			assert.Equal(t, tc.expectedError.Action, response.Error.Action)
+		})
+	}
+}
@@ -308,15 +308,12 @@ func AzureLiteSchema(machineTypesDisplay map[string]string, machineTypes []strin
 		properties.AutoScalerMax.Default = 10
 		properties.AutoScalerMin.Default = 2
 	}
+	properties.Hibernation = NewHibernationSchema()
 
 	return createSchemaWithProperties(properties, additionalParams, update, requiredSchemaProperties())
 }
 
 func FreemiumSchema(provider internal.CloudProvider, additionalParams, update bool, euAccessRestricted bool) *map[string]interface{} {
-	if update && !additionalParams {
-		return empty()
-	}
-
 	var regions []string
 	switch provider {
 	case internal.AWS:
@@ -333,10 +330,13 @@ func FreemiumSchema(provider internal.CloudProvider, additionalParams, update bo
 			Enum:      ToInterfaceSlice(regions),
 			MinLength: 1,
 		},
+		UpdateProperties: UpdateProperties{
+			Hibernation: NewHibernationSchema(),
+			Modules:     NewModulesSchema(),
+		},
 	}
 	if !update {
 		properties.Networking = NewNetworkingSchema()
-		properties.Modules = NewModulesSchema()
 	}
 
 	return createSchemaWithProperties(properties, additionalParams, update, requiredSchemaProperties())
@@ -345,14 +345,10 @@ func FreemiumSchema(provider internal.CloudProvider, additionalParams, update bo
 func TrialSchema(additionalParams, update bool) *map[string]interface{} {
 	properties := ProvisioningProperties{
 		Name: NameProperty(),
-	}
-
-	if !update {
-		properties.Modules = NewModulesSchema()
-	}
-
-	if update && !additionalParams {
-		return empty()
+		UpdateProperties: UpdateProperties{
+			Hibernation: NewHibernationSchema(),
+			Modules:     NewModulesSchema(),
+		},
 	}
 
 	return createSchemaWithProperties(properties, additionalParams, update, requiredTrialSchemaProperties())
@@ -365,22 +361,17 @@ func OwnClusterSchema(update bool) *map[string]interface{} {
 		ShootDomain: ShootDomainProperty(),
 		UpdateProperties: UpdateProperties{
 			Kubeconfig: KubeconfigProperty(),
+			Modules:    NewModulesSchema(),
 		},
 	}
 
 	if update {
 		return createSchemaWith(properties.UpdateProperties, update, requiredOwnClusterSchemaProperties())
 	} else {
-		properties.Modules = NewModulesSchema()
 		return createSchemaWith(properties, update, requiredOwnClusterSchemaProperties())
 	}
 }
 
-func empty() *map[string]interface{} {
-	empty := make(map[string]interface{}, 0)
-	return &empty
-}
-
 func createSchemaWithProperties(properties ProvisioningProperties, additionalParams, update bool, requiered []string) *map[string]interface{} {
 	if additionalParams {
 		properties.IncludeAdditional()
@@ -573,6 +564,16 @@ func IsOwnClusterPlan(planID string) bool {
 	return planID == OwnClusterPlanID
 }
 
+// IsHibernationSupportedPlan returns true for the non-production plans which accept the hibernation schedule
+func IsHibernationSupportedPlan(planID string) bool {
+	switch planID {
+	case TrialPlanID, FreemiumPlanID, AzureLitePlanID:
+		return true
+	default:
+		return false
+	}
+}
+
 func filter(items *[]interface{}, included map[string]interface{}) interface{} {
 	output := make([]interface{}, 0)
 	for i := 0; i < len(*items); i++ {
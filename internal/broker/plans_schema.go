@@ -28,16 +28,17 @@ type ProvisioningProperties struct {
 	ShootDomain *Type           `json:"shootDomain,omitempty"`
 	Region      *Type           `json:"region,omitempty"`
 	Networking  *NetworkingType `json:"networking,omitempty"`
-	Modules     *Modules        `json:"modules,omitempty"`
 }
 
 type UpdateProperties struct {
-	Kubeconfig     *Type     `json:"kubeconfig,omitempty"`
-	AutoScalerMin  *Type     `json:"autoScalerMin,omitempty"`
-	AutoScalerMax  *Type     `json:"autoScalerMax,omitempty"`
-	OIDC           *OIDCType `json:"oidc,omitempty"`
-	Administrators *Type     `json:"administrators,omitempty"`
-	MachineType    *Type     `json:"machineType,omitempty"`
+	Kubeconfig     *Type            `json:"kubeconfig,omitempty"`
+	AutoScalerMin  *Type            `json:"autoScalerMin,omitempty"`
+	AutoScalerMax  *Type            `json:"autoScalerMax,omitempty"`
+	OIDC           *OIDCType        `json:"oidc,omitempty"`
+	Administrators *Type            `json:"administrators,omitempty"`
+	MachineType    *Type            `json:"machineType,omitempty"`
+	Hibernation    *HibernationType `json:"hibernation,omitempty"`
+	Modules        *Modules         `json:"modules,omitempty"`
 }
 
 func (up *UpdateProperties) IncludeAdditional() {
@@ -70,6 +71,18 @@ type OIDCType struct {
 	Required   []string       `json:"required"`
 }
 
+type HibernationProperties struct {
+	Start    Type `json:"start"`
+	End      Type `json:"end"`
+	Timezone Type `json:"timezone"`
+}
+
+type HibernationType struct {
+	Type
+	Properties HibernationProperties `json:"properties"`
+	Required   []string              `json:"required"`
+}
+
 type Type struct {
 	Type        string `json:"type"`
 	Title       string `json:"title,omitempty"`
@@ -287,6 +300,7 @@ func NewProvisioningProperties(machineTypesDisplay map[string]string, machineTyp
 				Enum:            ToInterfaceSlice(machineTypes),
 				EnumDisplayName: machineTypesDisplay,
 			},
+			Modules: NewModulesSchema(),
 		},
 		Name: NameProperty(),
 		Region: &Type{
@@ -295,7 +309,6 @@ func NewProvisioningProperties(machineTypesDisplay map[string]string, machineTyp
 			MinLength: 1,
 		},
 		Networking: NewNetworkingSchema(),
-		Modules:    NewModulesSchema(),
 	}
 
 	if update {
@@ -339,6 +352,18 @@ func NewOIDCSchema() *OIDCType {
 	}
 }
 
+func NewHibernationSchema() *HibernationType {
+	return &HibernationType{
+		Type: Type{Type: "object", Description: "Hibernation schedule. The cluster is hibernated at the start time and woken up at the end time. Empty start and end remove the schedule."},
+		Properties: HibernationProperties{
+			Start:    Type{Type: "string", Title: "Hibernation start", Description: "Cron expression which defines when the cluster is hibernated, for example, 0 20 * * 1-5"},
+			End:      Type{Type: "string", Title: "Hibernation end", Description: "Cron expression which defines when the cluster is woken up, for example, 0 7 * * 1-5"},
+			Timezone: Type{Type: "string", Title: "Timezone", Description: "IANA time zone of the schedule, for example, Europe/Berlin. UTC is used by default."},
+		},
+		Required: []string{"start", "end"},
+	}
+}
+
 func NewSchema(properties interface{}, update bool, required []string) *RootSchema {
 	schema := &RootSchema{
 		Schema: "http://json-schema.org/draft-04/schema#",
@@ -369,7 +394,7 @@ func unmarshalOrPanic(from, to interface{}) interface{} {
 }
 
 func DefaultControlsOrder() []string {
-	return []string{"name", "kubeconfig", "shootName", "shootDomain", "region", "machineType", "autoScalerMin", "autoScalerMax", "zonesCount", "modules", "networking", "oidc", "administrators"}
+	return []string{"name", "kubeconfig", "shootName", "shootDomain", "region", "machineType", "autoScalerMin", "autoScalerMax", "zonesCount", "modules", "networking", "oidc", "administrators", "hibernation"}
 }
 
 func ToInterfaceSlice(input []string) []interface{} {
@@ -390,3 +415,26 @@ func AdministratorsProperty() *Type {
 		},
 	}
 }
+
+// BindingProperties are the parameters accepted when a service binding is created
+type BindingProperties struct {
+	ExpirationSeconds Type `json:"expiration_seconds"`
+}
+
+func BindingSchema(minExpirationSeconds, maxExpirationSeconds, defaultExpirationSeconds int) *map[string]interface{} {
+	properties := BindingProperties{
+		ExpirationSeconds: Type{
+			Type:        "integer",
+			Title:       "Expiration seconds",
+			Description: "Specifies the validity of the kubeconfig token in seconds",
+			Minimum:     minExpirationSeconds,
+			Maximum:     maxExpirationSeconds,
+			Default:     defaultExpirationSeconds,
+		},
+	}
+	schema := NewSchema(properties, false, []string{})
+	schema.ShowFormView = false
+	schema.AdditionalProperties = false
+	target := make(map[string]interface{})
+	return unmarshalOrPanic(schema, &target).(*map[string]interface{})
+}
@@ -23,7 +23,11 @@ func AttachRoutes(router *mux.Router, serviceBroker domain.ServiceBroker, logger
 	router.HandleFunc("/v2/service_instances/{instance_id}", apiHandler.GetInstance).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}", apiHandler.Provision).Methods("PUT")
 	router.HandleFunc("/v2/service_instances/{instance_id}", deprovision).Methods("DELETE")
-	router.HandleFunc("/v2/service_instances/{instance_id}/last_operation", apiHandler.LastOperation).Methods("GET")
+	lastOperation := apiHandler.LastOperation
+	if provider, ok := serviceBroker.(LastOperationErrorProvider); ok {
+		lastOperation = lastOperationWithError(apiHandler.LastOperation, provider, logger)
+	}
+	router.HandleFunc("/v2/service_instances/{instance_id}/last_operation", lastOperation).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}", apiHandler.Update).Methods("PATCH")
 
 	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}", apiHandler.GetBinding).Methods("GET")
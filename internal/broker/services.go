@@ -61,6 +61,12 @@ func (b *ServicesEndpoint) Services(ctx context.Context) ([]domain.Service, erro
 		}
 		if b.cfg.Binding.Enabled && b.cfg.Binding.BindablePlans.Contains(plan.Name) {
 			plan.Bindable = &bindable
+			limits, expirationSeconds := b.cfg.Binding.ExpirationLimitsForPlan(plan.Name)
+			plan.Schemas.Binding = domain.ServiceBindingSchema{
+				Create: domain.Schema{
+					Parameters: *BindingSchema(limits.MinExpirationSeconds, limits.MaxExpirationSeconds, expirationSeconds),
+				},
+			}
 		}
 
 		availableServicePlans = append(availableServicePlans, plan)
@@ -73,6 +79,7 @@ func (b *ServicesEndpoint) Services(ctx context.Context) ([]domain.Service, erro
 			Description:          class.Description,
 			Bindable:             false,
 			InstancesRetrievable: true,
+			BindingsRetrievable:  b.cfg.Binding.Enabled,
 			Tags: []string{
 				"SAP",
 				"Kyma",
@@ -144,6 +144,7 @@ func TestServices_Services(t *testing.T) {
 		// when
 		services, err := servicesEndpoint.Services(context.TODO())
 		require.NoError(t, err)
+		assert.True(t, services[0].BindingsRetrievable)
 		assertBindableForPlan(t, services, "aws")
 		assertBindableForPlan(t, services, "gcp")
 		assertNotBindableForPlan(t, services, "azure")
@@ -154,6 +155,7 @@ func assertBindableForPlan(t *testing.T, services []domain.Service, planName str
 	for _, plan := range services[0].Plans {
 		if strings.ToLower(plan.Name) == planName {
 			assert.True(t, *plan.Bindable)
+			assert.NotEmpty(t, plan.Schemas.Binding.Create.Parameters)
 			return
 		}
 	}
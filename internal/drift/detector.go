@@ -0,0 +1,378 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	FieldMachineType       = "machineType"
+	FieldAutoScalerMin     = "autoScalerMin"
+	FieldAutoScalerMax     = "autoScalerMax"
+	FieldZones             = "zones"
+	FieldKubernetesVersion = "kubernetesVersion"
+
+	instancesPageSize = 100
+)
+
+// correctableFields are the fields which are applied to the shoot by the update operation
+var correctableFields = map[string]bool{
+	FieldMachineType:   true,
+	FieldAutoScalerMin: true,
+	FieldAutoScalerMax: true,
+}
+
+type Config struct {
+	Enabled  bool          `envconfig:"default=false"`
+	Interval time.Duration `envconfig:"default=1h"`
+	// CorrectiveUpdate enables the update operation which applies the machine type and the autoscaler parameters of the
+	// instance to the drifted shoot
+	CorrectiveUpdate bool `envconfig:"default=false"`
+}
+
+type Queue interface {
+	Add(processId string)
+}
+
+// Detector compares the parameters of the instances with the shoots in Gardener and stores the differences
+type Detector struct {
+	instances      storage.Instances
+	operations     storage.Operations
+	runtimeStates  storage.RuntimeStates
+	drifts         storage.RuntimeDrifts
+	gardenerClient dynamic.Interface
+	namespace      string
+	updateQueue    Queue
+	lease          *process.JobLease
+	cfg            Config
+	log            logrus.FieldLogger
+}
+
+func NewDetector(db storage.BrokerStorage, gardenerClient dynamic.Interface, gardenerNamespace string, updateQueue Queue, cfg Config, log logrus.FieldLogger) *Detector {
+	return &Detector{
+		instances:      db.Instances(),
+		operations:     db.Operations(),
+		runtimeStates:  db.RuntimeStates(),
+		drifts:         db.RuntimeDrifts(),
+		gardenerClient: gardenerClient,
+		namespace:      gardenerNamespace,
+		updateQueue:    updateQueue,
+		cfg:            cfg,
+		log:            log.WithField("service", "DriftDetector"),
+	}
+}
+
+// UseLease makes the detector run only in the KEB replica which holds the lease, so the corrective updates are not
+// created by many replicas for the same instance
+func (d *Detector) UseLease(lease *process.JobLease) {
+	d.lease = lease
+}
+
+func (d *Detector) Start(ctx context.Context) {
+	go d.run(ctx)
+}
+
+func (d *Detector) run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !d.holdsLease() {
+				continue
+			}
+			if err := d.Run(); err != nil {
+				d.log.Errorf("drift detection failed: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Run checks all the succeeded instances, the instances which cannot be checked are skipped
+func (d *Detector) Run() error {
+	filter := dbmodel.InstanceFilter{
+		States:   []dbmodel.InstanceState{dbmodel.InstanceSucceeded},
+		PageSize: instancesPageSize,
+		Page:     1,
+	}
+	checked, drifted := 0, 0
+	for {
+		instances, _, _, err := d.instances.List(filter)
+		if err != nil {
+			return fmt.Errorf("while listing instances: %w", err)
+		}
+		for _, instance := range instances {
+			findings, err := d.Check(instance)
+			if err != nil {
+				d.log.Warnf("unable to check drift of instance %s: %v", instance.InstanceID, err)
+				continue
+			}
+			checked++
+			if len(findings) > 0 {
+				drifted++
+			}
+		}
+		if len(instances) < instancesPageSize {
+			break
+		}
+		filter.Page++
+	}
+	d.log.Infof("drift detection finished: %d instances checked, %d drifted", checked, drifted)
+	return d.removeDeprovisionedDrifts()
+}
+
+func (d *Detector) holdsLease() bool {
+	if d.lease == nil {
+		return true
+	}
+	acquired, err := d.lease.Acquire()
+	if err != nil {
+		d.log.Errorf("unable to acquire the drift detection lease: %v", err)
+		return false
+	}
+	return acquired
+}
+
+// removeDeprovisionedDrifts deletes the drifts of the instances which do not exist anymore
+func (d *Detector) removeDeprovisionedDrifts() error {
+	drifts, err := d.drifts.List()
+	if err != nil {
+		return fmt.Errorf("while listing drifts: %w", err)
+	}
+	for _, drift := range drifts {
+		_, err := d.instances.GetByID(drift.InstanceID)
+		switch {
+		case dberr.IsNotFound(err):
+			if err := d.drifts.Delete(drift.InstanceID); err != nil {
+				return fmt.Errorf("while deleting drift of instance %s: %w", drift.InstanceID, err)
+			}
+		case err != nil:
+			d.log.Warnf("unable to get instance %s: %v", drift.InstanceID, err)
+		}
+	}
+	return nil
+}
+
+// Check compares the instance with its shoot and stores the findings, the stored drift is removed if the shoot matches the instance
+func (d *Detector) Check(instance internal.Instance) ([]internal.DriftFinding, error) {
+	log := d.log.WithField("instanceID", instance.InstanceID)
+	if instance.RuntimeID == "" || instance.InstanceDetails.ShootName == "" {
+		return nil, nil
+	}
+
+	obj, err := d.gardenerClient.Resource(gardener.ShootResource).Namespace(d.namespace).Get(context.Background(), instance.InstanceDetails.ShootName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Infof("shoot %s not found, skipping", instance.InstanceDetails.ShootName)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while getting shoot %s: %w", instance.InstanceDetails.ShootName, err)
+	}
+	clusterConfig, err := d.lastClusterConfig(instance.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := expectedSpecFor(instance.Parameters.Parameters, clusterConfig)
+	findings := compare(expected, gardener.Shoot{Unstructured: *obj})
+	if len(findings) == 0 {
+		return nil, d.drifts.Delete(instance.InstanceID)
+	}
+
+	log.Infof("runtime drift detected: %v", findings)
+	err = d.drifts.Upsert(internal.RuntimeDrift{
+		InstanceID: instance.InstanceID,
+		RuntimeID:  instance.RuntimeID,
+		ShootName:  instance.InstanceDetails.ShootName,
+		Findings:   findings,
+		DetectedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while storing drift: %w", err)
+	}
+
+	if d.cfg.CorrectiveUpdate && isCorrectable(findings) {
+		if err := d.correct(instance, expected, log); err != nil {
+			log.Errorf("unable to correct the drift: %v", err)
+		}
+	}
+	return findings, nil
+}
+
+// lastClusterConfig returns the cluster configuration of the latest runtime state which contains it
+func (d *Detector) lastClusterConfig(runtimeID string) (gqlschema.GardenerConfigInput, error) {
+	states, err := d.runtimeStates.ListByRuntimeID(runtimeID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return gqlschema.GardenerConfigInput{}, fmt.Errorf("while listing runtime states: %w", err)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].CreatedAt.After(states[j].CreatedAt)
+	})
+	for _, state := range states {
+		if state.ClusterConfig.MachineType != "" {
+			return state.ClusterConfig, nil
+		}
+	}
+	return gqlschema.GardenerConfigInput{}, nil
+}
+
+// correct creates the update operation which applies the expected machine type and autoscaler parameters to the shoot
+func (d *Detector) correct(instance internal.Instance, expected expectedSpec, log logrus.FieldLogger) error {
+	lastOp, err := d.operations.GetLastOperation(instance.InstanceID)
+	if err != nil {
+		return fmt.Errorf("while getting last operation: %w", err)
+	}
+	if lastOp.State == domain.InProgress || lastOp.State == orchestration.Pending {
+		log.Infof("operation %s is in progress, skipping the corrective update", lastOp.ID)
+		return nil
+	}
+
+	updatingParams := internal.UpdatingParametersDTO{
+		AutoScalerParameters: internal.AutoScalerParameters{
+			AutoScalerMin: expected.autoScalerMin,
+			AutoScalerMax: expected.autoScalerMax,
+		},
+	}
+	if expected.machineType != "" {
+		updatingParams.MachineType = &expected.machineType
+	}
+	operation := internal.NewUpdateOperation(uuid.New().String(), &instance, updatingParams)
+	operation.Description = "Operation created to correct the runtime drift"
+	if err := d.operations.InsertOperation(operation); err != nil {
+		return fmt.Errorf("while inserting update operation: %w", err)
+	}
+	d.updateQueue.Add(operation.ID)
+	log.Infof("corrective update operation %s created", operation.ID)
+	return nil
+}
+
+type expectedSpec struct {
+	machineType       string
+	autoScalerMin     *int
+	autoScalerMax     *int
+	zones             []string
+	kubernetesVersion string
+}
+
+// expectedSpecFor takes the values requested by the customer, the values sent to the provisioner are used for the parameters not provided
+func expectedSpecFor(params internal.ProvisioningParametersDTO, clusterConfig gqlschema.GardenerConfigInput) expectedSpec {
+	spec := expectedSpec{
+		machineType:       clusterConfig.MachineType,
+		zones:             zonesFrom(clusterConfig.ProviderSpecificConfig),
+		kubernetesVersion: clusterConfig.KubernetesVersion,
+	}
+	if clusterConfig.MachineType != "" {
+		spec.autoScalerMin = &clusterConfig.AutoScalerMin
+		spec.autoScalerMax = &clusterConfig.AutoScalerMax
+	}
+	if params.MachineType != nil && *params.MachineType != "" {
+		spec.machineType = *params.MachineType
+	}
+	if params.AutoScalerMin != nil {
+		spec.autoScalerMin = params.AutoScalerMin
+	}
+	if params.AutoScalerMax != nil {
+		spec.autoScalerMax = params.AutoScalerMax
+	}
+	if len(params.Zones) > 0 {
+		spec.zones = params.Zones
+	}
+	return spec
+}
+
+func zonesFrom(config *gqlschema.ProviderSpecificInput) []string {
+	var zones []string
+	switch {
+	case config == nil:
+	case config.AwsConfig != nil:
+		for _, zone := range config.AwsConfig.AwsZones {
+			zones = append(zones, zone.Name)
+		}
+	case config.AzureConfig != nil:
+		zones = config.AzureConfig.Zones
+		for _, zone := range config.AzureConfig.AzureZones {
+			zones = append(zones, strconv.Itoa(zone.Name))
+		}
+	case config.GcpConfig != nil:
+		zones = config.GcpConfig.Zones
+	case config.OpenStackConfig != nil:
+		zones = config.OpenStackConfig.Zones
+	}
+	return zones
+}
+
+// compare returns the attributes of the first worker pool and the Kubernetes version of the shoot which differ from the expected ones.
+// The Kubernetes version is compared by the minor version because Gardener updates the patch version automatically.
+func compare(expected expectedSpec, shoot gardener.Shoot) []internal.DriftFinding {
+	var findings []internal.DriftFinding
+	add := func(field, expected, actual string) {
+		if expected != actual {
+			findings = append(findings, internal.DriftFinding{Field: field, Expected: expected, Actual: actual})
+		}
+	}
+
+	workers := shoot.GetSpecWorkers()
+	if len(workers) > 0 {
+		worker := workers[0]
+		if expected.machineType != "" {
+			add(FieldMachineType, expected.machineType, worker.MachineType)
+		}
+		if expected.autoScalerMin != nil {
+			add(FieldAutoScalerMin, strconv.Itoa(*expected.autoScalerMin), strconv.FormatInt(worker.Minimum, 10))
+		}
+		if expected.autoScalerMax != nil {
+			add(FieldAutoScalerMax, strconv.Itoa(*expected.autoScalerMax), strconv.FormatInt(worker.Maximum, 10))
+		}
+		if len(expected.zones) > 0 {
+			add(FieldZones, joinSorted(expected.zones), joinSorted(worker.Zones))
+		}
+	}
+	if expected.kubernetesVersion != "" {
+		add(FieldKubernetesVersion, minorVersion(expected.kubernetesVersion), minorVersion(shoot.GetSpecKubernetesVersion()))
+	}
+	return findings
+}
+
+func isCorrectable(findings []internal.DriftFinding) bool {
+	for _, finding := range findings {
+		if correctableFields[finding.Field] {
+			return true
+		}
+	}
+	return false
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func minorVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
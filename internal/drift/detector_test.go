@@ -0,0 +1,253 @@
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const namespace = "garden-kyma"
+
+type fakeQueue struct {
+	ids []string
+}
+
+func (q *fakeQueue) Add(id string) {
+	q.ids = append(q.ids, id)
+}
+
+func TestDetector_Run(t *testing.T) {
+	t.Run("should store the drift of the shoot which does not match the instance", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1")
+		givenInstance(t, db, "instance-2")
+		client := gardener.NewDynamicFakeClient(
+			fixShoot("Shoot-instance-1", "Standard_D8_v3", 3, 10, []string{"1"}, "1.29.3"),
+			fixShoot("Shoot-instance-2", "Standard_D4_v3", 3, 20, []string{"1", "2"}, "1.29.3"),
+		)
+		queue := &fakeQueue{}
+		detector := NewDetector(db, client, namespace, queue, Config{}, logrus.New())
+
+		// when
+		err := detector.Run()
+
+		// then
+		require.NoError(t, err)
+		_, err = db.RuntimeDrifts().GetByInstanceID("instance-1")
+		assert.True(t, dberr.IsNotFound(err))
+
+		drift, err := db.RuntimeDrifts().GetByInstanceID("instance-2")
+		require.NoError(t, err)
+		assert.Equal(t, "Shoot-instance-2", drift.ShootName)
+		assert.ElementsMatch(t, []internal.DriftFinding{
+			{Field: FieldMachineType, Expected: "Standard_D8_v3", Actual: "Standard_D4_v3"},
+			{Field: FieldAutoScalerMax, Expected: "10", Actual: "20"},
+			{Field: FieldZones, Expected: "1", Actual: "1,2"},
+		}, drift.Findings)
+		assert.Empty(t, queue.ids)
+	})
+
+	t.Run("should remove the drift when the shoot matches the instance again", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1")
+		err := db.RuntimeDrifts().Upsert(internal.RuntimeDrift{InstanceID: "instance-1", Findings: []internal.DriftFinding{{Field: FieldMachineType}}})
+		require.NoError(t, err)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", "Standard_D8_v3", 3, 10, []string{"1"}, "1.29.3"))
+		detector := NewDetector(db, client, namespace, &fakeQueue{}, Config{}, logrus.New())
+
+		// when
+		err = detector.Run()
+
+		// then
+		require.NoError(t, err)
+		_, err = db.RuntimeDrifts().GetByInstanceID("instance-1")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should create the corrective update operation", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1")
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", "Standard_D4_v3", 3, 10, []string{"1"}, "1.29.3"))
+		queue := &fakeQueue{}
+		detector := NewDetector(db, client, namespace, queue, Config{CorrectiveUpdate: true}, logrus.New())
+
+		// when
+		err := detector.Run()
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, queue.ids, 1)
+		operation, err := db.Operations().GetOperationByID(queue.ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, internal.OperationTypeUpdate, operation.Type)
+		assert.Equal(t, domain.LastOperationState(orchestration.Pending), operation.State)
+		assert.Equal(t, "Standard_D8_v3", *operation.UpdatingParameters.MachineType)
+		assert.Equal(t, 3, *operation.UpdatingParameters.AutoScalerMin)
+		assert.Equal(t, 10, *operation.UpdatingParameters.AutoScalerMax)
+	})
+
+	t.Run("should not correct the drift which cannot be fixed by the update", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1")
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", "Standard_D8_v3", 3, 10, []string{"2"}, "1.29.3"))
+		queue := &fakeQueue{}
+		detector := NewDetector(db, client, namespace, queue, Config{CorrectiveUpdate: true}, logrus.New())
+
+		// when
+		err := detector.Run()
+
+		// then
+		require.NoError(t, err)
+		drift, err := db.RuntimeDrifts().GetByInstanceID("instance-1")
+		require.NoError(t, err)
+		assert.Equal(t, []internal.DriftFinding{{Field: FieldZones, Expected: "1", Actual: "2"}}, drift.Findings)
+		assert.Empty(t, queue.ids)
+	})
+
+	t.Run("should skip the instance without the shoot", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1")
+		detector := NewDetector(db, gardener.NewDynamicFakeClient(), namespace, &fakeQueue{}, Config{}, logrus.New())
+
+		// when
+		err := detector.Run()
+
+		// then
+		require.NoError(t, err)
+		drifts, err := db.RuntimeDrifts().List()
+		require.NoError(t, err)
+		assert.Empty(t, drifts)
+	})
+
+	t.Run("should remove the drifts of the deprovisioned instances", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1")
+		require.NoError(t, db.RuntimeDrifts().Upsert(internal.RuntimeDrift{InstanceID: "instance-1", Findings: []internal.DriftFinding{{Field: FieldMachineType}}}))
+		require.NoError(t, db.RuntimeDrifts().Upsert(internal.RuntimeDrift{InstanceID: "deprovisioned", Findings: []internal.DriftFinding{{Field: FieldMachineType}}}))
+		detector := NewDetector(db, gardener.NewDynamicFakeClient(), namespace, &fakeQueue{}, Config{}, logrus.New())
+
+		// when
+		err := detector.Run()
+
+		// then
+		require.NoError(t, err)
+		drifts, err := db.RuntimeDrifts().List()
+		require.NoError(t, err)
+		require.Len(t, drifts, 1)
+		assert.Equal(t, "instance-1", drifts[0].InstanceID)
+	})
+}
+
+func TestDetector_Lease(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	first := NewDetector(db, gardener.NewDynamicFakeClient(), namespace, &fakeQueue{}, Config{}, logrus.New())
+	first.UseLease(process.NewJobLease(db.OperationLeases(), "drift-detection", "replica-1", time.Hour))
+	second := NewDetector(db, gardener.NewDynamicFakeClient(), namespace, &fakeQueue{}, Config{}, logrus.New())
+	second.UseLease(process.NewJobLease(db.OperationLeases(), "drift-detection", "replica-2", time.Hour))
+
+	// then
+	assert.True(t, first.holdsLease())
+	assert.False(t, second.holdsLease())
+	assert.True(t, first.holdsLease())
+}
+
+func TestExpectedSpecFor(t *testing.T) {
+	// given
+	clusterConfig := gqlschema.GardenerConfigInput{
+		MachineType:       "m5.xlarge",
+		AutoScalerMin:     3,
+		AutoScalerMax:     20,
+		KubernetesVersion: "1.29",
+		ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+			AwsConfig: &gqlschema.AWSProviderConfigInput{AwsZones: []*gqlschema.AWSZoneInput{{Name: "eu-central-1a"}, {Name: "eu-central-1b"}}},
+		},
+	}
+	params := internal.ProvisioningParametersDTO{
+		AutoScalerParameters: internal.AutoScalerParameters{AutoScalerMax: ptr.Integer(10)},
+	}
+
+	// when
+	spec := expectedSpecFor(params, clusterConfig)
+
+	// then
+	assert.Equal(t, "m5.xlarge", spec.machineType)
+	assert.Equal(t, 3, *spec.autoScalerMin)
+	assert.Equal(t, 10, *spec.autoScalerMax)
+	assert.Equal(t, []string{"eu-central-1a", "eu-central-1b"}, spec.zones)
+	assert.Equal(t, "1.29", spec.kubernetesVersion)
+
+	// the patch version is updated by Gardener
+	findings := compare(spec, *fixShoot("shoot", "m5.xlarge", 3, 10, []string{"eu-central-1b", "eu-central-1a"}, "1.29.8"))
+	assert.Empty(t, findings)
+	findings = compare(spec, *fixShoot("shoot", "m5.xlarge", 3, 10, []string{"eu-central-1b", "eu-central-1a"}, "1.30.1"))
+	assert.Equal(t, []internal.DriftFinding{{Field: FieldKubernetesVersion, Expected: "1.29", Actual: "1.30"}}, findings)
+}
+
+func givenInstance(t *testing.T, db storage.BrokerStorage, id string) {
+	instance := fixture.FixInstance(id)
+	require.NoError(t, db.Instances().Insert(instance))
+
+	operation := fixture.FixProvisioningOperation("op-"+id, id)
+	operation.State = domain.Succeeded
+	require.NoError(t, db.Operations().InsertOperation(operation))
+
+	state := internal.NewRuntimeState(instance.RuntimeID, operation.ID, nil, &gqlschema.GardenerConfigInput{
+		MachineType:       "Standard_D8_v3",
+		AutoScalerMin:     3,
+		AutoScalerMax:     10,
+		KubernetesVersion: "1.29",
+	})
+	require.NoError(t, db.RuntimeStates().Insert(state))
+}
+
+func fixShoot(name, machineType string, minimum, maximum int64, zones []string, kubernetesVersion string) *gardener.Shoot {
+	workerZones := make([]interface{}, 0, len(zones))
+	for _, zone := range zones {
+		workerZones = append(workerZones, zone)
+	}
+	return &gardener.Shoot{Unstructured: unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "core.gardener.cloud/v1beta1",
+		"kind":       "Shoot",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"kubernetes": map[string]interface{}{
+				"version": kubernetesVersion,
+			},
+			"provider": map[string]interface{}{
+				"workers": []interface{}{
+					map[string]interface{}{
+						"name":    "cpu-worker-0",
+						"machine": map[string]interface{}{"type": machineType},
+						"minimum": minimum,
+						"maximum": maximum,
+						"zones":   workerZones,
+					},
+				},
+			},
+		},
+	}}}
+}
@@ -5,8 +5,10 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/robfig/cron/v3"
 )
 
 const (
@@ -77,6 +79,52 @@ func (o *OIDCConfigDTO) validSigningAlgsSet() map[string]bool {
 	return signingAlgsSet
 }
 
+// HibernationDTO defines when the cluster is hibernated and woken up, the schedules are cron expressions evaluated in the timezone
+type HibernationDTO struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// IsEmpty returns true for the schedule without the start and the end, which removes the hibernation schedule of the instance
+func (h *HibernationDTO) IsEmpty() bool {
+	return h.Start == "" && h.End == ""
+}
+
+// Schedule returns the schedule to store, nil is returned for the empty schedule
+func (h *HibernationDTO) Schedule() *HibernationDTO {
+	if h.IsEmpty() {
+		return nil
+	}
+	return h
+}
+
+func (h *HibernationDTO) Validate() error {
+	errs := make([]string, 0)
+	if _, err := cron.ParseStandard(h.Start); err != nil {
+		errs = append(errs, fmt.Sprintf("start must be a valid cron expression: %s", err))
+	}
+	if _, err := cron.ParseStandard(h.End); err != nil {
+		errs = append(errs, fmt.Sprintf("end must be a valid cron expression: %s", err))
+	}
+	if _, err := h.Location(); err != nil {
+		errs = append(errs, fmt.Sprintf("timezone must be a valid IANA time zone: %s", err))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Location returns the location of the timezone, UTC is used if the timezone is not set
+func (h *HibernationDTO) Location() (*time.Location, error) {
+	if h.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(h.Timezone)
+}
+
 type ProvisioningParameters struct {
 	PlanID     string                    `json:"plan_id"`
 	ServiceID  string                    `json:"service_id"`
@@ -192,14 +240,18 @@ type ProvisioningParametersDTO struct {
 	OIDC       *OIDCConfigDTO `json:"oidc,omitempty"`
 	Networking *NetworkingDTO `json:"networking,omitempty""`
 	Modules    *ModulesDTO    `json:"modules,omitempty"`
+
+	Hibernation *HibernationDTO `json:"hibernation,omitempty"`
 }
 
 type UpdatingParametersDTO struct {
 	AutoScalerParameters `json:",inline"`
 
-	OIDC                  *OIDCConfigDTO `json:"oidc,omitempty"`
-	RuntimeAdministrators []string       `json:"administrators,omitempty"`
-	MachineType           *string        `json:"machineType,omitempty"`
+	OIDC                  *OIDCConfigDTO  `json:"oidc,omitempty"`
+	RuntimeAdministrators []string        `json:"administrators,omitempty"`
+	MachineType           *string         `json:"machineType,omitempty"`
+	Hibernation           *HibernationDTO `json:"hibernation,omitempty"`
+	Modules               *ModulesDTO     `json:"modules,omitempty"`
 }
 
 func (u UpdatingParametersDTO) UpdateAutoScaler(p *ProvisioningParametersDTO) bool {
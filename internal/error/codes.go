@@ -0,0 +1,128 @@
+package error
+
+// ErrCode is a stable, user-facing code of the operation failure. The codes are documented in the
+// docs/user/05-30-operation-status.md and must not be renamed.
+type ErrCode string
+
+const (
+	CodeTimeout                ErrCode = "KEB_TIMEOUT"
+	CodeInternal               ErrCode = "KEB_INTERNAL_ERROR"
+	CodeInvalidParameters      ErrCode = "INVALID_PARAMETERS"
+	CodeDependencyUnavailable  ErrCode = "DEPENDENCY_UNAVAILABLE"
+	CodeKubernetesAPI          ErrCode = "KUBERNETES_API_ERROR"
+	CodeClusterNotFound        ErrCode = "CLUSTER_NOT_FOUND"
+	CodeInfrastructure         ErrCode = "INFRASTRUCTURE_ERROR"
+	CodeKymaInstallationFailed ErrCode = "KYMA_INSTALLATION_FAILED"
+	CodeUnknown                ErrCode = "UNKNOWN_ERROR"
+)
+
+// ErrAction tells the user what to do with the failed operation
+type ErrAction string
+
+const (
+	ActionRetry          ErrAction = "retry"
+	ActionContactSupport ErrAction = "contact_support"
+	ActionFixParameters  ErrAction = "fix_parameters"
+)
+
+// ErrorDetails is the user-facing description of the operation failure
+type ErrorDetails struct {
+	Code      ErrCode      `json:"code"`
+	Reason    ErrReason    `json:"reason,omitempty"`
+	Component ErrComponent `json:"component,omitempty"`
+	Action    ErrAction    `json:"action"`
+	Hint      string       `json:"hint"`
+}
+
+type codeDescription struct {
+	action ErrAction
+	hint   string
+}
+
+var codeDescriptions = map[ErrCode]codeDescription{
+	CodeTimeout: {
+		action: ActionRetry,
+		hint:   "The operation has not finished in the expected time. Retry the operation and contact support if it fails again.",
+	},
+	CodeInternal: {
+		action: ActionContactSupport,
+		hint:   "The operation failed because of an internal error of Kyma Environment Broker. Contact support.",
+	},
+	CodeInvalidParameters: {
+		action: ActionFixParameters,
+		hint:   "The operation failed because of the provided parameters. Correct the parameters and run the operation again.",
+	},
+	CodeDependencyUnavailable: {
+		action: ActionRetry,
+		hint:   "A service required by the operation is temporarily unavailable. Retry the operation later.",
+	},
+	CodeKubernetesAPI: {
+		action: ActionRetry,
+		hint:   "The Kubernetes API server returned an error. Retry the operation and contact support if it fails again.",
+	},
+	CodeClusterNotFound: {
+		action: ActionContactSupport,
+		hint:   "The cluster of the runtime was not found. Contact support.",
+	},
+	CodeInfrastructure: {
+		action: ActionContactSupport,
+		hint:   "The cluster infrastructure could not be created or changed. Contact support.",
+	},
+	CodeKymaInstallationFailed: {
+		action: ActionContactSupport,
+		hint:   "The installation of Kyma on the cluster failed. Contact support.",
+	},
+	CodeUnknown: {
+		action: ActionContactSupport,
+		hint:   "The operation failed for an unknown reason. Contact support.",
+	},
+}
+
+// Details maps the reason and the component of the error to the user-facing error code, the suggested action and the hint.
+// It returns nil for an empty error.
+func Details(err LastError) *ErrorDetails {
+	if err.reason == "" && err.component == "" && err.message == "" {
+		return nil
+	}
+	code := CodeFor(err)
+	description := codeDescriptions[code]
+
+	return &ErrorDetails{
+		Code:      code,
+		Reason:    err.reason,
+		Component: err.component,
+		Action:    description.action,
+		Hint:      description.hint,
+	}
+}
+
+// CodeFor returns the user-facing code of the error, the reason takes precedence over the component
+func CodeFor(err LastError) ErrCode {
+	switch err.reason {
+	case ErrKEBTimeOut:
+		return CodeTimeout
+	case ErrInvalidParameters:
+		return CodeInvalidParameters
+	case ErrClusterNotFound:
+		return CodeClusterNotFound
+	case ErrHttpStatusCode:
+		return CodeDependencyUnavailable
+	case ErrKEBInternal:
+		return CodeInternal
+	}
+
+	switch err.component {
+	case ErrK8SClient:
+		return CodeKubernetesAPI
+	case ErrProvisioner:
+		return CodeInfrastructure
+	case ErrReconciler:
+		return CodeKymaInstallationFailed
+	case ErrEDP, ErrAVS:
+		return CodeDependencyUnavailable
+	case ErrKEB, ErrDB:
+		return CodeInternal
+	}
+
+	return CodeUnknown
+}
@@ -0,0 +1,107 @@
+package error_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/avs"
+	"github.com/kyma-project/kyma-environment-broker/internal/edp"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/kyma-environment-broker/internal/reconciler"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierr2 "k8s.io/apimachinery/pkg/api/meta"
+)
+
+func TestDetails(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err            error
+		expectedCode   kebError.ErrCode
+		expectedAction kebError.ErrAction
+	}{
+		"timeout": {
+			err:            fmt.Errorf("operation has reached the time limit: 2h"),
+			expectedCode:   kebError.CodeTimeout,
+			expectedAction: kebError.ActionRetry,
+		},
+		"invalid parameters": {
+			err:            fmt.Errorf("step failed: %w", kebError.InvalidParametersError("cannot map planID")),
+			expectedCode:   kebError.CodeInvalidParameters,
+			expectedAction: kebError.ActionFixParameters,
+		},
+		"kubernetes API": {
+			err:            fmt.Errorf("something: %w", &apierr2.NoKindMatchError{}),
+			expectedCode:   kebError.CodeKubernetesAPI,
+			expectedAction: kebError.ActionRetry,
+		},
+		"EDP": {
+			err:            edp.NewEDPBadRequestError("id", "bad request"),
+			expectedCode:   kebError.CodeDependencyUnavailable,
+			expectedAction: kebError.ActionRetry,
+		},
+		"AVS": {
+			err:            avs.NewAvsError("avs server returned %d status code", 503),
+			expectedCode:   kebError.CodeDependencyUnavailable,
+			expectedAction: kebError.ActionRetry,
+		},
+		"reconciler": {
+			err:            reconciler.NewReconcilerError(nil, "reconciler error"),
+			expectedCode:   kebError.CodeKymaInstallationFailed,
+			expectedAction: kebError.ActionContactSupport,
+		},
+		"database": {
+			err:            dberr.Internal("db error"),
+			expectedCode:   kebError.CodeInternal,
+			expectedAction: kebError.ActionContactSupport,
+		},
+		"unclassified": {
+			err:            fmt.Errorf("some error"),
+			expectedCode:   kebError.CodeInternal,
+			expectedAction: kebError.ActionContactSupport,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			details := kebError.Details(kebError.ReasonForError(tc.err))
+
+			// then
+			require.NotNil(t, details)
+			assert.Equal(t, tc.expectedCode, details.Code)
+			assert.Equal(t, tc.expectedAction, details.Action)
+			assert.NotEmpty(t, details.Hint)
+		})
+	}
+
+	t.Run("unknown reason and component", func(t *testing.T) {
+		// when
+		details := kebError.Details(kebError.LastError{}.SetReason("err_other").SetComponent("other"))
+
+		// then
+		require.NotNil(t, details)
+		assert.Equal(t, kebError.CodeUnknown, details.Code)
+		assert.Equal(t, kebError.ActionContactSupport, details.Action)
+	})
+
+	t.Run("empty error", func(t *testing.T) {
+		assert.Nil(t, kebError.Details(kebError.LastError{}))
+	})
+}
+
+func TestLastError_JSON(t *testing.T) {
+	// given
+	lastErr := kebError.TimeoutError("operation has reached the time limit")
+
+	// when
+	data, err := json.Marshal(lastErr)
+	require.NoError(t, err)
+
+	var decoded kebError.LastError
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, lastErr, decoded)
+	assert.JSONEq(t, `{"message":"operation has reached the time limit","reason":"err_keb_timeout","component":"keb"}`, string(data))
+}
@@ -1,6 +1,7 @@
 package error
 
 import (
+	"encoding/json"
 	"strings"
 
 	"errors"
@@ -38,6 +39,7 @@ const (
 	ErrK8SUnexpectedObjectError ErrReason = "err_k8s_unexpected_object_error"
 	ErrK8SNoMatchError          ErrReason = "err_k8s_no_match_error"
 	ErrK8SAmbiguousError        ErrReason = "err_k8s_ambiguous_error"
+	ErrInvalidParameters        ErrReason = "err_invalid_parameters"
 )
 
 type ErrComponent string
@@ -87,6 +89,40 @@ func TimeoutError(msg string) LastError {
 	}
 }
 
+// InvalidParametersError reports the operation which cannot be processed because of the provisioning parameters
+func InvalidParametersError(msg string) LastError {
+	return LastError{
+		message:   msg,
+		reason:    ErrInvalidParameters,
+		component: ErrKEB,
+	}
+}
+
+type lastErrorDTO struct {
+	Message   string       `json:"message,omitempty"`
+	Reason    ErrReason    `json:"reason,omitempty"`
+	Component ErrComponent `json:"component,omitempty"`
+}
+
+func (err LastError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lastErrorDTO{
+		Message:   err.message,
+		Reason:    err.reason,
+		Component: err.component,
+	})
+}
+
+func (err *LastError) UnmarshalJSON(data []byte) error {
+	var dto lastErrorDTO
+	if e := json.Unmarshal(data, &dto); e != nil {
+		return e
+	}
+	err.message = dto.Message
+	err.reason = dto.Reason
+	err.component = dto.Component
+	return nil
+}
+
 // resolve error component and reason
 func ReasonForError(err error) LastError {
 	if err == nil {
@@ -462,6 +462,22 @@ func (c *SimpleInputCreator) DisableOptionalComponent(name string) internal.Prov
 	return c
 }
 
+func (c *SimpleInputCreator) Snapshot() internal.ProvisionerInput {
+	labels := gqlschema.Labels{}
+	for key, value := range c.Labels {
+		labels[key] = value
+	}
+	return internal.ProvisionerInput{
+		ShootName:         c.ShootName,
+		ShootDomain:       c.ShootDomain,
+		ShootDNSProviders: c.shootDnsProviders,
+		RuntimeID:         c.RuntimeID,
+		Labels:            labels,
+		Overrides:         c.Overrides,
+		EnabledComponents: c.EnabledComponents,
+	}
+}
+
 func (c *SimpleInputCreator) Provider() internal.CloudProvider {
 	return c.CloudProvider
 }
@@ -0,0 +1,195 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	instancesPageSize = 100
+
+	// lookback limits how far back the schedules are evaluated, the schedules which did not fire within a week are ignored
+	lookback = 7 * 24 * time.Hour
+)
+
+type Config struct {
+	Enabled  bool          `envconfig:"default=false"`
+	Interval time.Duration `envconfig:"default=5m"`
+}
+
+// Scheduler hibernates and wakes up the shoots of the instances according to their hibernation schedules
+type Scheduler struct {
+	instances  storage.Instances
+	hibernator *ShootHibernator
+	cfg        Config
+	log        logrus.FieldLogger
+	lease      *process.JobLease
+
+	now func() time.Time
+}
+
+func NewScheduler(db storage.BrokerStorage, gardenerClient dynamic.Interface, gardenerNamespace string, cfg Config, log logrus.FieldLogger) *Scheduler {
+	return &Scheduler{
+		instances:  db.Instances(),
+		hibernator: NewShootHibernator(gardenerClient, gardenerNamespace),
+		cfg:        cfg,
+		log:        log.WithField("service", "HibernationScheduler"),
+		now:        time.Now,
+	}
+}
+
+// UseLease makes the scheduler run only in the KEB replica which holds the lease, so the shoots are not patched
+// by many replicas at the same time
+func (s *Scheduler) UseLease(lease *process.JobLease) {
+	s.lease = lease
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !s.holdsLease() {
+				continue
+			}
+			if err := s.Run(); err != nil {
+				s.log.Errorf("hibernation scheduling failed: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Scheduler) holdsLease() bool {
+	if s.lease == nil {
+		return true
+	}
+	acquired, err := s.lease.Acquire()
+	if err != nil {
+		s.log.Errorf("unable to acquire the hibernation lease: %v", err)
+		return false
+	}
+	return acquired
+}
+
+// Run applies the hibernation schedules to the succeeded instances of the plans which support the hibernation
+func (s *Scheduler) Run() error {
+	filter := dbmodel.InstanceFilter{
+		PlanIDs:  []string{broker.TrialPlanID, broker.FreemiumPlanID, broker.AzureLitePlanID},
+		States:   []dbmodel.InstanceState{dbmodel.InstanceSucceeded},
+		PageSize: instancesPageSize,
+		Page:     1,
+	}
+	for {
+		instances, _, _, err := s.instances.List(filter)
+		if err != nil {
+			return fmt.Errorf("while listing instances: %w", err)
+		}
+		for _, instance := range instances {
+			if err := s.Apply(instance); err != nil {
+				s.log.Warnf("unable to apply the hibernation schedule of instance %s: %v", instance.InstanceID, err)
+			}
+		}
+		if len(instances) < instancesPageSize {
+			break
+		}
+		filter.Page++
+	}
+	return nil
+}
+
+// Apply hibernates or wakes up the shoot of the instance if the last fired schedule requires it
+func (s *Scheduler) Apply(instance internal.Instance) error {
+	// the shoots of the deactivated subaccounts are managed by the suspension
+	if instance.IsDeactivated() {
+		return nil
+	}
+	hibernate, err := shouldHibernate(instance.Parameters.Parameters.Hibernation, s.now())
+	if err != nil {
+		return err
+	}
+	if hibernate == nil || *hibernate == instance.IsHibernated() {
+		return nil
+	}
+	if instance.InstanceDetails.ShootName == "" {
+		return nil
+	}
+
+	log := s.log.WithField("instanceID", instance.InstanceID)
+	if err := s.hibernator.SetHibernation(instance.InstanceDetails.ShootName, *hibernate); err != nil {
+		return err
+	}
+
+	if *hibernate {
+		now := s.now()
+		instance.HibernatedAt = &now
+		log.Infof("shoot %s hibernated", instance.InstanceDetails.ShootName)
+	} else {
+		instance.HibernatedAt = nil
+		log.Infof("shoot %s woken up", instance.InstanceDetails.ShootName)
+	}
+	if _, err := s.instances.Update(instance); err != nil {
+		return fmt.Errorf("while updating instance: %w", err)
+	}
+	return nil
+}
+
+// shouldHibernate returns the hibernation state required by the schedule which fired last, nil is returned if none of the schedules fired.
+// The cluster without the schedule should not be hibernated.
+func shouldHibernate(hibernation *internal.HibernationDTO, now time.Time) (*bool, error) {
+	if hibernation == nil {
+		hibernate := false
+		return &hibernate, nil
+	}
+	location, err := hibernation.Location()
+	if err != nil {
+		return nil, fmt.Errorf("while loading timezone: %w", err)
+	}
+	start, err := cron.ParseStandard(hibernation.Start)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing start schedule: %w", err)
+	}
+	end, err := cron.ParseStandard(hibernation.End)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing end schedule: %w", err)
+	}
+
+	now = now.In(location)
+	lastStart := lastActivation(start, now)
+	lastEnd := lastActivation(end, now)
+	switch {
+	case lastStart.IsZero() && lastEnd.IsZero():
+		return nil, nil
+	case lastStart.After(lastEnd):
+		hibernate := true
+		return &hibernate, nil
+	default:
+		hibernate := false
+		return &hibernate, nil
+	}
+}
+
+// lastActivation returns the last time the schedule fired before now, the zero time is returned if it did not fire within the lookback period
+func lastActivation(schedule cron.Schedule, now time.Time) time.Time {
+	var last time.Time
+	for next := schedule.Next(now.Add(-lookback)); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		last = next
+	}
+	return last
+}
@@ -0,0 +1,239 @@
+package hibernation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const namespace = "garden-kyma"
+
+// workingHours hibernates the cluster at 20:00 and wakes it up at 7:00 on working days in Berlin
+var workingHours = &internal.HibernationDTO{Start: "0 20 * * 1-5", End: "0 7 * * 1-5", Timezone: "Europe/Berlin"}
+
+func TestScheduler_Run(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("should hibernate the shoot after the start of the schedule", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1", broker.TrialPlanID, workingHours, nil)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", false))
+		scheduler := newScheduler(db, client, time.Date(2026, 10, 14, 21, 0, 0, 0, berlin))
+
+		// when
+		err := scheduler.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, shootHibernated(t, client, "Shoot-instance-1"))
+		instance, err := db.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		assert.True(t, instance.IsHibernated())
+	})
+
+	t.Run("should wake up the shoot after the end of the schedule", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		hibernatedAt := time.Date(2026, 10, 14, 20, 0, 0, 0, berlin)
+		givenInstance(t, db, "instance-1", broker.TrialPlanID, workingHours, &hibernatedAt)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", true))
+		scheduler := newScheduler(db, client, time.Date(2026, 10, 15, 7, 30, 0, 0, berlin))
+
+		// when
+		err := scheduler.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, shootHibernated(t, client, "Shoot-instance-1"))
+		instance, err := db.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		assert.False(t, instance.IsHibernated())
+	})
+
+	t.Run("should wake up the shoot after the schedule was removed", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		hibernatedAt := time.Date(2026, 10, 14, 20, 0, 0, 0, berlin)
+		givenInstance(t, db, "instance-1", broker.TrialPlanID, nil, &hibernatedAt)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", true))
+		scheduler := newScheduler(db, client, time.Date(2026, 10, 14, 21, 0, 0, 0, berlin))
+
+		// when
+		err := scheduler.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, shootHibernated(t, client, "Shoot-instance-1"))
+		instance, err := db.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		assert.False(t, instance.IsHibernated())
+	})
+
+	t.Run("should keep the shoot hibernated over the weekend", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		hibernatedAt := time.Date(2026, 10, 16, 20, 0, 0, 0, berlin)
+		givenInstance(t, db, "instance-1", broker.TrialPlanID, workingHours, &hibernatedAt)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", true))
+		scheduler := newScheduler(db, client, time.Date(2026, 10, 18, 12, 0, 0, 0, berlin))
+
+		// when
+		err := scheduler.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, shootHibernated(t, client, "Shoot-instance-1"))
+		instance, err := db.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		assert.True(t, hibernatedAt.Equal(*instance.HibernatedAt))
+	})
+
+	t.Run("should keep the shoot of the deactivated subaccount hibernated", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		hibernatedAt := time.Date(2026, 10, 14, 20, 0, 0, 0, berlin)
+		givenInstance(t, db, "instance-1", broker.TrialPlanID, workingHours, &hibernatedAt)
+		instance, err := db.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		instance.Parameters.ErsContext.Active = ptr.Bool(false)
+		_, err = db.Instances().Update(*instance)
+		require.NoError(t, err)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", true))
+		scheduler := newScheduler(db, client, time.Date(2026, 10, 15, 7, 30, 0, 0, berlin))
+
+		// when
+		err = scheduler.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, shootHibernated(t, client, "Shoot-instance-1"))
+	})
+
+	t.Run("should skip the instances of the plans without hibernation", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		givenInstance(t, db, "instance-1", broker.AzurePlanID, workingHours, nil)
+		client := gardener.NewDynamicFakeClient(fixShoot("Shoot-instance-1", false))
+		scheduler := newScheduler(db, client, time.Date(2026, 10, 14, 21, 0, 0, 0, berlin))
+
+		// when
+		err := scheduler.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, shootHibernated(t, client, "Shoot-instance-1"))
+	})
+}
+
+func TestScheduler_Lease(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	first := NewScheduler(db, gardener.NewDynamicFakeClient(), namespace, Config{}, logrus.New())
+	first.UseLease(process.NewJobLease(db.OperationLeases(), "hibernation", "replica-1", time.Hour))
+	second := NewScheduler(db, gardener.NewDynamicFakeClient(), namespace, Config{}, logrus.New())
+	second.UseLease(process.NewJobLease(db.OperationLeases(), "hibernation", "replica-2", time.Hour))
+
+	// then
+	assert.True(t, first.holdsLease())
+	assert.False(t, second.holdsLease())
+	assert.True(t, first.holdsLease())
+}
+
+func TestShouldHibernate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		now      time.Time
+		expected *bool
+	}{
+		"before the start in the timezone": {
+			// 19:30 in Berlin
+			now:      time.Date(2026, 10, 14, 17, 30, 0, 0, time.UTC),
+			expected: ptr.Bool(false),
+		},
+		"after the start in the timezone": {
+			// 20:30 in Berlin
+			now:      time.Date(2026, 10, 14, 18, 30, 0, 0, time.UTC),
+			expected: ptr.Bool(true),
+		},
+		"after the end in the timezone": {
+			// 7:30 in Berlin
+			now:      time.Date(2026, 10, 15, 5, 30, 0, 0, time.UTC),
+			expected: ptr.Bool(false),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			hibernate, err := shouldHibernate(workingHours, tc.now)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, hibernate)
+		})
+	}
+
+	t.Run("schedule which did not fire", func(t *testing.T) {
+		// when
+		hibernate, err := shouldHibernate(&internal.HibernationDTO{Start: "0 20 29 2 *", End: "0 7 1 3 *"}, time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, hibernate)
+	})
+}
+
+func newScheduler(db storage.BrokerStorage, client dynamic.Interface, now time.Time) *Scheduler {
+	scheduler := NewScheduler(db, client, namespace, Config{}, logrus.New())
+	scheduler.now = func() time.Time { return now }
+	return scheduler
+}
+
+func givenInstance(t *testing.T, db storage.BrokerStorage, id, planID string, hibernation *internal.HibernationDTO, hibernatedAt *time.Time) {
+	instance := fixture.FixInstance(id)
+	instance.ServicePlanID = planID
+	instance.Parameters.PlanID = planID
+	instance.Parameters.Parameters.Hibernation = hibernation
+	instance.HibernatedAt = hibernatedAt
+	require.NoError(t, db.Instances().Insert(instance))
+
+	operation := fixture.FixProvisioningOperation("op-"+id, id)
+	operation.State = domain.Succeeded
+	require.NoError(t, db.Operations().InsertOperation(operation))
+}
+
+func shootHibernated(t *testing.T, client dynamic.Interface, name string) bool {
+	obj, err := client.Resource(gardener.ShootResource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return gardener.Shoot{Unstructured: *obj}.GetSpecHibernationEnabled()
+}
+
+func fixShoot(name string, hibernated bool) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "core.gardener.cloud/v1beta1",
+		"kind":       "Shoot",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"hibernation": map[string]interface{}{
+				"enabled": hibernated,
+			},
+		},
+	}}
+}
@@ -0,0 +1,37 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// ShootHibernator enables and disables the hibernation of the shoots in Gardener
+type ShootHibernator struct {
+	gardenerClient dynamic.Interface
+	namespace      string
+}
+
+func NewShootHibernator(gardenerClient dynamic.Interface, gardenerNamespace string) *ShootHibernator {
+	return &ShootHibernator{
+		gardenerClient: gardenerClient,
+		namespace:      gardenerNamespace,
+	}
+}
+
+func (h *ShootHibernator) SetHibernation(shootName string, enabled bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"hibernation":{"enabled":%t}}}`, enabled))
+	_, err := h.gardenerClient.Resource(gardener.ShootResource).Namespace(h.namespace).Patch(context.Background(), shootName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("shoot %s not found", shootName)
+		}
+		return fmt.Errorf("while patching shoot %s: %w", shootName, err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package intervention
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// UserHeader is the header with the subject of the authenticated request, set by the istio request authentication
+const UserHeader = "X-KEB-User"
+
+const unknownUser = "unknown"
+
+type Adder interface {
+	Add(processId string)
+}
+
+type OperationStatusDTO struct {
+	OperationID      string   `json:"operationID"`
+	InstanceID       string   `json:"instanceID"`
+	Type             string   `json:"type"`
+	State            string   `json:"state"`
+	Description      string   `json:"description"`
+	Paused           bool     `json:"paused"`
+	FailureRequested bool     `json:"failureRequested"`
+	SkippedSteps     []string `json:"skippedSteps,omitempty"`
+}
+
+// Handler exposes admin endpoints which allow to manually intervene in the processing of an operation.
+// The changes are applied by the manager processing the operation before the next step, the queues trigger the processing.
+type Handler struct {
+	operations storage.Operations
+	queues     map[internal.OperationType]Adder
+	log        logrus.FieldLogger
+}
+
+func NewHandler(operations storage.Operations, queues map[internal.OperationType]Adder, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		operations: operations,
+		queues:     queues,
+		log:        log.WithField("service", "InterventionEndpoint"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/operations/{operation_id}/pause", h.pause).Methods(http.MethodPut)
+	router.HandleFunc("/operations/{operation_id}/resume", h.resume).Methods(http.MethodPut)
+	router.HandleFunc("/operations/{operation_id}/skip-step", h.skipStep).Methods(http.MethodPut)
+	router.HandleFunc("/operations/{operation_id}/fail", h.fail).Methods(http.MethodPut)
+}
+
+func (h *Handler) pause(w http.ResponseWriter, req *http.Request) {
+	h.intervene(w, req, func(operation *internal.Operation) error {
+		if operation.Paused {
+			return fmt.Errorf("operation %s is already paused", operation.ID)
+		}
+		operation.Paused = true
+		return nil
+	}, func(operation internal.Operation, user string) {
+		operation.EventInfof("operation paused by %s", user)
+	})
+}
+
+func (h *Handler) resume(w http.ResponseWriter, req *http.Request) {
+	h.intervene(w, req, func(operation *internal.Operation) error {
+		if !operation.Paused {
+			return fmt.Errorf("operation %s is not paused", operation.ID)
+		}
+		operation.Paused = false
+		return nil
+	}, func(operation internal.Operation, user string) {
+		operation.EventInfof("operation resumed by %s", user)
+		h.queues[operation.Type].Add(operation.ID)
+	})
+}
+
+func (h *Handler) skipStep(w http.ResponseWriter, req *http.Request) {
+	stepName := req.URL.Query().Get("name")
+	if stepName == "" {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("the name of the step to skip is required"))
+		return
+	}
+	h.intervene(w, req, func(operation *internal.Operation) error {
+		if operation.IsStepSkipped(stepName) {
+			return fmt.Errorf("step %s of the operation %s is already skipped", stepName, operation.ID)
+		}
+		operation.SkipStep(stepName)
+		return nil
+	}, func(operation internal.Operation, user string) {
+		operation.EventInfof("step %s skipped by %s", stepName, user)
+		// the upgrade operations which are not paused are processed by the orchestration strategy
+		if !operation.Paused && !isUpgrade(operation.Type) {
+			h.queues[operation.Type].Add(operation.ID)
+		}
+	})
+}
+
+// fail requests the failure of the operation, the manager fails the operation, so the compensation is started and the metrics are updated
+func (h *Handler) fail(w http.ResponseWriter, req *http.Request) {
+	h.intervene(w, req, func(operation *internal.Operation) error {
+		operation.FailureRequested = true
+		operation.Paused = false
+		return nil
+	}, func(operation internal.Operation, user string) {
+		operation.EventInfof("operation failure requested by %s", user)
+		h.queues[operation.Type].Add(operation.ID)
+	})
+}
+
+// intervene applies the change to the not finished operation and saves it, the action is called when the operation is saved
+func (h *Handler) intervene(w http.ResponseWriter, req *http.Request, change func(operation *internal.Operation) error, action func(operation internal.Operation, user string)) {
+	operationID := mux.Vars(req)["operation_id"]
+	user := req.Header.Get(UserHeader)
+	if user == "" {
+		user = unknownUser
+	}
+	logger := h.log.WithFields(logrus.Fields{"operationID": operationID, "user": user, "path": req.URL.Path})
+
+	operation, err := h.operations.GetOperationByID(operationID)
+	if err != nil {
+		logger.Errorf("unable to get operation: %s", err)
+		switch {
+		case dberr.IsNotFound(err):
+			httputil.WriteErrorResponse(w, http.StatusNotFound, err)
+		default:
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if _, found := h.queues[operation.Type]; !found {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("operation type %s is not supported", operation.Type))
+		return
+	}
+	if operation.State == domain.Failed || operation.State == domain.Succeeded {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("operation %s is already finished with state %s", operation.ID, operation.State))
+		return
+	}
+	if operation.FailureRequested {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("failure of the operation %s is already requested", operation.ID))
+		return
+	}
+
+	if err := change(operation); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusConflict, err)
+		return
+	}
+	updated, err := h.operations.UpdateOperation(*operation)
+	if err != nil {
+		logger.Errorf("unable to update operation: %s", err)
+		switch {
+		case dberr.IsConflict(err):
+			httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("operation %s was modified in the meantime, try again", operation.ID))
+		default:
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	action(*updated, user)
+	logger.Infof("operation changed by the administrator")
+	httputil.WriteResponse(w, http.StatusOK, OperationStatusDTO{
+		OperationID:      updated.ID,
+		InstanceID:       updated.InstanceID,
+		Type:             string(updated.Type),
+		State:            string(updated.State),
+		Description:      updated.Description,
+		Paused:           updated.Paused,
+		FailureRequested: updated.FailureRequested,
+		SkippedSteps:     updated.SkippedSteps,
+	})
+}
+
+func isUpgrade(operationType internal.OperationType) bool {
+	return operationType == internal.OperationTypeUpgradeKyma || operationType == internal.OperationTypeUpgradeCluster
+}
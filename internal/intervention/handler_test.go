@@ -0,0 +1,152 @@
+package intervention_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/intervention"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervention(t *testing.T) {
+	router := mux.NewRouter()
+	db := storage.NewMemoryStorage()
+	queue := &collectingQueue{}
+	handler := intervention.NewHandler(db.Operations(), map[internal.OperationType]intervention.Adder{
+		internal.OperationTypeProvision: queue,
+	}, logrus.New())
+	handler.AttachRoutes(router)
+
+	operation := fixture.FixProvisioningOperation("op-1", "inst-1")
+	operation.State = domain.InProgress
+	require.NoError(t, db.Operations().InsertOperation(operation))
+
+	t.Run("should receive 404 Not Found response", func(t *testing.T) {
+		// when
+		resp := callIntervention(router, "op-404-not-found", "pause")
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should receive 409 Conflict response when the operation is not paused", func(t *testing.T) {
+		// when
+		resp := callIntervention(router, "op-1", "resume")
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("should pause and resume the operation", func(t *testing.T) {
+		// when
+		resp := callIntervention(router, "op-1", "pause")
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, decodeStatus(t, resp).Paused)
+		op, err := db.Operations().GetOperationByID("op-1")
+		require.NoError(t, err)
+		assert.True(t, op.Paused)
+		assert.Empty(t, queue.operationIDs)
+
+		// when
+		resp = callIntervention(router, "op-1", "resume")
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.False(t, decodeStatus(t, resp).Paused)
+		op, err = db.Operations().GetOperationByID("op-1")
+		require.NoError(t, err)
+		assert.False(t, op.Paused)
+		assert.Equal(t, []string{"op-1"}, queue.operationIDs)
+	})
+
+	t.Run("should skip the step", func(t *testing.T) {
+		// given
+		queue.operationIDs = nil
+
+		// when
+		resp := callIntervention(router, "op-1", "skip-step")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+		// when
+		resp = callIntervention(router, "op-1", "skip-step?name=Check_GardenerCluster")
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, []string{"Check_GardenerCluster"}, decodeStatus(t, resp).SkippedSteps)
+		op, err := db.Operations().GetOperationByID("op-1")
+		require.NoError(t, err)
+		assert.True(t, op.IsStepSkipped("Check_GardenerCluster"))
+		assert.Equal(t, []string{"op-1"}, queue.operationIDs)
+	})
+
+	t.Run("should request the failure of the operation", func(t *testing.T) {
+		// given
+		queue.operationIDs = nil
+
+		// when
+		resp := callIntervention(router, "op-1", "fail")
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, decodeStatus(t, resp).FailureRequested)
+		op, err := db.Operations().GetOperationByID("op-1")
+		require.NoError(t, err)
+		assert.True(t, op.FailureRequested)
+		assert.Equal(t, []string{"op-1"}, queue.operationIDs)
+
+		// when
+		resp = callIntervention(router, "op-1", "pause")
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("should receive 400 Bad Request response for not supported operation type", func(t *testing.T) {
+		// given
+		deprovisioning := fixture.FixDeprovisioningOperationAsOperation("op-2", "inst-2")
+		deprovisioning.State = domain.InProgress
+		require.NoError(t, db.Operations().InsertOperation(deprovisioning))
+
+		// when
+		resp := callIntervention(router, "op-2", "pause")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+func callIntervention(router *mux.Router, operationID, action string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/operations/%s/%s", operationID, action), nil)
+	req.Header.Set(intervention.UserHeader, "admin@example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeStatus(t *testing.T, resp *httptest.ResponseRecorder) intervention.OperationStatusDTO {
+	var status intervention.OperationStatusDTO
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	return status
+}
+
+type collectingQueue struct {
+	operationIDs []string
+}
+
+func (q *collectingQueue) Add(operationID string) {
+	q.operationIDs = append(q.operationIDs, operationID)
+}
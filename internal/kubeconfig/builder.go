@@ -13,6 +13,7 @@ import (
 
 type Config struct {
 	AllowOrigins string
+	Token        TokenConfig
 }
 
 type Builder struct {
@@ -37,6 +38,7 @@ type kubeconfigData struct {
 	ServerURL     string
 	OIDCIssuerURL string
 	OIDCClientID  string
+	Token         string
 }
 
 func (b *Builder) BuildFromAdminKubeconfig(instance *internal.Instance, adminKubeconfig string) (string, error) {
@@ -79,7 +81,38 @@ func (b *Builder) Build(instance *internal.Instance) (string, error) {
 	return b.BuildFromAdminKubeconfig(instance, "")
 }
 
+// BuildFromServiceAccountToken builds the kubeconfig which authenticates with the given service account token
+// instead of the OIDC login, the cluster data is taken from the admin kubeconfig of the runtime
+func (b *Builder) BuildFromServiceAccountToken(instance *internal.Instance, token string) (string, error) {
+	if instance.RuntimeID == "" {
+		return "", fmt.Errorf("RuntimeID must not be empty")
+	}
+	kubeconfigContent, err := b.kubeconfigProvider.KubeconfigForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return "", err
+	}
+	var kubeCfg kubeconfig
+	err = yaml.Unmarshal(kubeconfigContent, &kubeCfg)
+	if err != nil {
+		return "", fmt.Errorf("while unmarshaling kubeconfig: %w", err)
+	}
+	if err := b.validKubeconfig(kubeCfg); err != nil {
+		return "", fmt.Errorf("while validation kubeconfig: %w", err)
+	}
+
+	return b.executeTemplate(tokenKubeconfigTemplate, kubeconfigData{
+		ContextName: kubeCfg.CurrentContext,
+		CAData:      kubeCfg.Clusters[0].Cluster.CertificateAuthorityData,
+		ServerURL:   kubeCfg.Clusters[0].Cluster.Server,
+		Token:       token,
+	})
+}
+
 func (b *Builder) parseTemplate(payload kubeconfigData) (string, error) {
+	return b.executeTemplate(kubeconfigTemplate, payload)
+}
+
+func (b *Builder) executeTemplate(kubeconfigTemplate string, payload kubeconfigData) (string, error) {
 	var result bytes.Buffer
 	t := template.New("kubeconfigParser")
 	t, err := t.Parse(kubeconfigTemplate)
@@ -113,6 +113,35 @@ func TestBuilder_BuildFromAdminKubeconfig(t *testing.T) {
 	})
 }
 
+func TestBuilder_BuildFromServiceAccountToken(t *testing.T) {
+	t.Run("new kubeconfig was build properly", func(t *testing.T) {
+		// given
+		builder := NewBuilder(&automock.Client{}, NewFakeKubeconfigProvider(skrKubeconfig()))
+		instance := &internal.Instance{
+			RuntimeID:       runtimeID,
+			GlobalAccountID: globalAccountID,
+		}
+
+		// when
+		kubeconfig, err := builder.BuildFromServiceAccountToken(instance, "sa-token")
+
+		//then
+		require.NoError(t, err)
+		require.Equal(t, newServiceAccountKubeconfig(), kubeconfig)
+	})
+
+	t.Run("runtime ID is missing", func(t *testing.T) {
+		// given
+		builder := NewBuilder(&automock.Client{}, NewFakeKubeconfigProvider(skrKubeconfig()))
+
+		// when
+		_, err := builder.BuildFromServiceAccountToken(&internal.Instance{}, "sa-token")
+
+		//then
+		require.Error(t, err)
+	})
+}
+
 func skrKubeconfig() *string {
 	kc := `
 ---
@@ -221,6 +250,29 @@ users:
 	)
 }
 
+func newServiceAccountKubeconfig() string {
+	return `
+---
+apiVersion: v1
+kind: Config
+current-context: shoot--kyma-dev--ac0d8d9
+clusters:
+- name: shoot--kyma-dev--ac0d8d9
+  cluster:
+    certificate-authority-data: LS0tLS1CRUdJTiBDRVJUSUZJQ0FURUSUZJQ0FURS0tLS0tCg==
+    server: https://api.ac0d8d9.kyma-dev.shoot.canary.k8s-hana.ondemand.com
+contexts:
+- name: shoot--kyma-dev--ac0d8d9
+  context:
+    cluster: shoot--kyma-dev--ac0d8d9
+    user: shoot--kyma-dev--ac0d8d9
+users:
+- name: shoot--kyma-dev--ac0d8d9
+  user:
+    token: sa-token
+`
+}
+
 func adminKubeconfig() string {
 	return `
 ---
@@ -1,9 +1,11 @@
 package kubeconfig
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 
@@ -11,6 +13,7 @@ import (
 
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
 	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
@@ -20,7 +23,22 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-const attachmentName = "kubeconfig.yaml"
+const (
+	attachmentName = "kubeconfig.yaml"
+
+	// ModeOIDC returns the kubeconfig which authenticates with the OIDC login
+	ModeOIDC = "oidc"
+	// ModeToken is served only by the authenticated token endpoint, which returns the kubeconfig with the short-lived token
+	// of the ServiceAccount bound to the requested role
+	ModeToken = "token"
+
+	// UserHeader is the header with the subject of the authenticated request, set by the istio request authentication
+	UserHeader = "X-KEB-User"
+	// GlobalAccountHeader is the header with the global account of the authenticated request, set by the istio request authentication
+	GlobalAccountHeader = "X-KEB-Global-Account"
+
+	tokenIssueTimeout = time.Minute
+)
 
 //go:generate mockery --name=KcBuilder --output=automock --outpkg=automock --case=underscore
 
@@ -29,26 +47,38 @@ type KcBuilder interface {
 	BuildFromAdminKubeconfig(instance *internal.Instance, adminKubeconfig string) (string, error)
 }
 
+//go:generate mockery --name=TokenIssuer --output=automock --outpkg=automock --case=underscore
+
+type TokenIssuer interface {
+	Issue(ctx context.Context, instance *internal.Instance, role, requester string) (string, time.Time, error)
+}
+
 type Handler struct {
 	kubeconfigBuilder KcBuilder
+	tokenIssuer       TokenIssuer
+	tokenConfig       TokenConfig
 	allowOrigins      string
 	instanceStorage   storage.Instances
 	operationStorage  storage.Operations
 	log               logrus.FieldLogger
 }
 
-func NewHandler(storage storage.BrokerStorage, b KcBuilder, origins string, log logrus.FieldLogger) *Handler {
+func NewHandler(storage storage.BrokerStorage, b KcBuilder, tokenIssuer TokenIssuer, cfg Config, log logrus.FieldLogger) *Handler {
 	return &Handler{
 		instanceStorage:   storage.Instances(),
 		operationStorage:  storage.Operations(),
 		kubeconfigBuilder: b,
-		allowOrigins:      origins,
+		tokenIssuer:       tokenIssuer,
+		tokenConfig:       cfg.Token,
+		allowOrigins:      cfg.AllowOrigins,
 		log:               log,
 	}
 }
 
 func (h *Handler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/kubeconfig/{instance_id}", h.GetKubeconfig).Methods(http.MethodGet)
+	// the token endpoint must be exposed only with the JWT authentication
+	router.HandleFunc("/kubeconfig/{instance_id}/token", h.GetKubeconfigWithToken).Methods(http.MethodGet)
 	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("instanceID is required"))
 	})
@@ -64,25 +94,107 @@ func (h *Handler) GetKubeconfig(w http.ResponseWriter, r *http.Request) {
 
 	h.specifyAllowOriginHeader(r, w)
 
+	switch r.URL.Query().Get("mode") {
+	case "", ModeOIDC:
+	case ModeToken:
+		h.handleResponse(w, http.StatusBadRequest, fmt.Errorf("kubeconfig with the token is available only at the /kubeconfig/%s/token endpoint", instanceID))
+		return
+	default:
+		h.handleResponse(w, http.StatusBadRequest, fmt.Errorf("mode must be %s", ModeOIDC))
+		return
+	}
+
+	instance, ok := h.getProvisionedInstance(w, instanceID)
+	if !ok {
+		return
+	}
+
+	var newKubeconfig string
+	var err error
+	if instance.ServicePlanID == broker.OwnClusterPlanID {
+		newKubeconfig, err = h.kubeconfigBuilder.BuildFromAdminKubeconfig(instance, instance.InstanceDetails.Kubeconfig)
+	} else {
+		newKubeconfig, err = h.kubeconfigBuilder.Build(instance)
+	}
+	if err != nil {
+		h.handleResponse(w, http.StatusInternalServerError, fmt.Errorf("cannot fetch SKR kubeconfig: %s", err))
+		return
+	}
+
+	writeToResponse(w, newKubeconfig, h.log)
+}
+
+// GetKubeconfigWithToken returns the kubeconfig with the ServiceAccount token of the requester. The endpoint requires the JWT authentication,
+// the requester and its global account are taken from the headers set by the istio request authentication.
+func (h *Handler) GetKubeconfigWithToken(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	instanceID := vars["instance_id"]
+
+	h.specifyAllowOriginHeader(r, w)
+
+	requester := r.Header.Get(UserHeader)
+	if requester == "" {
+		h.handleResponse(w, http.StatusUnauthorized, fmt.Errorf("the request is not authenticated"))
+		return
+	}
+	role := r.URL.Query().Get("role")
+	if !h.tokenConfig.IsRoleAllowed(role) {
+		h.handleResponse(w, http.StatusBadRequest, fmt.Errorf("role must be one of: %s", strings.Join(h.tokenConfig.Roles, ", ")))
+		return
+	}
+
+	instance, ok := h.getProvisionedInstance(w, instanceID)
+	if !ok {
+		return
+	}
+
+	log := h.log.WithField("instanceID", instance.InstanceID).WithField("globalAccountID", instance.GlobalAccountID).WithField("requester", requester)
+	if !h.tokenConfig.IsGlobalAccountAllowed(instance.GlobalAccountID) {
+		log.Warnf("kubeconfig with the %s token rejected, the global account is not allowed", role)
+		h.handleResponse(w, http.StatusForbidden, fmt.Errorf("kubeconfig with the token is not allowed for the global account %s", instance.GlobalAccountID))
+		return
+	}
+	if r.Header.Get(GlobalAccountHeader) != instance.GlobalAccountID {
+		log.Warnf("kubeconfig with the %s token rejected, the requester does not belong to the global account", role)
+		h.handleResponse(w, http.StatusForbidden, fmt.Errorf("the requester does not belong to the global account of the instance %s", instanceID))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenIssueTimeout)
+	defer cancel()
+	newKubeconfig, expiresAt, err := h.tokenIssuer.Issue(ctx, instance, role, requester)
+	if err != nil {
+		events.Errorf(instance.InstanceID, "", err, "unable to issue kubeconfig with the %s token for %s", role, requester)
+		h.handleResponse(w, http.StatusInternalServerError, fmt.Errorf("cannot issue SKR kubeconfig with the token: %s", err))
+		return
+	}
+	log.Infof("kubeconfig with the %s token issued, expires at %s", role, expiresAt)
+	events.Infof(instance.InstanceID, "", "kubeconfig with the %s token issued for %s of the global account %s, expires at %s", role, requester, instance.GlobalAccountID, expiresAt.Format(time.RFC3339))
+
+	writeToResponse(w, newKubeconfig, h.log)
+}
+
+// getProvisionedInstance returns the instance whose kubeconfig can be issued, otherwise the error response is written
+func (h *Handler) getProvisionedInstance(w http.ResponseWriter, instanceID string) (*internal.Instance, bool) {
 	instance, err := h.instanceStorage.GetByID(instanceID)
 	switch {
 	case err == nil:
 	case dberr.IsNotFound(err):
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("instance with ID %s does not exist", instanceID))
-		return
+		return nil, false
 	default:
 		h.handleResponse(w, http.StatusInternalServerError, err)
-		return
+		return nil, false
 	}
 
 	if broker.IsOwnClusterPlan(instance.ServicePlanID) {
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("kubeconfig for instance %s does not exist", instanceID))
-		return
+		return nil, false
 	}
 
 	if instance.RuntimeID == "" {
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("kubeconfig for instance %s does not exist. Provisioning could be in progress, please try again later", instanceID))
-		return
+		return nil, false
 	}
 
 	operation, err := h.operationStorage.GetProvisioningOperationByInstanceID(instanceID)
@@ -90,38 +202,26 @@ func (h *Handler) GetKubeconfig(w http.ResponseWriter, r *http.Request) {
 	case err == nil:
 	case dberr.IsNotFound(err):
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("provisioning operation for instance with ID %s does not exist", instanceID))
-		return
+		return nil, false
 	default:
 		h.handleResponse(w, http.StatusInternalServerError, err)
-		return
+		return nil, false
 	}
 
 	if operation.InstanceID != instanceID {
 		h.handleResponse(w, http.StatusBadRequest, fmt.Errorf("mismatch between operation and instance"))
-		return
+		return nil, false
 	}
 
 	switch operation.State {
 	case domain.InProgress, orchestration.Pending:
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("provisioning operation for instance %s is in progress state, kubeconfig not exist yet, please try again later", instanceID))
-		return
+		return nil, false
 	case domain.Failed:
 		h.handleResponse(w, http.StatusNotFound, fmt.Errorf("provisioning operation for instance %s failed, kubeconfig does not exist", instanceID))
-		return
+		return nil, false
 	}
-
-	var newKubeconfig string
-	if instance.ServicePlanID == broker.OwnClusterPlanID {
-		newKubeconfig, err = h.kubeconfigBuilder.BuildFromAdminKubeconfig(instance, instance.InstanceDetails.Kubeconfig)
-	} else {
-		newKubeconfig, err = h.kubeconfigBuilder.Build(instance)
-	}
-	if err != nil {
-		h.handleResponse(w, http.StatusInternalServerError, fmt.Errorf("cannot fetch SKR kubeconfig: %s", err))
-		return
-	}
-
-	writeToResponse(w, newKubeconfig, h.log)
+	return instance, true
 }
 
 func (h *Handler) handleResponse(w http.ResponseWriter, code int, err error) {
@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/kyma-environment-broker/internal"
@@ -121,7 +123,7 @@ func TestHandler_GetKubeconfig(t *testing.T) {
 
 			router := mux.NewRouter()
 
-			handler := NewHandler(db, builder, "", logger.NewLogDummy())
+			handler := NewHandler(db, builder, nil, Config{}, logger.NewLogDummy())
 			handler.AttachRoutes(router)
 
 			server := httptest.NewServer(router)
@@ -194,7 +196,7 @@ func TestHandler_GetKubeconfigForOwnCluster(t *testing.T) {
 
 	router := mux.NewRouter()
 
-	handler := NewHandler(db, builder, "", logger.NewLogDummy())
+	handler := NewHandler(db, builder, nil, Config{}, logger.NewLogDummy())
 	handler.AttachRoutes(router)
 
 	server := httptest.NewServer(router)
@@ -207,6 +209,141 @@ func TestHandler_GetKubeconfigForOwnCluster(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, response.StatusCode)
 }
 
+func TestHandler_GetKubeconfigWithToken(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	tokenConfig := TokenConfig{Roles: []string{"view", "edit"}, GlobalAccounts: []string{globalAccountID}}
+	cases := map[string]struct {
+		role                   string
+		requester              string
+		requesterGlobalAccount string
+		globalAccountID        string
+		issueErr               error
+		expectedStatusCode     int
+		expectedErrorMessage   string
+	}{
+		"kubeconfig with the token was returned": {
+			role:                   "edit",
+			requester:              "user@example.com",
+			requesterGlobalAccount: globalAccountID,
+			globalAccountID:        globalAccountID,
+			expectedStatusCode:     http.StatusOK,
+		},
+		"request is not authenticated": {
+			role:                   "edit",
+			requesterGlobalAccount: globalAccountID,
+			globalAccountID:        globalAccountID,
+			expectedStatusCode:     http.StatusUnauthorized,
+			expectedErrorMessage:   "the request is not authenticated",
+		},
+		"role is not allowed": {
+			role:                   "cluster-admin",
+			requester:              "user@example.com",
+			requesterGlobalAccount: globalAccountID,
+			globalAccountID:        globalAccountID,
+			expectedStatusCode:     http.StatusBadRequest,
+			expectedErrorMessage:   "role must be one of: view, edit",
+		},
+		"global account is not allowed": {
+			role:                   "view",
+			requester:              "user@example.com",
+			requesterGlobalAccount: "other-global-account",
+			globalAccountID:        "other-global-account",
+			expectedStatusCode:     http.StatusForbidden,
+			expectedErrorMessage:   "kubeconfig with the token is not allowed for the global account other-global-account",
+		},
+		"requester does not belong to the global account": {
+			role:                   "view",
+			requester:              "user@example.com",
+			requesterGlobalAccount: "other-global-account",
+			globalAccountID:        globalAccountID,
+			expectedStatusCode:     http.StatusForbidden,
+			expectedErrorMessage:   fmt.Sprintf("the requester does not belong to the global account of the instance %s", instanceID),
+		},
+		"token issuer failed": {
+			role:                   "view",
+			requester:              "user@example.com",
+			requesterGlobalAccount: globalAccountID,
+			globalAccountID:        globalAccountID,
+			issueErr:               fmt.Errorf("issuer error"),
+			expectedStatusCode:     http.StatusInternalServerError,
+			expectedErrorMessage:   "cannot issue SKR kubeconfig with the token: issuer error",
+		},
+	}
+	for name, d := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			instance := internal.Instance{
+				InstanceID:      instanceID,
+				RuntimeID:       instanceRuntimeID,
+				GlobalAccountID: d.globalAccountID,
+			}
+			operation := internal.ProvisioningOperation{
+				Operation: internal.Operation{
+					ID:         operationID,
+					InstanceID: instance.InstanceID,
+					State:      domain.Succeeded,
+					Type:       internal.OperationTypeProvision,
+				},
+			}
+
+			db := storage.NewMemoryStorage()
+			err := db.Instances().Insert(instance)
+			require.NoError(t, err)
+			err = db.Operations().InsertProvisioningOperation(operation)
+			require.NoError(t, err)
+
+			issuer := &automock.TokenIssuer{}
+			issuer.On("Issue", mock.Anything, &instance, mock.Anything, mock.Anything).Return("--kubeconfig with token", expiresAt, d.issueErr)
+
+			router := mux.NewRouter()
+			handler := NewHandler(db, &automock.KcBuilder{}, issuer, Config{Token: tokenConfig}, logger.NewLogDummy())
+			handler.AttachRoutes(router)
+			server := httptest.NewServer(router)
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/kubeconfig/%s/token?role=%s", server.URL, instanceID, d.role), nil)
+			require.NoError(t, err)
+			if d.requester != "" {
+				request.Header.Set(UserHeader, d.requester)
+			}
+			request.Header.Set(GlobalAccountHeader, d.requesterGlobalAccount)
+
+			// when
+			response, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+
+			// then
+			require.Equal(t, d.expectedStatusCode, response.StatusCode)
+			body, err := ioutil.ReadAll(response.Body)
+			require.NoError(t, err)
+
+			if d.expectedStatusCode == http.StatusOK {
+				require.Equal(t, "--kubeconfig with token", string(body))
+				issuer.AssertCalled(t, "Issue", mock.Anything, &instance, "edit", "user@example.com")
+			} else {
+				var errorResponse ErrorResponse
+				err := json.Unmarshal(body, &errorResponse)
+				require.NoError(t, err)
+				require.Equal(t, d.expectedErrorMessage, errorResponse.Error)
+			}
+		})
+	}
+}
+
+func TestHandler_GetKubeconfigRejectsTokenMode(t *testing.T) {
+	// given
+	router := mux.NewRouter()
+	handler := NewHandler(storage.NewMemoryStorage(), &automock.KcBuilder{}, &automock.TokenIssuer{}, Config{}, logger.NewLogDummy())
+	handler.AttachRoutes(router)
+	server := httptest.NewServer(router)
+
+	// when
+	response, err := http.Get(fmt.Sprintf("%s/kubeconfig/%s?mode=token&role=view", server.URL, instanceID))
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
 func TestHandler_specifyAllowOriginHeader(t *testing.T) {
 	cases := map[string]struct {
 		requestHeader      http.Header
@@ -255,7 +392,7 @@ func TestHandler_specifyAllowOriginHeader(t *testing.T) {
 			request := &http.Request{Header: d.requestHeader}
 			response := &httptest.ResponseRecorder{}
 
-			handler := NewHandler(storage.NewMemoryStorage(), nil, d.origins, nil)
+			handler := NewHandler(storage.NewMemoryStorage(), nil, nil, Config{AllowOrigins: d.origins}, nil)
 
 			// when
 			handler.specifyAllowOriginHeader(request, response)
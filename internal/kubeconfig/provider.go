@@ -3,10 +3,13 @@ package kubeconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -86,5 +89,60 @@ func (p *FakeProvider) K8sClientForRuntimeID(_ string) (client.Client, error) {
 }
 
 func (p *FakeProvider) KubeconfigForRuntimeID(runtimeId string) ([]byte, error) {
-	return []byte("fake kubeconfig"), nil
+	return []byte(fmt.Sprintf(fakeKubeconfig, runtimeId)), nil
+}
+
+const fakeKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: %[1]s
+clusters:
+- name: %[1]s
+  cluster:
+    certificate-authority-data: ZmFrZQ==
+    server: https://api.%[1]s.fake
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+users:
+- name: %[1]s
+  user:
+    token: fake
+`
+
+// NewFakeTokenRequestClient wraps the client which does not support TokenRequests, e.g. the controller-runtime fake client,
+// the returned client issues fake tokens for the service accounts
+func NewFakeTokenRequestClient(c client.Client) client.Client {
+	return &fakeTokenRequestClient{Client: c}
+}
+
+type fakeTokenRequestClient struct {
+	client.Client
+}
+
+func (c *fakeTokenRequestClient) SubResource(subResource string) client.SubResourceClient {
+	if subResource == "token" {
+		return &fakeTokenSubResourceClient{SubResourceClient: c.Client.SubResource(subResource)}
+	}
+	return c.Client.SubResource(subResource)
+}
+
+type fakeTokenSubResourceClient struct {
+	client.SubResourceClient
+}
+
+func (c *fakeTokenSubResourceClient) Create(_ context.Context, obj client.Object, subResource client.Object, _ ...client.SubResourceCreateOption) error {
+	tokenRequest, ok := subResource.(*authv1.TokenRequest)
+	if !ok {
+		return fmt.Errorf("unexpected token subresource %T", subResource)
+	}
+	expiration := time.Hour
+	if tokenRequest.Spec.ExpirationSeconds != nil {
+		expiration = time.Duration(*tokenRequest.Spec.ExpirationSeconds) * time.Second
+	}
+	tokenRequest.Status.Token = fmt.Sprintf("token-%s-%s", obj.GetNamespace(), obj.GetName())
+	tokenRequest.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(expiration))
+	return nil
 }
@@ -0,0 +1,155 @@
+package kubeconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	tokenNamespace             = "kyma-system"
+	tokenServiceAccountPrefix  = "kyma-kubeconfig-"
+	minTokenExpirationDuration = 10 * time.Minute
+
+	// RequesterAnnotation holds the subject of the request which created the ServiceAccount
+	RequesterAnnotation = "kyma-project.io/kubeconfig-requester"
+)
+
+type TokenConfig struct {
+	// Roles are the ClusterRoles which can be requested for the kubeconfig with the ServiceAccount token
+	Roles      []string      `envconfig:"default=view"`
+	Expiration time.Duration `envconfig:"default=1h"`
+	// GlobalAccounts are the global accounts whose instances can get the kubeconfig with the ServiceAccount token,
+	// the kubeconfig with the token is not issued if the list is empty
+	GlobalAccounts []string `envconfig:"optional"`
+}
+
+func (c TokenConfig) IsRoleAllowed(role string) bool {
+	return contains(c.Roles, role)
+}
+
+func (c TokenConfig) IsGlobalAccountAllowed(globalAccountID string) bool {
+	return contains(c.GlobalAccounts, globalAccountID)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+type k8sClientProvider interface {
+	K8sClientForRuntimeID(runtimeID string) (client.Client, error)
+}
+
+type serviceAccountKubeconfigBuilder interface {
+	BuildFromServiceAccountToken(instance *internal.Instance, token string) (string, error)
+}
+
+// ServiceAccountTokenIssuer issues the kubeconfigs with the short-lived tokens of the ServiceAccounts bound to the requested ClusterRoles.
+// Every requester gets its own ServiceAccount of the role, so the tokens of a single requester can be revoked by deleting its ServiceAccount.
+type ServiceAccountTokenIssuer struct {
+	clientProvider    k8sClientProvider
+	kubeconfigBuilder serviceAccountKubeconfigBuilder
+	expiration        time.Duration
+}
+
+func NewServiceAccountTokenIssuer(clientProvider k8sClientProvider, kubeconfigBuilder serviceAccountKubeconfigBuilder, expiration time.Duration) *ServiceAccountTokenIssuer {
+	if expiration < minTokenExpirationDuration {
+		expiration = minTokenExpirationDuration
+	}
+	return &ServiceAccountTokenIssuer{
+		clientProvider:    clientProvider,
+		kubeconfigBuilder: kubeconfigBuilder,
+		expiration:        expiration,
+	}
+}
+
+// Issue returns the kubeconfig with the token of the requester's ServiceAccount bound to the role and the expiration time of the token
+func (i *ServiceAccountTokenIssuer) Issue(ctx context.Context, instance *internal.Instance, role, requester string) (string, time.Time, error) {
+	k8sClient, err := i.clientProvider.K8sClientForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while getting k8s client for runtime %s: %w", instance.RuntimeID, err)
+	}
+
+	name := serviceAccountName(role, requester)
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker",
+	}
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   tokenNamespace,
+			Labels:      labels,
+			Annotations: map[string]string{RequesterAnnotation: requester},
+		},
+	}
+	err = k8sClient.Create(ctx, serviceAccount)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", time.Time{}, fmt.Errorf("while creating service account %s: %w", name, err)
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{RequesterAnnotation: requester},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     role,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      name,
+				Namespace: tokenNamespace,
+			},
+		},
+	}
+	err = k8sClient.Create(ctx, clusterRoleBinding)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", time.Time{}, fmt.Errorf("while creating cluster role binding %s: %w", name, err)
+	}
+
+	expiration := int64(i.expiration.Seconds())
+	tokenRequest := &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			ExpirationSeconds: &expiration,
+		},
+	}
+	err = k8sClient.SubResource("token").Create(ctx, serviceAccount, tokenRequest)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating token for service account %s: %w", name, err)
+	}
+
+	kubeconfig, err := i.kubeconfigBuilder.BuildFromServiceAccountToken(instance, tokenRequest.Status.Token)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while building kubeconfig: %w", err)
+	}
+
+	return kubeconfig, tokenRequest.Status.ExpirationTimestamp.Time, nil
+}
+
+// serviceAccountName returns the name of the requester's ServiceAccount of the role. The ClusterRole names may contain colons
+// and the requesters may contain any characters, which are not allowed in the ServiceAccount names, so the requester is hashed.
+func serviceAccountName(role, requester string) string {
+	hash := sha256.Sum256([]byte(requester))
+	return tokenServiceAccountPrefix + strings.ToLower(strings.ReplaceAll(role, ":", "-")) + "-" + hex.EncodeToString(hash[:])[:10]
+}
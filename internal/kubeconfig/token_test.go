@@ -0,0 +1,75 @@
+package kubeconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/provisioner/automock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceAccountTokenIssuer_Issue(t *testing.T) {
+	// given
+	k8sClient := fake.NewClientBuilder().WithScheme(internal.NewSchemeForTests()).Build()
+	builder := NewBuilder(&automock.Client{}, NewFakeKubeconfigProvider(skrKubeconfig()))
+	issuer := NewServiceAccountTokenIssuer(NewFakeK8sClientProvider(NewFakeTokenRequestClient(k8sClient)), builder, 30*time.Minute)
+	instance := &internal.Instance{
+		RuntimeID:       runtimeID,
+		GlobalAccountID: globalAccountID,
+	}
+
+	// when
+	kubeconfig, expiresAt, err := issuer.Issue(context.Background(), instance, "view", "user@example.com")
+
+	// then
+	require.NoError(t, err)
+	name := serviceAccountName("view", "user@example.com")
+	assert.Contains(t, kubeconfig, "token: token-kyma-system-"+name)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), expiresAt, time.Minute)
+	serviceAccount := &corev1.ServiceAccount{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: name}, serviceAccount))
+	assert.Equal(t, "user@example.com", serviceAccount.Annotations[RequesterAnnotation])
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: name}, clusterRoleBinding))
+	assert.Equal(t, "view", clusterRoleBinding.RoleRef.Name)
+	assert.Equal(t, name, clusterRoleBinding.Subjects[0].Name)
+
+	// when the token is issued again for the same role and requester
+	_, _, err = issuer.Issue(context.Background(), instance, "view", "user@example.com")
+
+	// then
+	require.NoError(t, err)
+
+	// when the token is issued for another requester
+	_, _, err = issuer.Issue(context.Background(), instance, "view", "other@example.com")
+
+	// then
+	require.NoError(t, err)
+	otherName := serviceAccountName("view", "other@example.com")
+	assert.NotEqual(t, name, otherName)
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: otherName}, &corev1.ServiceAccount{}))
+}
+
+func TestServiceAccountName(t *testing.T) {
+	// when
+	name := serviceAccountName("kyma:namespace-admin", "user@example.com")
+
+	// then
+	assert.Regexp(t, "^kyma-kubeconfig-kyma-namespace-admin-[0-9a-f]{10}$", name)
+}
+
+func TestNewServiceAccountTokenIssuer_MinimalExpiration(t *testing.T) {
+	// when
+	issuer := NewServiceAccountTokenIssuer(nil, nil, time.Minute)
+
+	// then
+	assert.Equal(t, minTokenExpirationDuration, issuer.expiration)
+}
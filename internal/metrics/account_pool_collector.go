@@ -0,0 +1,207 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// AccountPoolStatusGetter provides the capacity of the hyperscaler account pool
+type AccountPoolStatusGetter interface {
+	Status(hyperscalerType hyperscaler.Type, euAccess bool) (hyperscaler.PoolStatus, error)
+	SharedUsage(hyperscalerType hyperscaler.Type) ([]hyperscaler.SharedAccountUsage, error)
+}
+
+type AccountPoolConfig struct {
+	Enabled  bool          `envconfig:"default=false"`
+	Interval time.Duration `envconfig:"default=5m"`
+	// HyperscalerTypes lists the hyperscaler types to count, e.g. "aws,gcp,openstack_eu-de-1". If empty, gcp, azure and aws are counted.
+	HyperscalerTypes []string `envconfig:"optional"`
+}
+
+// AccountPoolCollector periodically counts the accounts of the hyperscaler account pool and exposes them as:
+//
+// - compass_keb_hyperscaler_accounts - the number of accounts by hyperscaler type, EU access and state
+// (free, used, dirty, internal, shared)
+// - compass_keb_hyperscaler_shared_account_shoots - the number of shoots using the shared account
+// - compass_keb_hyperscaler_accounts_update_failed - 1 if the last update of the hyperscaler type failed, 0 otherwise
+//
+// The metrics of the hyperscaler type which cannot be counted keep the values of the last successful update.
+type AccountPoolCollector struct {
+	pool     AccountPoolStatusGetter
+	types    []hyperscaler.Type
+	interval time.Duration
+	logger   logrus.FieldLogger
+
+	accounts     *prometheus.GaugeVec
+	sharedShoots *prometheus.GaugeVec
+	failed       *prometheus.GaugeVec
+
+	mu sync.RWMutex
+	// statuses holds the last counted pool statuses by the hyperscaler type key and EU access
+	statuses  map[string]hyperscaler.PoolStatus
+	updatedAt time.Time
+}
+
+func NewAccountPoolCollector(pool AccountPoolStatusGetter, cfg AccountPoolConfig, logger logrus.FieldLogger) (*AccountPoolCollector, error) {
+	keys := cfg.HyperscalerTypes
+	if len(keys) == 0 {
+		keys = []string{hyperscaler.GCP().GetKey(), hyperscaler.Azure().GetKey(), hyperscaler.AWS().GetKey()}
+	}
+	types := make([]hyperscaler.Type, 0, len(keys))
+	for _, key := range keys {
+		hyperscalerType, err := hyperscaler.ParseType(key)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, hyperscalerType)
+	}
+
+	return &AccountPoolCollector{
+		pool:     pool,
+		types:    types,
+		interval: cfg.Interval,
+		logger:   logger.WithField("service", "AccountPoolCollector"),
+		accounts: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "hyperscaler_accounts",
+			Help:      "The number of hyperscaler accounts in the pool by state",
+		}, []string{"hyperscaler_type", "eu_access", "state"}),
+		sharedShoots: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "hyperscaler_shared_account_shoots",
+			Help:      "The number of shoots using the shared hyperscaler account",
+		}, []string{"hyperscaler_type", "account"}),
+		failed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "hyperscaler_accounts_update_failed",
+			Help:      "Whether the last update of the hyperscaler accounts failed (1) or not (0)",
+		}, []string{"hyperscaler_type"}),
+		statuses: map[string]hyperscaler.PoolStatus{},
+	}, nil
+}
+
+func (c *AccountPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.accounts.Describe(ch)
+	c.sharedShoots.Describe(ch)
+	c.failed.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface, it exposes the values counted by the last update.
+func (c *AccountPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.accounts.Collect(ch)
+	c.sharedShoots.Collect(ch)
+	c.failed.Collect(ch)
+}
+
+// Start runs the updates in the background until the context is done
+func (c *AccountPoolCollector) Start(ctx context.Context) {
+	go c.run(ctx)
+}
+
+func (c *AccountPoolCollector) run(ctx context.Context) {
+	if err := c.UpdateMetrics(); err != nil {
+		c.logger.Errorf("failed to update account pool metrics: %v", err)
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.UpdateMetrics(); err != nil {
+				c.logger.Errorf("failed to update account pool metrics: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// UpdateMetrics counts the accounts of all the hyperscaler types. Only the metrics of the counted types are replaced,
+// the types which cannot be counted keep the last values and are marked as failed.
+func (c *AccountPoolCollector) UpdateMetrics() error {
+	var lastErr error
+	for _, hyperscalerType := range c.types {
+		if err := c.updateType(hyperscalerType); err != nil {
+			lastErr = err
+			c.logger.Warn(err.Error())
+			c.failed.WithLabelValues(hyperscalerType.GetKey()).Set(1)
+			continue
+		}
+		c.failed.WithLabelValues(hyperscalerType.GetKey()).Set(0)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.updatedAt = time.Now()
+
+	return lastErr
+}
+
+// updateType replaces the metrics of the hyperscaler type, the pools which cannot be counted are left unchanged
+func (c *AccountPoolCollector) updateType(hyperscalerType hyperscaler.Type) error {
+	key := hyperscalerType.GetKey()
+	sharedUsage, err := c.pool.SharedUsage(hyperscalerType)
+	if err != nil {
+		return fmt.Errorf("while counting shared accounts of %s: %w", key, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sharedShoots.DeletePartialMatch(prometheus.Labels{"hyperscaler_type": key})
+	for _, usage := range sharedUsage {
+		c.sharedShoots.WithLabelValues(key, usage.Name).Set(float64(usage.Shoots))
+	}
+
+	var lastErr error
+	for _, euAccess := range []bool{false, true} {
+		status, err := c.pool.Status(hyperscalerType, euAccess)
+		if err != nil {
+			lastErr = fmt.Errorf("while counting accounts of %s: %w", key, err)
+			continue
+		}
+		status.LeastUsedShared = sharedUsage
+		c.statuses[statusKey(key, euAccess)] = status
+
+		euAccessLabel := strconv.FormatBool(euAccess)
+		c.accounts.DeletePartialMatch(prometheus.Labels{"hyperscaler_type": key, "eu_access": euAccessLabel})
+		for state, count := range map[string]int{
+			"free":     status.Free,
+			"used":     status.Used,
+			"dirty":    status.Dirty,
+			"internal": status.Internal,
+			"shared":   status.Shared,
+		} {
+			c.accounts.WithLabelValues(key, euAccessLabel, state).Set(float64(count))
+		}
+	}
+	return lastErr
+}
+
+// Statuses returns the last counted pool statuses
+func (c *AccountPoolCollector) Statuses() ([]hyperscaler.PoolStatus, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var statuses []hyperscaler.PoolStatus
+	for _, hyperscalerType := range c.types {
+		for _, euAccess := range []bool{false, true} {
+			if status, found := c.statuses[statusKey(hyperscalerType.GetKey(), euAccess)]; found {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	return statuses, c.updatedAt
+}
+
+func statusKey(hyperscalerType string, euAccess bool) string {
+	return fmt.Sprintf("%s/%t", hyperscalerType, euAccess)
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAccountPool struct {
+	statuses map[string]hyperscaler.PoolStatus
+	shared   []hyperscaler.SharedAccountUsage
+}
+
+func (p *fakeAccountPool) Status(hyperscalerType hyperscaler.Type, euAccess bool) (hyperscaler.PoolStatus, error) {
+	status, found := p.statuses[fmt.Sprintf("%s/%t", hyperscalerType.GetKey(), euAccess)]
+	if !found {
+		return hyperscaler.PoolStatus{}, fmt.Errorf("no accounts")
+	}
+	return status, nil
+}
+
+func (p *fakeAccountPool) SharedUsage(hyperscalerType hyperscaler.Type) ([]hyperscaler.SharedAccountUsage, error) {
+	return p.shared, nil
+}
+
+func TestAccountPoolCollector(t *testing.T) {
+	// given
+	pool := &fakeAccountPool{
+		statuses: map[string]hyperscaler.PoolStatus{
+			"aws/false": {HyperscalerType: "aws", Free: 2, Used: 10, Dirty: 1, Shared: 1},
+			"aws/true":  {HyperscalerType: "aws", EUAccess: true, Used: 3},
+		},
+		shared: []hyperscaler.SharedAccountUsage{{Name: "aws-shared", Shoots: 4}},
+	}
+	collector, err := NewAccountPoolCollector(pool, AccountPoolConfig{Interval: time.Minute, HyperscalerTypes: []string{"aws"}}, logrus.New())
+	require.NoError(t, err)
+
+	// when
+	err = collector.UpdateMetrics()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.accounts.WithLabelValues("aws", "false", "free")))
+	assert.Equal(t, float64(10), testutil.ToFloat64(collector.accounts.WithLabelValues("aws", "false", "used")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.accounts.WithLabelValues("aws", "true", "free")))
+	assert.Equal(t, float64(4), testutil.ToFloat64(collector.sharedShoots.WithLabelValues("aws", "aws-shared")))
+
+	// when
+	router := mux.NewRouter()
+	NewAccountPoolHandler(collector, logrus.New()).AttachRoutes(router)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/pool/status", nil))
+
+	// then
+	require.Equal(t, http.StatusOK, resp.Code)
+	var dto AccountPoolStatusDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &dto))
+	require.Len(t, dto.Pools, 2)
+	assert.Equal(t, 2, dto.Pools[0].Free)
+	assert.True(t, dto.Pools[1].EUAccess)
+	assert.Equal(t, []hyperscaler.SharedAccountUsage{{Name: "aws-shared", Shoots: 4}}, dto.Pools[0].LeastUsedShared)
+}
+
+func TestAccountPoolCollector_Errors(t *testing.T) {
+	t.Run("should skip the hyperscaler types which cannot be counted", func(t *testing.T) {
+		// given
+		pool := &fakeAccountPool{statuses: map[string]hyperscaler.PoolStatus{
+			"gcp/false": {HyperscalerType: "gcp", Free: 1},
+		}}
+		collector, err := NewAccountPoolCollector(pool, AccountPoolConfig{Interval: time.Minute}, logrus.New())
+		require.NoError(t, err)
+
+		// when
+		err = collector.UpdateMetrics()
+
+		// then
+		assert.Error(t, err)
+		statuses, updatedAt := collector.Statuses()
+		assert.False(t, updatedAt.IsZero())
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "gcp", statuses[0].HyperscalerType)
+	})
+
+	t.Run("should keep the last values of the hyperscaler type which failed to update", func(t *testing.T) {
+		// given
+		pool := &fakeAccountPool{statuses: map[string]hyperscaler.PoolStatus{
+			"aws/false": {HyperscalerType: "aws", Free: 2, Used: 10},
+			"gcp/false": {HyperscalerType: "gcp", Free: 1},
+		}}
+		collector, err := NewAccountPoolCollector(pool, AccountPoolConfig{Interval: time.Minute, HyperscalerTypes: []string{"aws", "gcp"}}, logrus.New())
+		require.NoError(t, err)
+		_ = collector.UpdateMetrics()
+
+		// when
+		pool.statuses = map[string]hyperscaler.PoolStatus{
+			"gcp/false": {HyperscalerType: "gcp", Free: 3},
+		}
+		err = collector.UpdateMetrics()
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, float64(2), testutil.ToFloat64(collector.accounts.WithLabelValues("aws", "false", "free")))
+		assert.Equal(t, float64(10), testutil.ToFloat64(collector.accounts.WithLabelValues("aws", "false", "used")))
+		assert.Equal(t, float64(3), testutil.ToFloat64(collector.accounts.WithLabelValues("gcp", "false", "free")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(collector.failed.WithLabelValues("aws")))
+		statuses, _ := collector.Statuses()
+		require.Len(t, statuses, 2)
+		assert.Equal(t, 2, statuses[0].Free)
+		assert.Equal(t, 3, statuses[1].Free)
+	})
+
+	t.Run("should return error for unknown hyperscaler type", func(t *testing.T) {
+		// when
+		_, err := NewAccountPoolCollector(&fakeAccountPool{}, AccountPoolConfig{HyperscalerTypes: []string{"alicloud"}}, logrus.New())
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/sirupsen/logrus"
+)
+
+type AccountPoolStatusDTO struct {
+	UpdatedAt time.Time                `json:"updatedAt"`
+	Pools     []hyperscaler.PoolStatus `json:"pools"`
+}
+
+// AccountPoolHandler exposes the account pool capacity counted by the AccountPoolCollector
+type AccountPoolHandler struct {
+	collector *AccountPoolCollector
+	log       logrus.FieldLogger
+}
+
+func NewAccountPoolHandler(collector *AccountPoolCollector, log logrus.FieldLogger) *AccountPoolHandler {
+	return &AccountPoolHandler{
+		collector: collector,
+		log:       log.WithField("service", "AccountPoolHandler"),
+	}
+}
+
+func (h *AccountPoolHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/pool/status", h.getStatus).Methods(http.MethodGet)
+}
+
+func (h *AccountPoolHandler) getStatus(w http.ResponseWriter, req *http.Request) {
+	statuses, updatedAt := h.collector.Statuses()
+	if updatedAt.IsZero() {
+		// the first update has not finished yet
+		if err := h.collector.UpdateMetrics(); err != nil {
+			h.log.Warnf("while counting accounts: %v", err)
+		}
+		statuses, updatedAt = h.collector.Statuses()
+	}
+	if statuses == nil {
+		statuses = []hyperscaler.PoolStatus{}
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, AccountPoolStatusDTO{
+		UpdatedAt: updatedAt,
+		Pools:     statuses,
+	})
+}
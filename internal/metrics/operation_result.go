@@ -145,8 +145,19 @@ func (c *OperationResultCollector) OnOperationStepProcessed(ctx context.Context,
 			StepProcessed: e.StepProcessed,
 			Operation:     internal.DeprovisioningOperation{Operation: e.Operation},
 		})
+	case internal.OperationTypeUpgradeKyma:
+		return c.OnUpgradeKymaStepProcessed(ctx, process.UpgradeKymaStepProcessed{
+			StepProcessed: e.StepProcessed,
+			Operation:     internal.UpgradeKymaOperation{Operation: e.Operation},
+		})
+	case internal.OperationTypeUpgradeCluster:
+		return c.OnUpgradeClusterStepProcessed(ctx, process.UpgradeClusterStepProcessed{
+			StepProcessed: e.StepProcessed,
+			Operation:     internal.UpgradeClusterOperation{Operation: e.Operation},
+		})
 	default:
-		return fmt.Errorf("expected OperationStep of types [%s, %s] but got %+v", internal.OperationTypeProvision, internal.OperationTypeDeprovision, e.Operation.Type)
+		return fmt.Errorf("expected OperationStep of types [%s, %s, %s, %s] but got %+v", internal.OperationTypeProvision, internal.OperationTypeDeprovision,
+			internal.OperationTypeUpgradeKyma, internal.OperationTypeUpgradeCluster, e.Operation.Type)
 	}
 }
 
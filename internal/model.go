@@ -46,6 +46,7 @@ type ProvisionerInputCreator interface {
 	SetShootDNSProviders(dnsProviders gardener.DNSProvidersData) ProvisionerInputCreator
 	SetClusterName(name string) ProvisionerInputCreator
 	SetOIDCLastValues(oidcConfig gqlschema.OIDCConfigInput) ProvisionerInputCreator
+	Snapshot() ProvisionerInput
 }
 
 // GitKymaProject and GitKymaRepo define public Kyma GitHub parameters used for
@@ -140,6 +141,8 @@ type Instance struct {
 	UpdatedAt time.Time
 	DeletedAt time.Time
 	ExpiredAt *time.Time
+	// HibernatedAt is set when the cluster is hibernated by the hibernation schedule
+	HibernatedAt *time.Time
 
 	Version      int
 	Provider     CloudProvider
@@ -150,6 +153,15 @@ func (i *Instance) IsExpired() bool {
 	return i.ExpiredAt != nil
 }
 
+func (i *Instance) IsHibernated() bool {
+	return i.HibernatedAt != nil
+}
+
+// IsDeactivated returns true if the subaccount of the instance is deactivated by ERS
+func (i *Instance) IsDeactivated() bool {
+	return i.Parameters.ErsContext.Active != nil && !*i.Parameters.ErsContext.Active
+}
+
 func (i *Instance) GetSubscriptionGlobalAccoundID() string {
 	if i.SubscriptionGlobalAccountID != "" {
 		return i.SubscriptionGlobalAccountID
@@ -201,11 +213,33 @@ type Operation struct {
 	ProvisioningParameters ProvisioningParameters    `json:"-"`
 
 	InputCreator ProvisionerInputCreator `json:"-"`
+	// ProvisionerInput is the state of the InputCreator saved with the finished stage, it is stored encrypted in a separate column
+	ProvisionerInput *ProvisionerInput `json:"-"`
 
 	// OrchestrationID specifies the origin orchestration which triggers the operation, empty for OSB operations (provisioning/deprovisioning)
 	OrchestrationID string             `json:"-"`
 	FinishedStages  []string           `json:"-"`
-	LastError       kebError.LastError `json:"-"`
+	LastError       kebError.LastError `json:"last_error"`
+
+	// CompensableSteps contains names of executed steps which are able to revert their changes, in the order of execution
+	CompensableSteps []string `json:"compensable_steps,omitempty"`
+	// CompensatedSteps contains names of steps which changes were already reverted
+	CompensatedSteps []string `json:"compensated_steps,omitempty"`
+	// FailedCompensations contains names of steps which changes could not be reverted and must be cleaned up manually
+	FailedCompensations []string `json:"failed_compensations,omitempty"`
+	// CompensationInProgress indicates that the operation failed and the compensation of executed steps is not finished yet
+	CompensationInProgress bool      `json:"compensation_in_progress,omitempty"`
+	CompensationStartedAt  time.Time `json:"compensation_started_at,omitempty"`
+
+	// StepRetries contains retries of the steps registered with a retry policy, by step name
+	StepRetries map[string]StepRetries `json:"step_retries,omitempty"`
+
+	// Paused stops the processing of the operation until it is resumed by an administrator
+	Paused bool `json:"paused,omitempty"`
+	// FailureRequested makes the manager fail the operation before the next step, it is set by an administrator
+	FailureRequested bool `json:"failure_requested,omitempty"`
+	// SkippedSteps contains names of steps which an administrator decided to not execute
+	SkippedSteps []string `json:"skipped_steps,omitempty"`
 
 	// PROVISIONING
 	RuntimeVersion RuntimeVersionData `json:"runtime_version"`
@@ -241,6 +275,26 @@ type Operation struct {
 	KymaTemplate string `json:"KymaTemplate"`
 }
 
+// StepRetries holds the number of retries of a step and the time of the first retry
+type StepRetries struct {
+	Attempts       int       `json:"attempts"`
+	FirstAttemptAt time.Time `json:"first_attempt_at"`
+}
+
+// RetryStep records the next retry of the step and returns the retries of the step
+func (o *Operation) RetryStep(stepName string) StepRetries {
+	if o.StepRetries == nil {
+		o.StepRetries = make(map[string]StepRetries)
+	}
+	retries := o.StepRetries[stepName]
+	if retries.Attempts == 0 {
+		retries.FirstAttemptAt = time.Now()
+	}
+	retries.Attempts++
+	o.StepRetries[stepName] = retries
+	return retries
+}
+
 func (o *Operation) IsFinished() bool {
 	return o.State != orchestration.InProgress && o.State != orchestration.Pending && o.State != orchestration.Canceling && o.State != orchestration.Retrying
 }
@@ -264,6 +318,22 @@ type Orchestration struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	Parameters      orchestration.Parameters
+	// Canary holds the wave state of the orchestration executed with the canary strategy
+	Canary *CanaryState
+}
+
+// CanaryState holds the progress of the canary strategy, it is used to continue at the right wave after restart
+type CanaryState struct {
+	// Wave is the number of the current wave, the first wave has number 1
+	Wave int `json:"wave"`
+	// Total is the number of operations scheduled by the orchestration
+	Total int `json:"total"`
+	// Done is the number of operations in the already evaluated waves
+	Done int `json:"done"`
+	// OperationIDs contains the operations of the current wave
+	OperationIDs []string `json:"operationIDs"`
+	// SoakUntil is the end of the soak period of the current wave, zero until all operations of the wave are finished
+	SoakUntil time.Time `json:"soakUntil,omitempty"`
 }
 
 func (o *Orchestration) IsFinished() bool {
@@ -275,6 +345,84 @@ func (o *Orchestration) IsCanceled() bool {
 	return o.State == orchestration.Canceling || o.State == orchestration.Canceled
 }
 
+// OperationTrace holds the result of a single step invocation during the operation processing
+type OperationTrace struct {
+	ID          string
+	OperationID string
+	InstanceID  string
+	Stage       string
+	StepName    string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Backoff     time.Duration
+	Error       string
+	State       domain.LastOperationState
+}
+
+// OperationLease marks the KEB replica which owns the processing of the operation until the lease expires
+type OperationLease struct {
+	OperationID string
+	Owner       string
+	AcquiredAt  time.Time
+	ExpiresAt   time.Time
+}
+
+// Binding is a service binding of the instance which holds the kubeconfig issued for a dedicated service account in the runtime
+type Binding struct {
+	ID         string
+	InstanceID string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+	ExpiredAt *time.Time
+
+	Kubeconfig  string
+	State       domain.LastOperationState
+	Description string
+}
+
+func (b *Binding) IsExpired() bool {
+	return b.ExpiredAt != nil
+}
+
+// HyperscalerAccount is an account of the hyperscaler account pool which is not backed by Gardener bindings
+type HyperscalerAccount struct {
+	// Name is the name used by the cluster to refer to the account
+	Name string
+	// SecretName is the name of the Gardener secret with the account credentials
+	SecretName      string
+	HyperscalerType string
+	TenantName      string
+
+	Shared   bool
+	Dirty    bool
+	Internal bool
+	EUAccess bool
+	// ManualCleanup marks the dirty account which must be cleaned up by an operator before it is returned to the pool
+	ManualCleanup bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   int
+}
+
+// RuntimeDrift lists the differences found between the parameters of the instance and the shoot of its runtime
+type RuntimeDrift struct {
+	InstanceID string
+	RuntimeID  string
+	ShootName  string
+	Findings   []DriftFinding
+	DetectedAt time.Time
+}
+
+// DriftFinding is an attribute of the shoot which does not match the value expected by the instance
+type DriftFinding struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
 type InstanceWithOperation struct {
 	Instance
 
@@ -574,6 +722,12 @@ func NewUpdateOperation(operationID string, instance *Instance, updatingParams U
 	if updatingParams.MachineType != nil && *updatingParams.MachineType != "" {
 		op.ProvisioningParameters.Parameters.MachineType = updatingParams.MachineType
 	}
+	if updatingParams.Hibernation != nil {
+		op.ProvisioningParameters.Parameters.Hibernation = updatingParams.Hibernation.Schedule()
+	}
+	if updatingParams.Modules != nil {
+		op.ProvisioningParameters.Parameters.Modules = updatingParams.Modules
+	}
 
 	return op
 }
@@ -625,6 +779,77 @@ func (o *Operation) IsStageFinished(stage string) bool {
 	return false
 }
 
+// AddCompensableStep records the step which must be compensated if the operation fails
+func (o *Operation) AddCompensableStep(stepName string) {
+	for _, value := range o.CompensableSteps {
+		if value == stepName {
+			return
+		}
+	}
+	o.CompensableSteps = append(o.CompensableSteps, stepName)
+}
+
+func (o *Operation) CompensateStep(stepName string) {
+	if o.IsStepCompensated(stepName) {
+		log.Warnf("Attempt to compensate step (%s) which is already compensated.", stepName)
+		return
+	}
+	o.CompensatedSteps = append(o.CompensatedSteps, stepName)
+}
+
+func (o *Operation) IsStepCompensated(stepName string) bool {
+	for _, value := range o.CompensatedSteps {
+		if value == stepName {
+			return true
+		}
+	}
+	return false
+}
+
+// FailCompensation records the step which changes could not be reverted
+func (o *Operation) FailCompensation(stepName string) {
+	if o.IsCompensationFailed(stepName) {
+		return
+	}
+	o.FailedCompensations = append(o.FailedCompensations, stepName)
+}
+
+func (o *Operation) IsCompensationFailed(stepName string) bool {
+	for _, value := range o.FailedCompensations {
+		if value == stepName {
+			return true
+		}
+	}
+	return false
+}
+
+// PendingCompensations returns names of steps which are neither compensated nor failed to compensate yet, in the reverse order of execution
+func (o *Operation) PendingCompensations() []string {
+	var pending []string
+	for i := len(o.CompensableSteps) - 1; i >= 0; i-- {
+		if !o.IsStepCompensated(o.CompensableSteps[i]) && !o.IsCompensationFailed(o.CompensableSteps[i]) {
+			pending = append(pending, o.CompensableSteps[i])
+		}
+	}
+	return pending
+}
+
+func (o *Operation) SkipStep(stepName string) {
+	if o.IsStepSkipped(stepName) {
+		return
+	}
+	o.SkippedSteps = append(o.SkippedSteps, stepName)
+}
+
+func (o *Operation) IsStepSkipped(stepName string) bool {
+	for _, value := range o.SkippedSteps {
+		if value == stepName {
+			return true
+		}
+	}
+	return false
+}
+
 type ComponentConfigurationInputList []*gqlschema.ComponentConfigurationInput
 
 func (l ComponentConfigurationInputList) DeepCopy() []*gqlschema.ComponentConfigurationInput {
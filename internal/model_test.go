@@ -54,3 +54,35 @@ func countStageOccurrences(operation ProvisioningOperation, stage string) int {
 	}
 	return foundStages
 }
+
+func TestHibernationDTO_Validate(t *testing.T) {
+	t.Run("should accept the schedule in the timezone", func(t *testing.T) {
+		hibernation := HibernationDTO{Start: "0 20 * * 1-5", End: "0 7 * * 1-5", Timezone: "Europe/Berlin"}
+
+		assert.NoError(t, hibernation.Validate())
+	})
+
+	t.Run("should reject invalid cron expressions and timezone", func(t *testing.T) {
+		hibernation := HibernationDTO{Start: "at 8 pm", End: "0 7 * *", Timezone: "Europe/Nowhere"}
+
+		err := hibernation.Validate()
+
+		assert.ErrorContains(t, err, "start must be a valid cron expression")
+		assert.ErrorContains(t, err, "end must be a valid cron expression")
+		assert.ErrorContains(t, err, "timezone must be a valid IANA time zone")
+	})
+}
+
+func TestHibernationDTO_Schedule(t *testing.T) {
+	t.Run("should return the schedule", func(t *testing.T) {
+		hibernation := &HibernationDTO{Start: "0 20 * * 1-5", End: "0 7 * * 1-5"}
+
+		assert.Equal(t, hibernation, hibernation.Schedule())
+	})
+
+	t.Run("should remove the empty schedule", func(t *testing.T) {
+		hibernation := &HibernationDTO{Timezone: "Europe/Berlin"}
+
+		assert.Nil(t, hibernation.Schedule())
+	})
+}
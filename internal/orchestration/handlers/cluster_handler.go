@@ -73,6 +73,22 @@ func (h *clusterHandler) createOrchestration(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// validate `strategy` field
+	err = ValidateStrategyParameter(&params)
+	if err != nil {
+		h.log.Errorf("while validating strategy: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while validating strategy: %w", err))
+		return
+	}
+
+	// validate `failureThreshold` field
+	err = ValidateFailureThresholdParameter(&params)
+	if err != nil {
+		h.log.Errorf("while validating failure threshold: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while validating failure threshold: %w", err))
+		return
+	}
+
 	now := time.Now()
 	o := internal.Orchestration{
 		OrchestrationID: uuid.New().String(),
@@ -56,6 +56,78 @@ func TestClusterHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEmpty(t, out.OrchestrationID)
 	})
+
+	t.Run("upgrade with invalid canary strategy", func(t *testing.T) {
+		// given
+		handler := fixClusterHandler(t)
+
+		params := orchestration.Parameters{
+			Targets: orchestration.TargetSpec{
+				Include: []orchestration.RuntimeTarget{
+					{
+						RuntimeID: "test",
+					},
+				},
+			},
+			Strategy: orchestration.StrategySpec{
+				Type:     orchestration.CanaryStrategy,
+				Schedule: "now",
+				Canary: orchestration.CanaryStrategySpec{
+					FirstWave:  1,
+					SoakPeriod: "one hour",
+				},
+			},
+		}
+		p, err := json.Marshal(&params)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/upgrade/cluster", bytes.NewBuffer(p))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		handler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("upgrade with invalid target version constraint", func(t *testing.T) {
+		// given
+		handler := fixClusterHandler(t)
+
+		params := orchestration.Parameters{
+			Targets: orchestration.TargetSpec{
+				Include: []orchestration.RuntimeTarget{
+					{
+						KubernetesVersion: "1.25 or newer",
+					},
+				},
+			},
+			Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: "now",
+			},
+		}
+		p, err := json.Marshal(&params)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/upgrade/cluster", bytes.NewBuffer(p))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		handler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 func fixClusterHandler(t *testing.T) *clusterHandler {
@@ -57,6 +57,7 @@ func (c *Converter) UpgradeKymaOperationToDTO(op internal.UpgradeKymaOperation)
 		MaintenanceWindowEnd:   op.MaintenanceWindowEnd,
 		State:                  string(op.Operation.State),
 		Description:            op.Operation.Description,
+		MatchReasons:           dryRunMatchReasons(op.RuntimeOperation),
 	}, nil
 }
 
@@ -104,6 +105,7 @@ func (c *Converter) UpgradeClusterOperationToDTO(op internal.UpgradeClusterOpera
 		MaintenanceWindowEnd:   op.MaintenanceWindowEnd,
 		State:                  string(op.Operation.State),
 		Description:            op.Operation.Description,
+		MatchReasons:           dryRunMatchReasons(op.RuntimeOperation),
 	}, nil
 }
 
@@ -135,3 +137,11 @@ func (c *Converter) UpgradeClusterOperationToDetailDTO(op internal.UpgradeCluste
 		ClusterConfig:     clusterConfig,
 	}, nil
 }
+
+// dryRunMatchReasons returns the reasons why the runtime was selected by the orchestration targets, only dry run operations report them
+func dryRunMatchReasons(op orchestration.RuntimeOperation) []string {
+	if !op.DryRun {
+		return nil
+	}
+	return op.MatchReasons
+}
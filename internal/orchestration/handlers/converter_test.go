@@ -59,6 +59,24 @@ func TestConverter_UpgradeKymaOperationToDTO(t *testing.T) {
 	// then
 	require.NoError(t, err)
 	assert.Equal(t, id, resp.OrchestrationID)
+	assert.Empty(t, resp.MatchReasons)
+}
+
+func TestConverter_UpgradeKymaOperationToDTO_DryRun(t *testing.T) {
+	// given
+	c := handlers.Converter{}
+
+	givenOperation := fixUpgradeKymaOperation("id")
+	givenOperation.RuntimeOperation.DryRun = true
+	givenOperation.RuntimeOperation.MatchReasons = []string{"plan is azure"}
+
+	// when
+	resp, err := c.UpgradeKymaOperationToDTO(givenOperation)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"plan is azure"}, resp.MatchReasons)
 }
 
 func TestConverter_UpgradeKymaOperationListToDTO(t *testing.T) {
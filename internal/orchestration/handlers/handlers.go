@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration/strategies"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/pkg/errors"
@@ -40,6 +41,11 @@ func validateTarget(spec orchestration.TargetSpec) error {
 	if spec.Include == nil || len(spec.Include) == 0 {
 		return errors.New("targets.include array must be not empty")
 	}
+	for _, rt := range append(spec.Include, spec.Exclude...) {
+		if err := orchestration.ValidateRuntimeTarget(rt); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -51,6 +57,40 @@ func ValidateDeprecatedParameters(params orchestration.Parameters) error {
 	return nil
 }
 
+// ValidateStrategyParameter checks if the strategy parameters are valid.
+func ValidateStrategyParameter(params *orchestration.Parameters) error {
+	if params.Strategy.Type != orchestration.CanaryStrategy {
+		return nil
+	}
+	if _, err := strategies.NewCanaryPlan(params.Strategy.Canary); err != nil {
+		return err
+	}
+	if params.Strategy.Parallel.Workers == 0 {
+		params.Strategy.Parallel.Workers = 1
+	}
+	return nil
+}
+
+// ValidateFailureThresholdParameter checks if the failure threshold is valid.
+func ValidateFailureThresholdParameter(params *orchestration.Parameters) error {
+	threshold := params.FailureThreshold
+	if threshold == nil {
+		return nil
+	}
+	if threshold.Count < 0 {
+		return fmt.Errorf("failureThreshold count must not be negative")
+	}
+	if threshold.Percentage < 0 || threshold.Percentage > 100 {
+		return fmt.Errorf("failureThreshold percentage must be between 0 and 100")
+	}
+	if threshold.Count == 0 && threshold.Percentage == 0 {
+		return fmt.Errorf("failureThreshold count or percentage must be set")
+	}
+	// ignored failures are set by KEB when the orchestration is resumed
+	threshold.IgnoredFailures = 0
+	return nil
+}
+
 // ValidateScheduleParameter cheks if the schedule parameter is valid.
 func ValidateScheduleParameter(params *orchestration.Parameters) error {
 	switch params.Strategy.Schedule {
@@ -31,6 +31,7 @@ type orchestrationHandler struct {
 	log       logrus.FieldLogger
 
 	canceler       *Canceler
+	resumer        *Resumer
 	kymaRetryer    *kymaRetryer
 	clusterRetryer *clusterRetryer
 
@@ -53,6 +54,7 @@ func NewOrchestrationStatusHandler(operations storage.Operations,
 		defaultMaxPage: defaultMaxPage,
 		converter:      Converter{},
 		canceler:       NewCanceler(orchestrations, log),
+		resumer:        NewResumer(orchestrations, operations, kymaQueue, clusterQueue, log),
 		kymaRetryer:    NewKymaRetryer(orchestrations, operations, kymaQueue, log),
 		clusterRetryer: NewClusterRetryer(orchestrations, operations, clusterQueue, log),
 	}
@@ -62,6 +64,7 @@ func (h *orchestrationHandler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/orchestrations", h.listOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}", h.getOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/cancel", h.cancelOrchestrationByID).Methods(http.MethodPut)
+	router.HandleFunc("/orchestrations/{orchestration_id}/resume", h.resumeOrchestrationByID).Methods(http.MethodPut)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations", h.listOperations).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations/{operation_id}", h.getOperation).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/retry", h.retryOrchestrationByID).Methods(http.MethodPost)
@@ -97,13 +100,44 @@ func (h *orchestrationHandler) getOrchestration(w http.ResponseWriter, r *http.R
 func (h *orchestrationHandler) cancelOrchestrationByID(w http.ResponseWriter, r *http.Request) {
 	orchestrationID := mux.Vars(r)["orchestration_id"]
 
-	err := h.canceler.CancelForID(orchestrationID)
+	o, err := h.orchestrations.GetByID(orchestrationID)
 	if err != nil {
 		h.log.Errorf("while canceling orchestration %s: %v", orchestrationID, err)
 		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), fmt.Errorf("while canceling orchestration %s: %w", orchestrationID, err))
 		return
 	}
 
+	err = h.canceler.CancelForID(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while canceling orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), fmt.Errorf("while canceling orchestration %s: %w", orchestrationID, err))
+		return
+	}
+
+	// paused orchestration is not processed, it has to be queued to cancel its pending operations
+	if o.State == commonOrchestration.Paused {
+		err = h.resumer.enqueue(o)
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	response := commonOrchestration.UpgradeResponse{OrchestrationID: orchestrationID}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func (h *orchestrationHandler) resumeOrchestrationByID(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+
+	err := h.resumer.ResumeForID(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while resuming orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), fmt.Errorf("while resuming orchestration %s: %w", orchestrationID, err))
+		return
+	}
+
 	response := commonOrchestration.UpgradeResponse{OrchestrationID: orchestrationID}
 
 	httputil.WriteResponse(w, http.StatusOK, response)
@@ -187,10 +221,16 @@ func (h *orchestrationHandler) listOrchestration(w http.ResponseWriter, r *http.
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
 		return
 	}
+	cursor, err := pagination.ExtractCursorFromRequest(r)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
+		return
+	}
 	query := r.URL.Query()
 	filter := dbmodel.OrchestrationFilter{
 		Page:     page,
 		PageSize: pageSize,
+		Cursor:   cursor,
 		// For optional filters, zero value (nil) is ok if not supplied
 		States: query[commonOrchestration.StateParam],
 	}
@@ -208,6 +248,10 @@ func (h *orchestrationHandler) listOrchestration(w http.ResponseWriter, r *http.
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while converting orchestrations: %w", err))
 		return
 	}
+	if len(orchestrations) > 0 {
+		last := orchestrations[len(orchestrations)-1]
+		response.NextCursor = pagination.NextCursor(pageSize, len(orchestrations), last.CreatedAt, last.OrchestrationID)
+	}
 
 	httputil.WriteResponse(w, http.StatusOK, response)
 }
@@ -219,10 +263,16 @@ func (h *orchestrationHandler) listOperations(w http.ResponseWriter, r *http.Req
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
 		return
 	}
+	cursor, err := pagination.ExtractCursorFromRequest(r)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
+		return
+	}
 	query := r.URL.Query()
 	filter := dbmodel.OperationFilter{
 		Page:     page,
 		PageSize: pageSize,
+		Cursor:   cursor,
 		// For optional filters, zero value (nil) is ok if not supplied
 		States: query[commonOrchestration.StateParam],
 	}
@@ -249,6 +299,10 @@ func (h *orchestrationHandler) listOperations(w http.ResponseWriter, r *http.Req
 			httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while converting operations: %w", err))
 			return
 		}
+		if len(operations) > 0 {
+			last := operations[len(operations)-1]
+			response.NextCursor = pagination.NextCursor(pageSize, len(operations), last.CreatedAt, last.Operation.ID)
+		}
 
 	case commonOrchestration.UpgradeClusterOrchestration:
 		operations, count, totalCount, err := h.operations.ListUpgradeClusterOperationsByOrchestrationID(orchestrationID, filter)
@@ -263,6 +317,10 @@ func (h *orchestrationHandler) listOperations(w http.ResponseWriter, r *http.Req
 			httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while converting operations: %w", err))
 			return
 		}
+		if len(operations) > 0 {
+			last := operations[len(operations)-1]
+			response.NextCursor = pagination.NextCursor(pageSize, len(operations), last.CreatedAt, last.Operation.ID)
+		}
 
 	default:
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("unsupported orchestration type: %s", o.Type))
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	orchestrationExt "github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type Resumer struct {
+	orchestrations storage.Orchestrations
+	operations     storage.Operations
+	kymaQueue      *process.Queue
+	clusterQueue   *process.Queue
+	log            logrus.FieldLogger
+}
+
+func NewResumer(orchestrations storage.Orchestrations, operations storage.Operations, kymaQueue, clusterQueue *process.Queue, logger logrus.FieldLogger) *Resumer {
+	return &Resumer{
+		orchestrations: orchestrations,
+		operations:     operations,
+		kymaQueue:      kymaQueue,
+		clusterQueue:   clusterQueue,
+		log:            logger,
+	}
+}
+
+// ResumeForID resumes the paused orchestration by ID, the operations failed before the resume are not counted to the failure threshold
+func (r *Resumer) ResumeForID(orchestrationID string) error {
+	o, err := r.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		return fmt.Errorf("while getting orchestration: %w", err)
+	}
+	if o.State != orchestrationExt.Paused {
+		return apiErrors.NewBadRequest(fmt.Sprintf("orchestration is in %s state, only paused orchestration can be resumed", o.State))
+	}
+
+	stats, err := r.operations.GetOperationStatsForOrchestration(orchestrationID)
+	if err != nil {
+		return fmt.Errorf("while getting operation statistics: %w", err)
+	}
+	if o.Parameters.FailureThreshold != nil {
+		o.Parameters.FailureThreshold.IgnoredFailures = stats[orchestrationExt.Failed]
+	}
+
+	o.UpdatedAt = time.Now()
+	o.Description = "Orchestration was resumed"
+	o.State = orchestrationExt.InProgress
+	err = r.orchestrations.Update(*o)
+	if err != nil {
+		return fmt.Errorf("while updating orchestration: %w", err)
+	}
+
+	r.log.Infof("Resuming orchestration %s", orchestrationID)
+	return r.enqueue(o)
+}
+
+// enqueue adds the orchestration to the queue of its type
+func (r *Resumer) enqueue(o *internal.Orchestration) error {
+	switch o.Type {
+	case orchestrationExt.UpgradeKymaOrchestration:
+		r.kymaQueue.Add(o.OrchestrationID)
+	case orchestrationExt.UpgradeClusterOrchestration:
+		r.clusterQueue.Add(o.OrchestrationID)
+	default:
+		return fmt.Errorf("unsupported orchestration type: %s", o.Type)
+	}
+	return nil
+}
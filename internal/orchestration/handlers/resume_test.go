@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestResumer_ResumeForID(t *testing.T) {
+	t.Run("should resume paused orchestration", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.Type = orchestration.UpgradeKymaOrchestration
+		o.State = orchestration.Paused
+		o.Parameters.FailureThreshold = &orchestration.FailureThresholdParameters{Count: 1}
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+		err = s.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:              "op-id",
+				InstanceID:      "instance-id",
+				OrchestrationID: fixOrchestrationID,
+				State:           orchestration.Failed,
+				Type:            internal.OperationTypeUpgradeKyma,
+			},
+		})
+		require.NoError(t, err)
+
+		r := fixResumer(s)
+
+		err = r.ResumeForID(fixOrchestrationID)
+		require.NoError(t, err)
+
+		resumed, err := s.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.InProgress, resumed.State)
+		assert.Equal(t, 1, resumed.Parameters.FailureThreshold.IgnoredFailures)
+	})
+	t.Run("should not resume orchestration which is not paused", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		err := s.Orchestrations().Insert(fixOrchestration())
+		require.NoError(t, err)
+
+		r := fixResumer(s)
+
+		err = r.ResumeForID(fixOrchestrationID)
+		assert.True(t, apiErrors.IsBadRequest(err))
+	})
+	t.Run("should return error when orchestration not found", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		r := fixResumer(s)
+
+		err := r.ResumeForID(fixOrchestrationID)
+		assert.Error(t, err)
+	})
+}
+
+func fixResumer(s storage.BrokerStorage) *Resumer {
+	logs := logrus.New()
+	return NewResumer(s.Orchestrations(), s.Operations(), process.NewQueue(&testExecutor{}, logs), process.NewQueue(&testExecutor{}, logs), logs)
+}
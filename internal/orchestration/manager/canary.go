@@ -0,0 +1,232 @@
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration/strategies"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/sirupsen/logrus"
+)
+
+// executeCanary executes operations in growing waves. After every wave and its soak period the results of the wave are
+// evaluated, the orchestration is halted if the success threshold is not reached. The wave state is stored in the orchestration,
+// so the processing continues at the right wave after restart or resume. The failure threshold, pause and cancel are applied
+// the same way as for the other strategies.
+func (m *orchestrationManager) executeCanary(o *internal.Orchestration, operations []orchestration.RuntimeOperation, strategy orchestration.Strategy, log logrus.FieldLogger) (*internal.Orchestration, error) {
+	plan, err := strategies.NewCanaryPlan(o.Parameters.Strategy.Canary)
+	if err != nil {
+		return nil, fmt.Errorf("while creating canary plan: %w", err)
+	}
+	if o.Canary == nil {
+		o.Canary = &internal.CanaryState{Total: len(operations)}
+	}
+	state := o.Canary
+
+	// operations are scheduled in a stable order, so the waves are the same after restart
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].ID < operations[j].ID
+	})
+	currentWave := map[string]struct{}{}
+	for _, id := range state.OperationIDs {
+		currentWave[id] = struct{}{}
+	}
+	var waveOperations, pending []orchestration.RuntimeOperation
+	for _, op := range operations {
+		if _, found := currentWave[op.ID]; found {
+			waveOperations = append(waveOperations, op)
+		} else {
+			pending = append(pending, op)
+		}
+	}
+
+	for {
+		if len(state.OperationIDs) == 0 {
+			if len(pending) == 0 {
+				break
+			}
+			size := plan.WaveSize(state.Wave+1, state.Total)
+			if size > len(pending) {
+				size = len(pending)
+			}
+			waveOperations, pending = pending[:size], pending[size:]
+			state.Wave++
+			state.SoakUntil = time.Time{}
+			for _, op := range waveOperations {
+				state.OperationIDs = append(state.OperationIDs, op.ID)
+			}
+			o.Description = fmt.Sprintf("Canary wave %d: scheduled %d operations, %d of %d operations done", state.Wave, size, state.Done, state.Total)
+			if err := m.updateCanaryState(o); err != nil {
+				return nil, err
+			}
+			log.Infof("Starting canary wave %d with %d operations", state.Wave, size)
+		}
+
+		if state.SoakUntil.IsZero() {
+			execID, err := strategy.Execute(waveOperations, o.Parameters.Strategy)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute strategy for canary wave %d: %w", state.Wave, err)
+			}
+			interrupted, err := m.waitForWave(o, log)
+			if err != nil {
+				return nil, fmt.Errorf("while waiting for canary wave %d to finish: %w", state.Wave, err)
+			}
+			if interrupted {
+				return m.resolveOrchestration(o, strategy, []string{execID}, nil)
+			}
+			// the last wave is not soaked, there is nothing to protect
+			state.SoakUntil = time.Now()
+			if len(pending) > 0 {
+				state.SoakUntil = state.SoakUntil.Add(plan.SoakPeriod())
+			}
+			if err := m.updateCanaryState(o); err != nil {
+				return nil, err
+			}
+		}
+
+		interrupted, err := m.waitForSoak(o, state.SoakUntil, log)
+		if err != nil {
+			return nil, fmt.Errorf("while soaking canary wave %d: %w", state.Wave, err)
+		}
+		if interrupted {
+			return m.resolveOrchestration(o, strategy, nil, nil)
+		}
+
+		succeeded, err := m.countSucceeded(state.OperationIDs)
+		if err != nil {
+			return nil, fmt.Errorf("while evaluating canary wave %d: %w", state.Wave, err)
+		}
+		if !plan.Passed(succeeded, len(state.OperationIDs)) {
+			log.Warnf("Halting canary orchestration, wave %d: %d of %d operations succeeded", state.Wave, succeeded, len(state.OperationIDs))
+			err := m.factory.CancelOperations(o.OrchestrationID)
+			if err != nil {
+				return nil, fmt.Errorf("while canceling operations of halted orchestration: %w", err)
+			}
+			o.State = orchestration.Failed
+			o.Description = fmt.Sprintf("Canary wave %d halted: %d of %d operations succeeded, success threshold is %d%%",
+				state.Wave, succeeded, len(state.OperationIDs), plan.SuccessThreshold())
+			return o, nil
+		}
+
+		state.Done += len(state.OperationIDs)
+		state.OperationIDs = nil
+		state.SoakUntil = time.Time{}
+		waveOperations = nil
+	}
+
+	stats, err := m.operationStorage.GetOperationStatsForOrchestration(o.OrchestrationID)
+	if err != nil {
+		return nil, fmt.Errorf("while getting operation stats: %w", err)
+	}
+	o.Description = fmt.Sprintf("Canary finished after %d waves, %d of %d operations done", state.Wave, state.Done, state.Total)
+	return m.resolveOrchestration(o, strategy, nil, stats)
+}
+
+func (m *orchestrationManager) updateCanaryState(o *internal.Orchestration) error {
+	o.UpdatedAt = time.Now()
+	err := m.orchestrationStorage.Update(*o)
+	if err != nil {
+		return fmt.Errorf("while updating canary state of orchestration: %w", err)
+	}
+	return nil
+}
+
+// waitForWave waits until all operations of the current wave are finished. The orchestration is paused when the failure threshold
+// is crossed, returns true if the orchestration was canceled or paused, the pending operations are not awaited then.
+func (m *orchestrationManager) waitForWave(o *internal.Orchestration, log logrus.FieldLogger) (bool, error) {
+	interrupted := false
+	err := wait.PollImmediateInfinite(m.pollingInterval, func() (bool, error) {
+		var err error
+		interrupted, err = m.isInterrupted(o, log)
+		if err != nil {
+			return false, err
+		}
+		if !interrupted {
+			stats, err := m.operationStorage.GetOperationStatsForOrchestration(o.OrchestrationID)
+			if err != nil {
+				log.Errorf("while getting operations: %v", err)
+				return false, nil
+			}
+			interrupted, err = m.pauseOnFailureThreshold(o, stats, log)
+			if err != nil {
+				log.Errorf("%v", err)
+				return false, nil
+			}
+		}
+
+		inProgress, notFinished := 0, 0
+		for _, id := range o.Canary.OperationIDs {
+			op, err := m.operationStorage.GetOperationByID(id)
+			if err != nil {
+				log.Errorf("while getting operation %s: %v", id, err)
+				return false, nil
+			}
+			switch string(op.State) {
+			case orchestration.InProgress:
+				inProgress++
+				notFinished++
+			case orchestration.Pending, orchestration.Retrying:
+				notFinished++
+			}
+		}
+
+		if interrupted {
+			return inProgress == 0, nil
+		}
+		return notFinished == 0, nil
+	})
+	return interrupted, err
+}
+
+// waitForSoak waits until the end of the soak period, returns true if the orchestration was canceled or paused
+func (m *orchestrationManager) waitForSoak(o *internal.Orchestration, until time.Time, log logrus.FieldLogger) (bool, error) {
+	interrupted := false
+	err := wait.PollImmediateInfinite(m.pollingInterval, func() (bool, error) {
+		var err error
+		interrupted, err = m.isInterrupted(o, log)
+		if err != nil {
+			return false, err
+		}
+		return interrupted || !time.Now().Before(until), nil
+	})
+	return interrupted, err
+}
+
+// isInterrupted returns true if the orchestration was canceled or paused in the meantime, the state is then set on the given orchestration
+func (m *orchestrationManager) isInterrupted(o *internal.Orchestration, log logrus.FieldLogger) (bool, error) {
+	current, err := m.orchestrationStorage.GetByID(o.OrchestrationID)
+	switch {
+	case err == nil:
+		if current.State != orchestration.Canceling && current.State != orchestration.Paused {
+			return false, nil
+		}
+		o.State = current.State
+		o.Description = current.Description
+		return true, nil
+	case dberr.IsNotFound(err):
+		log.Errorf("while getting orchestration: %v", err)
+		return false, err
+	default:
+		log.Errorf("while getting orchestration: %v", err)
+		return false, nil
+	}
+}
+
+func (m *orchestrationManager) countSucceeded(operationIDs []string) (int, error) {
+	succeeded := 0
+	for _, id := range operationIDs {
+		op, err := m.operationStorage.GetOperationByID(id)
+		if err != nil {
+			return 0, fmt.Errorf("while getting operation %s: %w", id, err)
+		}
+		if string(op.State) == orchestration.Succeeded {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
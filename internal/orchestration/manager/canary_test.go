@@ -0,0 +1,220 @@
+package manager_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration/automock"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	notificationAutomock "github.com/kyma-project/kyma-environment-broker/internal/notification/mocks"
+	internalOrchestration "github.com/kyma-project/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal/orchestration/manager"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpgradeKymaManager_ExecuteCanary(t *testing.T) {
+	orchestrationConfig := internalOrchestration.Config{
+		KymaVersion:       defaultKymaVersion,
+		KubernetesVersion: "1.22",
+		Namespace:         "default",
+		Name:              "policyConfig",
+	}
+
+	t.Run("should execute operations in growing waves", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		id := "id"
+		fixCanaryOrchestration(t, store, id, orchestration.CanaryStrategySpec{FirstWave: 1}, nil)
+		fixPendingUpgradeKymaOperations(t, store, id, 5)
+		executor := &stateTestExecutor{store: store, failed: map[string]bool{}}
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor,
+			&automock.RuntimeResolver{}, poolingInterval, logrus.New(), fake.NewFakeClient(), &orchestrationConfig, &notificationAutomock.BundleBuilder{}, 1000)
+
+		// when
+		_, err := svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+		require.NotNil(t, o.Canary)
+		assert.Equal(t, 3, o.Canary.Wave)
+		assert.Equal(t, 5, o.Canary.Done)
+		assert.Equal(t, []string{"op-0", "op-1", "op-2", "op-3", "op-4"}, executor.executed)
+	})
+
+	t.Run("should halt when the success threshold is not reached", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		id := "id"
+		fixCanaryOrchestration(t, store, id, orchestration.CanaryStrategySpec{FirstWave: 2, SuccessThreshold: 60}, nil)
+		fixPendingUpgradeKymaOperations(t, store, id, 5)
+		executor := &stateTestExecutor{store: store, failed: map[string]bool{"op-1": true}}
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor,
+			&automock.RuntimeResolver{}, poolingInterval, logrus.New(), fake.NewFakeClient(), &orchestrationConfig, &notificationAutomock.BundleBuilder{}, 1000)
+
+		// when
+		_, err := svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, o.State)
+		assert.Contains(t, o.Description, "Canary wave 1 halted")
+		assert.Equal(t, []string{"op-0", "op-1"}, executor.executed)
+		for _, opID := range []string{"op-2", "op-3", "op-4"} {
+			op, err := store.Operations().GetUpgradeKymaOperationByID(opID)
+			require.NoError(t, err)
+			assert.Equal(t, orchestration.Canceled, string(op.State))
+		}
+	})
+
+	t.Run("should pause when the failure threshold is crossed", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		id := "id"
+		fixCanaryOrchestration(t, store, id, orchestration.CanaryStrategySpec{FirstWave: 2}, nil)
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		o.Parameters.FailureThreshold = &orchestration.FailureThresholdParameters{Count: 1}
+		require.NoError(t, store.Orchestrations().Update(*o))
+		fixPendingUpgradeKymaOperations(t, store, id, 5)
+		executor := &stateTestExecutor{store: store, failed: map[string]bool{"op-1": true}}
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor,
+			&automock.RuntimeResolver{}, poolingInterval, logrus.New(), fake.NewFakeClient(), &orchestrationConfig, &notificationAutomock.BundleBuilder{}, 1000)
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err = store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Paused, o.State)
+		assert.Equal(t, "Orchestration was paused, 1 of 5 operations failed", o.Description)
+		require.NotNil(t, o.Canary)
+		assert.Equal(t, 1, o.Canary.Wave)
+		assert.Equal(t, []string{"op-0", "op-1"}, executor.executed)
+		for _, opID := range []string{"op-2", "op-3", "op-4"} {
+			op, err := store.Operations().GetUpgradeKymaOperationByID(opID)
+			require.NoError(t, err)
+			assert.Equal(t, orchestration.Pending, string(op.State))
+		}
+	})
+
+	t.Run("should continue at the stored wave after restart", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		id := "id"
+		fixPendingUpgradeKymaOperations(t, store, id, 4)
+		op, err := store.Operations().GetUpgradeKymaOperationByID("op-0")
+		require.NoError(t, err)
+		op.State = orchestration.Succeeded
+		_, err = store.Operations().UpdateUpgradeKymaOperation(*op)
+		require.NoError(t, err)
+		fixCanaryOrchestration(t, store, id, orchestration.CanaryStrategySpec{FirstWave: 1}, &internal.CanaryState{
+			Wave:         1,
+			Total:        4,
+			OperationIDs: []string{"op-0"},
+			SoakUntil:    time.Now().Add(-time.Minute),
+		})
+		executor := &stateTestExecutor{store: store, failed: map[string]bool{}}
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor,
+			&automock.RuntimeResolver{}, poolingInterval, logrus.New(), fake.NewFakeClient(), &orchestrationConfig, &notificationAutomock.BundleBuilder{}, 1000)
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+		assert.Equal(t, 3, o.Canary.Wave)
+		assert.Equal(t, 4, o.Canary.Done)
+		assert.Equal(t, []string{"op-1", "op-2", "op-3"}, executor.executed)
+	})
+}
+
+func fixCanaryOrchestration(t *testing.T, store storage.BrokerStorage, id string, spec orchestration.CanaryStrategySpec, state *internal.CanaryState) {
+	err := store.Orchestrations().Insert(internal.Orchestration{
+		OrchestrationID: id,
+		State:           orchestration.InProgress,
+		Type:            orchestration.UpgradeKymaOrchestration,
+		Parameters: orchestration.Parameters{
+			Strategy: orchestration.StrategySpec{
+				Type:     orchestration.CanaryStrategy,
+				Schedule: time.Now().Format(time.RFC3339),
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+				Canary:   spec,
+			},
+		},
+		Canary: state,
+	})
+	require.NoError(t, err)
+}
+
+func fixPendingUpgradeKymaOperations(t *testing.T, store storage.BrokerStorage, orchestrationID string, count int) {
+	for i := 0; i < count; i++ {
+		opID := fmt.Sprintf("op-%d", i)
+		err := store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:              opID,
+				InstanceID:      opID,
+				OrchestrationID: orchestrationID,
+				State:           orchestration.Pending,
+				Type:            internal.OperationTypeUpgradeKyma,
+				RuntimeOperation: orchestration.RuntimeOperation{
+					ID:      opID,
+					Runtime: orchestration.Runtime{RuntimeID: opID},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+}
+
+// stateTestExecutor finishes upgrade kyma operations with the succeeded or failed state
+type stateTestExecutor struct {
+	mux      sync.Mutex
+	store    storage.BrokerStorage
+	failed   map[string]bool
+	delay    time.Duration
+	executed []string
+}
+
+func (t *stateTestExecutor) Execute(opID string) (time.Duration, error) {
+	t.mux.Lock()
+	t.executed = append(t.executed, opID)
+	t.mux.Unlock()
+
+	op, err := t.store.Operations().GetUpgradeKymaOperationByID(opID)
+	if err != nil {
+		return 0, err
+	}
+	op.State = orchestration.Succeeded
+	if t.failed[opID] {
+		op.State = orchestration.Failed
+	}
+	_, err = t.store.Operations().UpdateUpgradeKymaOperation(*op)
+	time.Sleep(t.delay)
+	return 0, err
+}
+
+func (t *stateTestExecutor) Reschedule(operationID string, maintenanceWindowBegin, maintenanceWindowEnd time.Time) error {
+	return nil
+}
@@ -70,6 +70,11 @@ func (m *orchestrationManager) Execute(orchestrationID string) (time.Duration, e
 		}
 		return m.failOrchestration(o, fmt.Errorf("failed to get orchestration: %w", err))
 	}
+	// paused orchestration is processed again after resume
+	if o.State == orchestration.Paused {
+		m.log.Infof("Orchestration %s is paused", orchestrationID)
+		return 0, nil
+	}
 
 	operations, runtimeNums, err := m.waitForStart(o)
 	if err != nil {
@@ -83,6 +88,8 @@ func (m *orchestrationManager) Execute(orchestrationID string) (time.Duration, e
 		o.Parameters.Kubernetes = &orchestration.KubernetesParameters{KubernetesVersion: m.kubernetesVersion}
 	}
 
+	// retried operations are executed at once, waves apply only to the scheduled operations
+	canary := o.Parameters.Strategy.Type == orchestration.CanaryStrategy && o.State != orchestration.Retrying
 	if o.State == orchestration.Pending || o.State == orchestration.Retrying {
 		if runtimeNums != 0 {
 			o.State = orchestration.InProgress
@@ -102,14 +109,24 @@ func (m *orchestrationManager) Execute(orchestrationID string) (time.Duration, e
 		return 0, nil
 	}
 
-	strategy := m.resolveStrategy(o.Parameters.Strategy.Type, m.executor, logger)
-
-	execID, err := strategy.Execute(operations, o.Parameters.Strategy)
-	if err != nil {
-		return 0, fmt.Errorf("failed to execute strategy: %w", err)
+	executor := &pausableExecutor{
+		OperationExecutor:    m.executor,
+		orchestrationID:      o.OrchestrationID,
+		orchestrationStorage: m.orchestrationStorage,
+		operationStorage:     m.operationStorage,
 	}
+	strategy := m.resolveStrategy(o.Parameters.Strategy.Type, executor, logger)
 
-	o, err = m.waitForCompletion(o, strategy, execID, logger)
+	if canary {
+		o, err = m.executeCanary(o, operations, strategy, logger)
+	} else {
+		execID, err := strategy.Execute(operations, o.Parameters.Strategy)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute strategy: %w", err)
+		}
+
+		o, err = m.waitForCompletion(o, strategy, execID, logger)
+	}
 	if err != nil && kebError.IsTemporaryError(err) {
 		return 5 * time.Second, nil
 	} else if err != nil {
@@ -317,7 +334,8 @@ func (m *orchestrationManager) resolveOperations(o *internal.Orchestration, poli
 
 func (m *orchestrationManager) resolveStrategy(sType orchestration.StrategyType, executor orchestration.OperationExecutor, log logrus.FieldLogger) orchestration.Strategy {
 	switch sType {
-	case orchestration.ParallelStrategy:
+	// every wave of the canary strategy is executed in parallel
+	case orchestration.ParallelStrategy, orchestration.CanaryStrategy:
 		s := strategies.NewParallelOrchestrationStrategy(executor, log, 0)
 		if m.speedFactor != 0 {
 			s.SpeedUp(m.speedFactor)
@@ -331,6 +349,7 @@ func (m *orchestrationManager) resolveStrategy(sType orchestration.StrategyType,
 func (m *orchestrationManager) waitForCompletion(o *internal.Orchestration, strategy orchestration.Strategy, execID string, log logrus.FieldLogger) (*internal.Orchestration, error) {
 	orchestrationID := o.OrchestrationID
 	canceled := false
+	paused := false
 	var err error
 	var stats map[string]int
 	execIDs := []string{execID}
@@ -344,6 +363,7 @@ func (m *orchestrationManager) waitForCompletion(o *internal.Orchestration, stra
 				log.Info("Orchestration was canceled")
 				canceled = true
 			}
+			paused = o.State == orchestration.Paused
 		case dberr.IsNotFound(err):
 			log.Errorf("while getting orchestration: %v", err)
 			return false, err
@@ -405,8 +425,16 @@ func (m *orchestrationManager) waitForCompletion(o *internal.Orchestration, stra
 			m.log.Infof("PollImmediateInfinite() while resuming %d operations for orchestration %s", len(result), o.OrchestrationID)
 		}
 
-		// don't wait for pending operations if orchestration was canceled
-		if canceled {
+		if !canceled && !paused {
+			paused, err = m.pauseOnFailureThreshold(o, stats, log)
+			if err != nil {
+				log.Errorf("%v", err)
+				return false, nil
+			}
+		}
+
+		// don't wait for pending operations if orchestration was canceled or paused
+		if canceled || paused {
 			return numberOfInProgress == 0, nil
 		} else {
 			return numberOfNotFinished == 0, nil
@@ -419,6 +447,24 @@ func (m *orchestrationManager) waitForCompletion(o *internal.Orchestration, stra
 	return m.resolveOrchestration(o, strategy, execIDs, stats)
 }
 
+// pauseOnFailureThreshold pauses the orchestration when the failed operations cross its failure threshold, returns true if the orchestration was paused
+func (m *orchestrationManager) pauseOnFailureThreshold(o *internal.Orchestration, stats map[string]int, log logrus.FieldLogger) (bool, error) {
+	if !o.Parameters.FailureThreshold.Crossed(stats[orchestration.Failed], totalOperations(stats)) {
+		return false, nil
+	}
+	log.Warnf("Pausing orchestration, %d operations failed", stats[orchestration.Failed])
+	paused := *o
+	paused.State = orchestration.Paused
+	paused.Description = fmt.Sprintf("Orchestration was paused, %d of %d operations failed", stats[orchestration.Failed], totalOperations(stats))
+	paused.UpdatedAt = time.Now()
+	err := m.orchestrationStorage.Update(paused)
+	if err != nil {
+		return false, fmt.Errorf("while pausing orchestration: %w", err)
+	}
+	*o = paused
+	return true, nil
+}
+
 func (m *orchestrationManager) resolveOrchestration(o *internal.Orchestration, strategy orchestration.Strategy, execIDs []string, stats map[string]int) (*internal.Orchestration, error) {
 	if o.State == orchestration.Canceling {
 		err := m.factory.CancelOperations(o.OrchestrationID)
@@ -449,6 +495,11 @@ func (m *orchestrationManager) resolveOrchestration(o *internal.Orchestration, s
 			}
 		}
 		o.State = orchestration.Canceled
+	} else if o.State == orchestration.Paused {
+		// pending operations stay pending in the storage and are scheduled again after resume
+		for _, execID := range execIDs {
+			strategy.Cancel(execID)
+		}
 	} else {
 		state := orchestration.Succeeded
 		if stats[orchestration.Failed] > 0 {
@@ -651,6 +702,14 @@ func (m *orchestrationManager) sendNotificationCancel(o *internal.Orchestration,
 	return nil
 }
 
+func totalOperations(stats map[string]int) int {
+	total := 0
+	for _, count := range stats {
+		total += count
+	}
+	return total
+}
+
 func updateRetryingDescription(desc string, newDesc string) string {
 	if strings.Contains(desc, "retrying") {
 		return strings.Replace(desc, "retrying", newDesc, -1)
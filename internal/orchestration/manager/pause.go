@@ -0,0 +1,28 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+)
+
+// pausableExecutor does not start pending operations of a paused or canceling orchestration,
+// the operations stay pending and are scheduled again when the orchestration is resumed.
+type pausableExecutor struct {
+	orchestration.OperationExecutor
+	orchestrationID      string
+	orchestrationStorage storage.Orchestrations
+	operationStorage     storage.Operations
+}
+
+func (e *pausableExecutor) Execute(operationID string) (time.Duration, error) {
+	o, err := e.orchestrationStorage.GetByID(e.orchestrationID)
+	if err == nil && (o.State == orchestration.Paused || o.State == orchestration.Canceling) {
+		op, err := e.operationStorage.GetOperationByID(operationID)
+		if err == nil && string(op.State) == orchestration.Pending {
+			return 0, nil
+		}
+	}
+	return e.OperationExecutor.Execute(operationID)
+}
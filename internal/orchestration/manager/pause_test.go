@@ -0,0 +1,105 @@
+package manager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration/automock"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	notificationAutomock "github.com/kyma-project/kyma-environment-broker/internal/notification/mocks"
+	internalOrchestration "github.com/kyma-project/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/internal/orchestration/manager"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpgradeKymaManager_ExecuteWithFailureThreshold(t *testing.T) {
+	orchestrationConfig := internalOrchestration.Config{
+		KymaVersion:       defaultKymaVersion,
+		KubernetesVersion: "1.22",
+		Namespace:         "default",
+		Name:              "policyConfig",
+	}
+
+	t.Run("should pause orchestration when failure threshold is crossed", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		id := "id"
+		fixOrchestrationWithFailureThreshold(t, store, id, &orchestration.FailureThresholdParameters{Count: 1})
+		fixPendingUpgradeKymaOperations(t, store, id, 3)
+		executor := &stateTestExecutor{store: store, failed: map[string]bool{"op-0": true}, delay: 10 * poolingInterval}
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor,
+			&automock.RuntimeResolver{}, poolingInterval, logrus.New(), fake.NewFakeClient(), &orchestrationConfig, &notificationAutomock.BundleBuilder{}, 1000)
+
+		// when
+		_, err := svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Paused, o.State)
+		assert.Equal(t, []string{"op-0"}, executor.executed)
+		stats, err := store.Operations().GetOperationStatsForOrchestration(id)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats[orchestration.Failed])
+		assert.Equal(t, 2, stats[orchestration.Pending])
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, []string{"op-0"}, executor.executed)
+	})
+
+	t.Run("should not pause orchestration when failures are ignored after resume", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		id := "id"
+		fixOrchestrationWithFailureThreshold(t, store, id, &orchestration.FailureThresholdParameters{Percentage: 10, IgnoredFailures: 1})
+		fixPendingUpgradeKymaOperations(t, store, id, 3)
+		op, err := store.Operations().GetUpgradeKymaOperationByID("op-0")
+		require.NoError(t, err)
+		op.State = orchestration.Failed
+		_, err = store.Operations().UpdateUpgradeKymaOperation(*op)
+		require.NoError(t, err)
+		executor := &stateTestExecutor{store: store, failed: map[string]bool{}}
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor,
+			&automock.RuntimeResolver{}, poolingInterval, logrus.New(), fake.NewFakeClient(), &orchestrationConfig, &notificationAutomock.BundleBuilder{}, 1000)
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, o.State)
+		assert.Equal(t, []string{"op-1", "op-2"}, executor.executed)
+	})
+}
+
+func fixOrchestrationWithFailureThreshold(t *testing.T, store storage.BrokerStorage, id string, threshold *orchestration.FailureThresholdParameters) {
+	err := store.Orchestrations().Insert(internal.Orchestration{
+		OrchestrationID: id,
+		State:           orchestration.InProgress,
+		Type:            orchestration.UpgradeKymaOrchestration,
+		Parameters: orchestration.Parameters{
+			Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: time.Now().Format(time.RFC3339),
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+			},
+			FailureThreshold: threshold,
+		},
+	})
+	require.NoError(t, err)
+}
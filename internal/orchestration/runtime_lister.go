@@ -12,18 +12,20 @@ import (
 )
 
 type RuntimeLister struct {
-	instancesDb  storage.Instances
-	operationsDb storage.Operations
-	converter    runtimeInt.Converter
-	log          logrus.FieldLogger
+	instancesDb     storage.Instances
+	operationsDb    storage.Operations
+	runtimeStatesDb storage.RuntimeStates
+	converter       runtimeInt.Converter
+	log             logrus.FieldLogger
 }
 
-func NewRuntimeLister(instancesDb storage.Instances, operationsDb storage.Operations, converter runtimeInt.Converter, log logrus.FieldLogger) *RuntimeLister {
+func NewRuntimeLister(instancesDb storage.Instances, operationsDb storage.Operations, runtimeStatesDb storage.RuntimeStates, converter runtimeInt.Converter, log logrus.FieldLogger) *RuntimeLister {
 	return &RuntimeLister{
-		instancesDb:  instancesDb,
-		operationsDb: operationsDb,
-		converter:    converter,
-		log:          log,
+		instancesDb:     instancesDb,
+		operationsDb:    operationsDb,
+		runtimeStatesDb: runtimeStatesDb,
+		converter:       converter,
+		log:             log,
 	}
 }
 
@@ -69,3 +71,17 @@ func (rl RuntimeLister) ListAllRuntimes() ([]runtime.RuntimeDTO, error) {
 
 	return runtimes, nil
 }
+
+// GetKymaVersion returns the Kyma version of the latest runtime state, the runtime states are not read by ListAllRuntimes
+// to avoid a query per runtime, the version is needed only for the runtimes matched by the Kyma version constraint
+func (rl RuntimeLister) GetKymaVersion(runtimeID string) (string, error) {
+	state, err := rl.runtimeStatesDb.GetLatestWithKymaVersionByRuntimeID(runtimeID)
+	switch {
+	case err == nil:
+		return state.GetKymaVersion(), nil
+	case dberr.IsNotFound(err):
+		return "", nil
+	default:
+		return "", fmt.Errorf("while getting runtime state: %w", err)
+	}
+}
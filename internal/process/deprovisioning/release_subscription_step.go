@@ -35,6 +35,10 @@ func (s ReleaseSubscriptionStep) Name() string {
 }
 
 func (s ReleaseSubscriptionStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if operation.Temporary {
+		log.Info("the subscription is kept for the unsuspension of the suspended instance, skipping")
+		return operation, 0, nil
+	}
 
 	planID := operation.ProvisioningParameters.PlanID
 	if needsRelease(planID) {
@@ -73,6 +73,31 @@ func TestReleaseSubscriptionStep_TrialPlan(t *testing.T) {
 	assert.Equal(t, domain.Succeeded, operation.State)
 }
 
+func TestReleaseSubscriptionStep_Suspension(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixDeprovisioningOperationWithPlanID(broker.GCPPlanID)
+	operation.Temporary = true
+	instance := fixGCPInstance(operation.InstanceID)
+
+	err := memoryStorage.Instances().Insert(instance)
+	assert.NoError(t, err)
+
+	accountProviderMock := &hyperscalerMocks.AccountProvider{}
+
+	step := NewReleaseSubscriptionStep(memoryStorage.Operations(), memoryStorage.Instances(), accountProviderMock)
+
+	// when
+	operation, repeat, err := step.Run(operation, log)
+
+	// then
+	assert.NoError(t, err)
+	accountProviderMock.AssertNumberOfCalls(t, "MarkUnusedGardenerSecretBindingAsDirty", 0)
+	assert.Equal(t, time.Duration(0), repeat)
+}
+
 func TestReleaseSubscriptionStep_OwnClusterPlan(t *testing.T) {
 	// given
 	log := logrus.New()
@@ -7,10 +7,14 @@ import (
 )
 
 type StepProcessed struct {
-	StepName string
-	Duration time.Duration
-	When     time.Duration
-	Error    error
+	StepName  string
+	Stage     string
+	StartedAt time.Time
+	Duration  time.Duration
+	When      time.Duration
+	Error     error
+	// StepError is the error returned by the step, it is kept even if the error was handled by the manager
+	StepError error
 }
 
 type ProvisioningStepProcessed struct {
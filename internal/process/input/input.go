@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -61,10 +62,11 @@ type RuntimeInput struct {
 	provisioningParameters    internal.ProvisioningParameters
 	shootName                 *string
 
-	componentsDisabler        ComponentsDisabler
-	enabledOptionalComponents map[string]struct{}
-	oidcDefaultValues         internal.OIDCConfigDTO
-	oidcLastValues            gqlschema.OIDCConfigInput
+	componentsDisabler         ComponentsDisabler
+	enabledOptionalComponents  map[string]struct{}
+	disabledOptionalComponents []string
+	oidcDefaultValues          internal.OIDCConfigDTO
+	oidcLastValues             gqlschema.OIDCConfigInput
 
 	trialNodesNumber  int
 	instanceID        string
@@ -92,6 +94,7 @@ func (r *RuntimeInput) DisableOptionalComponent(componentName string) internal.P
 
 	r.optionalComponentsService.AddComponentToDisable(componentName, runtime.NewGenericComponentDisabler(componentName))
 	delete(r.enabledOptionalComponents, componentName)
+	r.disabledOptionalComponents = append(r.disabledOptionalComponents, componentName)
 	return r
 }
 
@@ -208,6 +211,44 @@ func (r *RuntimeInput) SetLabel(key, value string) internal.ProvisionerInputCrea
 	return r
 }
 
+// Snapshot returns the state set by the steps, the input creator with the same state is returned by RestoreInput
+func (r *RuntimeInput) Snapshot() internal.ProvisionerInput {
+	r.muOptionalComponents.Lock()
+	defer r.muOptionalComponents.Unlock()
+	r.muLabels.Lock()
+	defer r.muLabels.Unlock()
+	r.muOverrides.Lock()
+	defer r.muOverrides.Unlock()
+
+	state := internal.ProvisionerInput{
+		ShootName:          r.shootName,
+		ShootDomain:        r.shootDomain,
+		ShootDNSProviders:  r.shootDnsProviders,
+		InstanceID:         r.instanceID,
+		RuntimeID:          r.runtimeID,
+		Kubeconfig:         r.kubeconfig,
+		ClusterName:        r.clusterName,
+		OIDCLastValues:     r.oidcLastValues,
+		Labels:             gqlschema.Labels{},
+		Overrides:          map[string][]*gqlschema.ConfigEntryInput{},
+		GlobalOverrides:    append([]*gqlschema.ConfigEntryInput{}, r.globalOverrides...),
+		DisabledComponents: append([]string{}, r.disabledOptionalComponents...),
+	}
+	if r.provisionRuntimeInput.RuntimeInput != nil {
+		for key, value := range r.provisionRuntimeInput.RuntimeInput.Labels {
+			state.Labels[key] = value
+		}
+	}
+	for component, overrides := range r.overrides {
+		state.Overrides[component] = append([]*gqlschema.ConfigEntryInput{}, overrides...)
+	}
+	for component := range r.enabledOptionalComponents {
+		state.EnabledComponents = append(state.EnabledComponents, component)
+	}
+	sort.Strings(state.EnabledComponents)
+	return state
+}
+
 func (r *RuntimeInput) CreateProvisionRuntimeInput() (gqlschema.ProvisionRuntimeInput, error) {
 	for _, step := range []struct {
 		name    string
@@ -0,0 +1,67 @@
+package input
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+)
+
+// ProvisionInputRestorer recreates the provisioning input creator from the state stored in the operation
+type ProvisionInputRestorer struct {
+	inputBuilder CreatorForPlan
+}
+
+func NewProvisionInputRestorer(inputBuilder CreatorForPlan) *ProvisionInputRestorer {
+	return &ProvisionInputRestorer{inputBuilder: inputBuilder}
+}
+
+func (r *ProvisionInputRestorer) Restore(operation internal.Operation) (internal.ProvisionerInputCreator, error) {
+	if operation.ProvisionerInput == nil {
+		return nil, fmt.Errorf("operation %s does not contain the provisioner input", operation.ID)
+	}
+	creator, err := r.inputBuilder.CreateProvisionInput(operation.ProvisioningParameters, operation.RuntimeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("while creating provisioning input creator: %w", err)
+	}
+	return RestoreInput(creator, *operation.ProvisionerInput)
+}
+
+// RestoreInput applies the state returned by the Snapshot method to the new input creator.
+// The labels are set only with string values, the state with other label values cannot be restored without changing them.
+func RestoreInput(creator internal.ProvisionerInputCreator, state internal.ProvisionerInput) (internal.ProvisionerInputCreator, error) {
+	if state.ShootName != nil {
+		creator.SetShootName(*state.ShootName)
+	}
+	creator.SetShootDomain(state.ShootDomain).
+		SetShootDNSProviders(state.ShootDNSProviders).
+		SetInstanceID(state.InstanceID).
+		SetRuntimeID(state.RuntimeID).
+		SetKubeconfig(state.Kubeconfig).
+		SetClusterName(state.ClusterName).
+		SetOIDCLastValues(state.OIDCLastValues)
+
+	keys := make([]string, 0, len(state.Labels))
+	for key := range state.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := state.Labels[key].(string)
+		if !ok {
+			return nil, fmt.Errorf("label %s has the unsupported value type %T", key, state.Labels[key])
+		}
+		creator.SetLabel(key, value)
+	}
+	for component, overrides := range state.Overrides {
+		creator.SetOverrides(component, overrides)
+	}
+	creator.AppendGlobalOverrides(state.GlobalOverrides)
+	for _, component := range state.DisabledComponents {
+		creator.DisableOptionalComponent(component)
+	}
+	for _, component := range state.EnabledComponents {
+		creator.EnableOptionalComponent(component)
+	}
+	return creator, nil
+}
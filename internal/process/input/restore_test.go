@@ -0,0 +1,82 @@
+package input
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/input/automock"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionInputRestorer_Restore(t *testing.T) {
+	// given
+	componentsProvider := &automock.ComponentListProvider{}
+	componentsProvider.On("AllComponents", mock.AnythingOfType("internal.RuntimeVersionData"),
+		mock.AnythingOfType("*internal.ConfigForPlan")).Return([]internal.KymaComponent{}, nil)
+	optComponentsSvc := &automock.OptionalComponentService{}
+	optComponentsSvc.On("AddComponentToDisable", mock.AnythingOfType("string"), mock.Anything).Return()
+
+	ibf, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(), componentsProvider,
+		mockConfigProvider(), Config{}, "1.10", fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), false)
+	require.NoError(t, err)
+	operation := internal.Operation{
+		ID:                     "operation-id",
+		ProvisioningParameters: fixProvisioningParameters(broker.AzurePlanID, ""),
+		RuntimeVersion:         internal.RuntimeVersionData{Version: "1.10", Origin: internal.Defaults},
+	}
+	creator, err := ibf.CreateProvisionInput(operation.ProvisioningParameters, operation.RuntimeVersion)
+	require.NoError(t, err)
+
+	creator.DisableOptionalComponent(internal.BTPOperatorComponentName).
+		EnableOptionalComponent(internal.BTPOperatorComponentName).
+		SetShootName("c-1234567").
+		SetShootDomain("c-1234567.kyma.example.com").
+		SetShootDNSProviders(gardener.DNSProvidersData{Providers: []gardener.DNSProviderData{{Primary: true, SecretName: "dns", Type: "route53"}}}).
+		SetLabel("broker_instance_id", "instance-id").
+		SetRuntimeID("runtime-id").
+		SetKubeconfig("kubeconfig").
+		AppendOverrides(internal.BTPOperatorComponentName, []*gqlschema.ConfigEntryInput{{Key: "manager.secret.clientsecret", Value: "secret", Secret: ptr.Bool(true)}}).
+		AppendGlobalOverrides([]*gqlschema.ConfigEntryInput{{Key: "global.domainName", Value: "c-1234567.kyma.example.com"}})
+	expected := creator.Snapshot()
+
+	// the state is stored in the operation
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+	operation.ProvisionerInput = &internal.ProvisionerInput{}
+	require.NoError(t, json.Unmarshal(data, operation.ProvisionerInput))
+
+	// when
+	restored, err := NewProvisionInputRestorer(ibf).Restore(operation)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expected, restored.Snapshot())
+	assert.Equal(t, []string{internal.BTPOperatorComponentName}, expected.DisabledComponents)
+	assert.Equal(t, []string{internal.BTPOperatorComponentName}, expected.EnabledComponents)
+	assert.Equal(t, "secret", expected.Overrides[internal.BTPOperatorComponentName][0].Value)
+}
+
+func TestProvisionInputRestorer_RestoreWithoutInput(t *testing.T) {
+	// when
+	_, err := NewProvisionInputRestorer(nil).Restore(internal.Operation{ID: "operation-id"})
+
+	// then
+	assert.EqualError(t, err, "operation operation-id does not contain the provisioner input")
+}
+
+func TestRestoreInput_UnsupportedLabel(t *testing.T) {
+	// when
+	_, err := RestoreInput(fixture.FixInputCreator(internal.Azure), internal.ProvisionerInput{Labels: gqlschema.Labels{"count": float64(3)}})
+
+	// then
+	assert.EqualError(t, err, "label count has the unsupported value type float64")
+}
@@ -0,0 +1,136 @@
+package process
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+)
+
+// LeaseConfiguration enables processing of operations by many KEB replicas. An operation is executed only by the replica
+// which holds the lease of the operation, the lease of a replica which stopped renewing it is taken over after the TTL.
+type LeaseConfiguration struct {
+	Enabled bool `envconfig:"default=false"`
+	// TTL is the time after which the lease which is not renewed expires
+	TTL time.Duration `envconfig:"default=1m"`
+	// HeartbeatInterval is the interval of the lease renewal while the operation is executed
+	HeartbeatInterval time.Duration `envconfig:"default=20s"`
+}
+
+func (c LeaseConfiguration) String() string {
+	return fmt.Sprintf("(Enabled=%t; TTL=%s; HeartbeatInterval=%s)", c.Enabled, c.TTL, c.HeartbeatInterval)
+}
+
+// UseLeases makes the manager execute an operation only if the owner holds the lease of the operation
+func (m *StagedManager) UseLeases(leases storage.OperationLeases, owner string, cfg LeaseConfiguration) {
+	m.leases = leases
+	m.leaseOwner = owner
+	m.leaseCfg = cfg
+}
+
+func (m *StagedManager) executeWithLease(operationID string) (time.Duration, error) {
+	log := m.log.WithField("operation", operationID)
+
+	acquired, err := m.leases.Acquire(operationID, m.leaseOwner, m.leaseCfg.TTL)
+	if err != nil {
+		log.Errorf("Cannot acquire the lease of the operation: %s", err)
+		return 3 * time.Second, nil
+	}
+	if !acquired {
+		// the operation is retried to take it over when the owner stops renewing the lease
+		log.Infof("Operation is processed by another replica, checking the lease in %s", m.leaseCfg.TTL)
+		return m.leaseCfg.TTL, nil
+	}
+
+	operation, err := m.operationStorage.GetOperationByID(operationID)
+	if err != nil {
+		log.Errorf("Cannot fetch operation from storage: %s", err)
+		return 3 * time.Second, nil
+	}
+	if operation.IsFinished() && !operation.CompensationInProgress {
+		log.Infof("Operation was already processed with state %s", operation.State)
+		m.releaseLease(operationID)
+		return 0, nil
+	}
+
+	stopHeartbeat, leaseLost := m.heartbeat(operationID)
+	when, err := m.execute(operationID, leaseLost)
+	close(stopHeartbeat)
+
+	if isClosed(leaseLost) {
+		// the lease belongs to another replica, it must not be released
+		return 0, nil
+	}
+	if when == 0 || err != nil {
+		m.releaseLease(operationID)
+	}
+	return when, err
+}
+
+// heartbeat renews the lease of the operation until the returned stop channel is closed.
+// The returned lost channel is closed when the lease was taken over by another replica, the heartbeat stops then.
+func (m *StagedManager) heartbeat(operationID string) (chan struct{}, <-chan struct{}) {
+	stop := make(chan struct{})
+	lost := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(m.leaseCfg.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				renewed, err := m.leases.Renew(operationID, m.leaseOwner, m.leaseCfg.TTL)
+				switch {
+				case err != nil:
+					m.log.Errorf("Cannot renew the lease of the operation %s: %s", operationID, err)
+				case !renewed:
+					m.log.Warnf("The lease of the operation %s was taken over by another replica", operationID)
+					close(lost)
+					return
+				}
+			}
+		}
+	}()
+	return stop, lost
+}
+
+// isClosed returns true if the channel is closed, the nil channel is never closed
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *StagedManager) releaseLease(operationID string) {
+	if err := m.leases.Release(operationID, m.leaseOwner); err != nil {
+		m.log.Errorf("Cannot release the lease of the operation %s: %s", operationID, err)
+	}
+}
+
+// JobLease lets only one KEB replica run the periodic job. The lease of the job is stored as the lease of the pseudo
+// operation named after the job. The replica holding the lease renews it in every interval, so another replica takes
+// the job over only when the lease is not renewed for two intervals.
+type JobLease struct {
+	leases storage.OperationLeases
+	jobID  string
+	owner  string
+	ttl    time.Duration
+}
+
+func NewJobLease(leases storage.OperationLeases, job, owner string, interval time.Duration) *JobLease {
+	return &JobLease{
+		leases: leases,
+		jobID:  fmt.Sprintf("job-%s", job),
+		owner:  owner,
+		ttl:    2 * interval,
+	}
+}
+
+// Acquire takes or renews the lease of the job, it returns false if the job is run by another replica
+func (l *JobLease) Acquire() (bool, error) {
+	return l.leases.Acquire(l.jobID, l.owner, l.ttl)
+}
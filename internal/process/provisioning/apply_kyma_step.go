@@ -3,6 +3,7 @@ package provisioning
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"reflect"
 	"time"
 
@@ -20,12 +21,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ApplyKymaStep creates or updates the Kyma resource, the errors of the KCP requests are returned to be handled by the retry policy of the step
 type ApplyKymaStep struct {
 	operationManager *process.OperationManager
 	k8sClient        client.Client
 }
 
-var _ process.Step = &ApplyKymaStep{}
+var _ process.CompensatingStep = &ApplyKymaStep{}
 
 func NewApplyKymaStep(os storage.Operations, cli client.Client) *ApplyKymaStep {
 	return &ApplyKymaStep{operationManager: process.NewOperationManager(os), k8sClient: cli}
@@ -66,23 +68,45 @@ func (a *ApplyKymaStep) Run(operation internal.Operation, logger logrus.FieldLog
 		err = a.k8sClient.Update(context.Background(), &existingKyma)
 		if err != nil {
 			logger.Errorf("unable to update a Kyma resource: %s", err.Error())
-			return a.operationManager.RetryOperation(operation, "unable to update the Kyma resource", err, time.Second, 10*time.Second, logger)
+			return operation, 0, fmt.Errorf("unable to update the Kyma resource: %w", err)
 		}
 	case errors.IsNotFound(err):
 		logger.Infof("creating Kyma resource: %s in namespace: %s", template.GetName(), template.GetNamespace())
 		err := a.k8sClient.Create(context.Background(), template)
 		if err != nil {
 			logger.Errorf("unable to create a Kyma resource: %s", err.Error())
-			return a.operationManager.RetryOperation(operation, "unable to create the Kyma resource", err, time.Second, 10*time.Second, logger)
+			return operation, 0, fmt.Errorf("unable to create the Kyma resource: %w", err)
 		}
 	default:
 		logger.Errorf("Unable to get Kyma: %s", err.Error())
-		return a.operationManager.RetryOperation(operation, "unable to get the Kyma resource", err, time.Second, 10*time.Second, logger)
+		return operation, 0, fmt.Errorf("unable to get the Kyma resource: %w", err)
 	}
 
 	return operation, 0, nil
 }
 
+// Compensate deletes the Kyma resource created by the operation
+func (a *ApplyKymaStep) Compensate(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if operation.KymaResourceName == "" {
+		return operation, 0, nil
+	}
+	template, err := steps.DecodeKymaTemplate(operation.KymaTemplate)
+	if err != nil {
+		return operation, 0, fmt.Errorf("while decoding the kyma template: %w", err)
+	}
+
+	logger.Infof("Deleting Kyma resource: %s in namespace: %s", operation.KymaResourceName, operation.KymaResourceNamespace)
+	kyma := &unstructured.Unstructured{}
+	kyma.SetGroupVersionKind(template.GroupVersionKind())
+	kyma.SetName(operation.KymaResourceName)
+	kyma.SetNamespace(operation.KymaResourceNamespace)
+	err = a.k8sClient.Delete(context.Background(), kyma)
+	if err != nil && !errors.IsNotFound(err) {
+		return operation, 0, fmt.Errorf("while deleting the Kyma resource: %w", err)
+	}
+	return operation, 0, nil
+}
+
 func (a *ApplyKymaStep) addLabelsAndName(operation internal.Operation, obj *unstructured.Unstructured) bool {
 	oldLabels := obj.GetLabels()
 	steps.ApplyLabelsAndAnnotationsForLM(obj, operation)
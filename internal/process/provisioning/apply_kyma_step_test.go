@@ -232,6 +232,34 @@ func TestUpdatinInternalKymaResourceIfExists(t *testing.T) {
 	assertLabelsExistsForInternalKymaResource(t, aList.Items[0])
 }
 
+func TestCompensatingKymaResource(t *testing.T) {
+	// given
+	operation, cli := fixOperationForApplyKymaResource(t)
+	storage := storage.NewMemoryStorage()
+	storage.Operations().InsertOperation(operation)
+	svc := NewApplyKymaStep(storage.Operations(), cli)
+	operation, _, err := svc.Run(operation, logrus.New())
+	require.NoError(t, err)
+
+	// when
+	_, backoff, err := svc.Compensate(operation, logrus.New())
+
+	// then
+	require.NoError(t, err)
+	require.Zero(t, backoff)
+	aList := unstructured.UnstructuredList{}
+	aList.SetGroupVersionKind(schema.GroupVersionKind{Group: "operator.kyma-project.io", Version: "v1beta2", Kind: "KymaList"})
+
+	cli.List(context.Background(), &aList)
+	assert.Empty(t, aList.Items)
+
+	// when the resource is already deleted
+	_, _, err = svc.Compensate(operation, logrus.New())
+
+	// then
+	assert.NoError(t, err)
+}
+
 func assertLabelsExists(t *testing.T, obj unstructured.Unstructured) {
 	assert.Contains(t, obj.GetLabels(), "kyma-project.io/instance-id")
 	assert.Contains(t, obj.GetLabels(), "kyma-project.io/runtime-id")
@@ -115,6 +115,10 @@ func (c *simpleInputCreator) SetOIDCLastValues(oidcConfig gqlschema.OIDCConfigIn
 	return c
 }
 
+func (c *simpleInputCreator) Snapshot() internal.ProvisionerInput {
+	return internal.ProvisionerInput{}
+}
+
 func (c *simpleInputCreator) AppendOverrides(component string, overrides []*gqlschema.ConfigEntryInput) internal.ProvisionerInputCreator {
 	c.overrides[component] = append(c.overrides[component], overrides...)
 	return c
@@ -29,13 +29,17 @@ type EDPRegistrationStep struct {
 	operationManager *process.OperationManager
 	client           EDPClient
 	config           edp.Config
+	instances        storage.Instances
 }
 
-func NewEDPRegistrationStep(os storage.Operations, client EDPClient, config edp.Config) *EDPRegistrationStep {
+var _ process.CompensatingStep = &EDPRegistrationStep{}
+
+func NewEDPRegistrationStep(os storage.Operations, is storage.Instances, client EDPClient, config edp.Config) *EDPRegistrationStep {
 	return &EDPRegistrationStep{
 		operationManager: process.NewOperationManager(os),
 		client:           client,
 		config:           config,
+		instances:        is,
 	}
 }
 
@@ -96,6 +100,43 @@ func (s *EDPRegistrationStep) Run(operation internal.Operation, log logrus.Field
 	return newOp, 0, nil
 }
 
+// Compensate removes the DataTenant registered by the operation, the DataTenant is kept when other instances of the subaccount use it
+func (s *EDPRegistrationStep) Compensate(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if !operation.EDPCreated {
+		return operation, 0, nil
+	}
+	subAccountID := strings.ToLower(operation.ProvisioningParameters.ErsContext.SubAccountID)
+	instances, err := s.instances.FindAllInstancesForSubAccounts([]string{operation.ProvisioningParameters.ErsContext.SubAccountID})
+	if err != nil {
+		return operation, 0, fmt.Errorf("while getting instances of subaccount %s: %w", subAccountID, err)
+	}
+	for _, instance := range instances {
+		if instance.InstanceID != operation.InstanceID {
+			log.Infof("Skipping removal of the DataTenant used by the instance %s", instance.InstanceID)
+			return operation, 0, nil
+		}
+	}
+
+	for _, key := range []string{
+		edp.MaasConsumerEnvironmentKey,
+		edp.MaasConsumerRegionKey,
+		edp.MaasConsumerSubAccountKey,
+		edp.MaasConsumerServicePlan,
+	} {
+		log.Infof("Deleting DataTenant metadata %s (%s): %s", subAccountID, s.config.Environment, key)
+		if err := s.client.DeleteMetadataTenant(subAccountID, s.config.Environment, key); err != nil {
+			return operation, 0, fmt.Errorf("while removing DataTenant metadata with key %s: %w", key, err)
+		}
+	}
+	log.Infof("Deleting DataTenant %s (%s)", subAccountID, s.config.Environment)
+	if err := s.client.DeleteDataTenant(subAccountID, s.config.Environment); err != nil {
+		return operation, 0, fmt.Errorf("while removing DataTenant: %w", err)
+	}
+
+	operation.EDPCreated = false
+	return operation, 0, nil
+}
+
 func (s *EDPRegistrationStep) handleError(operation internal.Operation, err error, log logrus.FieldLogger, msg string) (internal.Operation, time.Duration, error) {
 	log.Errorf("%s: %s", msg, err)
 
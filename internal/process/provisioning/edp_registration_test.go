@@ -25,7 +25,7 @@ func TestEDPRegistration_Run(t *testing.T) {
 	memoryStorage := storage.NewMemoryStorage()
 	client := edp.NewFakeClient()
 
-	step := NewEDPRegistrationStep(memoryStorage.Operations(), client, edp.Config{
+	step := NewEDPRegistrationStep(memoryStorage.Operations(), memoryStorage.Instances(), client, edp.Config{
 		Environment: edpEnvironment,
 		Required:    true,
 	})
@@ -102,7 +102,7 @@ func TestEDPRegistrationStep_selectEnvironmentKey(t *testing.T) {
 	} {
 		t.Run(name, func(t *testing.T) {
 			// given
-			step := NewEDPRegistrationStep(nil, nil, edp.Config{})
+			step := NewEDPRegistrationStep(nil, nil, nil, edp.Config{})
 
 			// when
 			envKey := step.selectEnvironmentKey(tc.region, logger.NewLogDummy())
@@ -149,7 +149,7 @@ func TestEDPRegistrationStep_selectServicePlan(t *testing.T) {
 	} {
 		t.Run(name, func(t *testing.T) {
 			// given
-			step := NewEDPRegistrationStep(nil, nil, edp.Config{})
+			step := NewEDPRegistrationStep(nil, nil, nil, edp.Config{})
 
 			// when
 			envKey := step.selectServicePlan(tc.planID)
@@ -159,3 +159,62 @@ func TestEDPRegistrationStep_selectServicePlan(t *testing.T) {
 		})
 	}
 }
+
+func TestEDPRegistration_Compensate(t *testing.T) {
+	t.Run("should remove the registered DataTenant", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		client := edp.NewFakeClient()
+		step := NewEDPRegistrationStep(memoryStorage.Operations(), memoryStorage.Instances(), client, edp.Config{Environment: edpEnvironment, Required: true})
+		operation := fixEDPOperation()
+		memoryStorage.Operations().InsertOperation(operation)
+		operation, _, err := step.Run(operation, logger.NewLogDummy())
+		assert.NoError(t, err)
+
+		// when
+		operation, repeat, err := step.Compensate(operation, logger.NewLogDummy())
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.False(t, operation.EDPCreated)
+		_, dataTenantExists := client.GetDataTenantItem(edpName, edpEnvironment)
+		assert.False(t, dataTenantExists)
+		_, metadataTenantExists := client.GetMetadataItem(edpName, edpEnvironment, edp.MaasConsumerRegionKey)
+		assert.False(t, metadataTenantExists)
+	})
+
+	t.Run("should keep the DataTenant used by other instances of the subaccount", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		client := edp.NewFakeClient()
+		step := NewEDPRegistrationStep(memoryStorage.Operations(), memoryStorage.Instances(), client, edp.Config{Environment: edpEnvironment, Required: true})
+		operation := fixEDPOperation()
+		memoryStorage.Operations().InsertOperation(operation)
+		memoryStorage.Instances().Insert(internal.Instance{InstanceID: "other-instance", SubAccountID: edpName})
+		operation, _, err := step.Run(operation, logger.NewLogDummy())
+		assert.NoError(t, err)
+
+		// when
+		_, _, err = step.Compensate(operation, logger.NewLogDummy())
+
+		// then
+		assert.NoError(t, err)
+		_, dataTenantExists := client.GetDataTenantItem(edpName, edpEnvironment)
+		assert.True(t, dataTenantExists)
+	})
+}
+
+func fixEDPOperation() internal.Operation {
+	return internal.Operation{
+		ID:         "op-id",
+		InstanceID: "instance-id",
+		ProvisioningParameters: internal.ProvisioningParameters{
+			PlanID:         broker.AzurePlanID,
+			PlatformRegion: edpRegion,
+			ErsContext: internal.ERSContext{
+				SubAccountID: edpName,
+			},
+		},
+	}
+}
@@ -1,7 +1,6 @@
 package provisioning
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -86,42 +85,14 @@ func (k *OverrideKymaModules) handleModulesOverride(operation internal.Operation
 }
 
 func (k *OverrideKymaModules) replaceModulesSpec(kymaTemplate *unstructured.Unstructured, customModuleList []internal.ModuleDTO) error {
-	toInsert := k.prepareModulesSection(customModuleList)
-	toInsertMarshaled, err := json.Marshal(toInsert)
-	if err != nil {
-		return err
+	if len(customModuleList) == 0 {
+		k.logger.Info("empty (0 items) list with custom modules passed to KEB, 0 modules will be installed - default config will be ignored")
+	} else {
+		k.logger.Infof("not empty list with custom modules passed to KEB. Number of modules: %d", len(customModuleList))
 	}
-	var toInsertUnmarshaled interface{}
-	err = json.Unmarshal(toInsertMarshaled, &toInsertUnmarshaled)
-	if err != nil {
+	if err := steps.ReplaceKymaModules(kymaTemplate, customModuleList); err != nil {
 		return err
 	}
-	unstructured.SetNestedField(kymaTemplate.Object, toInsertUnmarshaled, "spec", "modules")
 	k.logger.Info("custom modules replaced in Kyma template successfully.")
 	return nil
 }
-func (k *OverrideKymaModules) prepareModulesSection(customModuleList []internal.ModuleDTO) []internal.ModuleDTO {
-	// if field is "" convert it to nil to field will be not present in yaml
-	mapIfNeeded := func(field *string) *string {
-		if field != nil && *field == "" {
-			return nil
-		}
-		return field
-	}
-
-	overridedModules := make([]internal.ModuleDTO, 0)
-	if customModuleList == nil || len(customModuleList) == 0 {
-		k.logger.Info("empty (0 items) list with custom modules passed to KEB, 0 modules will be installed - default config will be ignored")
-		return overridedModules
-	}
-
-	for _, customModule := range customModuleList {
-		module := internal.ModuleDTO{Name: customModule.Name}
-		module.CustomResourcePolicy = mapIfNeeded(customModule.CustomResourcePolicy)
-		module.Channel = mapIfNeeded(customModule.Channel)
-		overridedModules = append(overridedModules, module)
-	}
-
-	k.logger.Infof("not empty list with custom modules passed to KEB. Number of modules: %d", len(overridedModules))
-	return overridedModules
-}
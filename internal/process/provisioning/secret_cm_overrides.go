@@ -9,6 +9,7 @@ import (
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 
 	"github.com/sirupsen/logrus"
@@ -45,8 +46,9 @@ func (s *OverridesFromSecretsAndConfigStep) Name() string {
 func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	planName, exists := broker.PlanNamesMapping[operation.ProvisioningParameters.PlanID]
 	if !exists {
-		log.Errorf("cannot map planID '%s' to planName", operation.ProvisioningParameters.PlanID)
-		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters", nil, log)
+		errMsg := fmt.Sprintf("cannot map planID '%s' to planName", operation.ProvisioningParameters.PlanID)
+		log.Error(errMsg)
+		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters", kebError.InvalidParametersError(errMsg), log)
 	}
 
 	overridesVersion := s.getOverridesVersion(operation)
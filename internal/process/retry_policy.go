@@ -0,0 +1,95 @@
+package process
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+)
+
+const defaultRetryInterval = time.Second
+
+// RetryPolicy declares how the StagedManager retries a step. The policy is applied when the step returns a retry duration
+// or an error with a retryable reason while the operation is not failed. When the policy is exhausted or the step returns an error
+// which is not retryable, the operation is marked as failed.
+// Every attempt is saved in the operation, so the policy governs the step instead of retry limits the step calculates from the operation update time.
+type RetryPolicy struct {
+	// InitialInterval is the backoff before the first retry, the duration returned by the step is used if not set
+	InitialInterval time.Duration
+	// MaxInterval limits the exponentially growing backoff, no limit if not set
+	MaxInterval time.Duration
+	// Multiplier is the factor of the backoff growth, the backoff is constant if lower or equal 1
+	Multiplier float64
+	// Jitter randomizes the backoff by the given fraction, e.g. 0.1 means +/- 10%
+	Jitter float64
+	// MaxAttempts is the number of retries after which the operation fails, unlimited if not set
+	MaxAttempts int
+	// MaxElapsedTime is the time since the first retry after which the operation fails, unlimited if not set
+	MaxElapsedTime time.Duration
+	// RetryableReasons are reasons of errors (see kebError.LastError) which are retried instead of stopping the operation
+	RetryableReasons []kebError.ErrReason
+}
+
+func (p RetryPolicy) String() string {
+	return fmt.Sprintf("(InitialInterval=%s; MaxInterval=%s; Multiplier=%.2f; Jitter=%.2f; MaxAttempts=%d; MaxElapsedTime=%s)",
+		p.InitialInterval, p.MaxInterval, p.Multiplier, p.Jitter, p.MaxAttempts, p.MaxElapsedTime)
+}
+
+// IsRetryable returns true if the reason of the error is one of the retryable reasons
+func (p RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	reason := kebError.ReasonForError(err).Reason()
+	for _, r := range p.RetryableReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Exhausted returns true if the attempt exceeds the policy limits
+func (p RetryPolicy) Exhausted(attempts int, firstAttemptAt time.Time) bool {
+	if p.MaxAttempts > 0 && attempts > p.MaxAttempts {
+		return true
+	}
+	return p.MaxElapsedTime > 0 && time.Since(firstAttemptAt) > p.MaxElapsedTime
+}
+
+// Backoff calculates the duration before the given attempt (counted from 1), stepBackoff is the duration returned by the step
+func (p RetryPolicy) Backoff(attempt int, stepBackoff time.Duration) time.Duration {
+	interval := p.InitialInterval
+	if interval == 0 {
+		interval = stepBackoff
+	}
+	if interval == 0 {
+		interval = defaultRetryInterval
+	}
+
+	backoff := float64(interval)
+	if p.Multiplier > 1 && attempt > 1 {
+		backoff = backoff * math.Pow(p.Multiplier, float64(attempt-1))
+	}
+	if p.MaxInterval > 0 && backoff > float64(p.MaxInterval) {
+		backoff = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		delta := p.Jitter * backoff
+		backoff = backoff - delta + rand.Float64()*2*delta
+	}
+
+	return time.Duration(backoff)
+}
+
+// StepOption configures a step registered in the StagedManager
+type StepOption func(step *StepWithCondition)
+
+// WithRetryPolicy sets the retry policy of the step
+func WithRetryPolicy(policy RetryPolicy) StepOption {
+	return func(step *StepWithCondition) {
+		step.retryPolicy = &policy
+	}
+}
@@ -0,0 +1,91 @@
+package process
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy      RetryPolicy
+		attempt     int
+		stepBackoff time.Duration
+		expected    time.Duration
+	}{
+		"initial interval": {
+			policy:   RetryPolicy{InitialInterval: time.Second, Multiplier: 2},
+			attempt:  1,
+			expected: time.Second,
+		},
+		"exponential growth": {
+			policy:   RetryPolicy{InitialInterval: time.Second, Multiplier: 2},
+			attempt:  4,
+			expected: 8 * time.Second,
+		},
+		"limited by max interval": {
+			policy:   RetryPolicy{InitialInterval: time.Second, Multiplier: 2, MaxInterval: 5 * time.Second},
+			attempt:  4,
+			expected: 5 * time.Second,
+		},
+		"constant interval": {
+			policy:   RetryPolicy{InitialInterval: 3 * time.Second},
+			attempt:  4,
+			expected: 3 * time.Second,
+		},
+		"step backoff": {
+			policy:      RetryPolicy{Multiplier: 2},
+			attempt:     2,
+			stepBackoff: 10 * time.Second,
+			expected:    20 * time.Second,
+		},
+		"default interval": {
+			policy:   RetryPolicy{},
+			attempt:  1,
+			expected: defaultRetryInterval,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.Backoff(tc.attempt, tc.stepBackoff))
+		})
+	}
+}
+
+func TestRetryPolicy_BackoffWithJitter(t *testing.T) {
+	// given
+	policy := RetryPolicy{InitialInterval: 10 * time.Second, Jitter: 0.1}
+
+	for i := 0; i < 100; i++ {
+		// when
+		backoff := policy.Backoff(1, 0)
+
+		// then
+		assert.GreaterOrEqual(t, backoff, 9*time.Second)
+		assert.LessOrEqual(t, backoff, 11*time.Second)
+	}
+}
+
+func TestRetryPolicy_Exhausted(t *testing.T) {
+	// given
+	policy := RetryPolicy{MaxAttempts: 3, MaxElapsedTime: time.Minute}
+
+	// then
+	assert.False(t, policy.Exhausted(3, time.Now()))
+	assert.True(t, policy.Exhausted(4, time.Now()))
+	assert.True(t, policy.Exhausted(1, time.Now().Add(-2*time.Minute)))
+	assert.False(t, RetryPolicy{}.Exhausted(100, time.Now().Add(-24*time.Hour)))
+}
+
+func TestRetryPolicy_IsRetryable(t *testing.T) {
+	// given
+	policy := RetryPolicy{RetryableReasons: []kebError.ErrReason{kebError.ErrKEBTimeOut}}
+
+	// then
+	assert.True(t, policy.IsRetryable(kebError.TimeoutError("timeout")))
+	assert.True(t, policy.IsRetryable(fmt.Errorf("wrapped: %w", kebError.TimeoutError("timeout"))))
+	assert.False(t, policy.IsRetryable(fmt.Errorf("some error")))
+	assert.False(t, policy.IsRetryable(nil))
+}
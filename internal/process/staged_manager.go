@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,13 +24,22 @@ type StagedManager struct {
 	operationStorage storage.Operations
 	publisher        event.Publisher
 
-	stages           []*stage
+	stages []*stage
+	// operationTimeout is the max processing time of an operation, zero means no limit
 	operationTimeout time.Duration
 
 	mu sync.RWMutex
 
 	speedFactor int64
 	cfg         StagedManagerConfiguration
+
+	leases     storage.OperationLeases
+	leaseOwner string
+	leaseCfg   LeaseConfiguration
+
+	inputRestorer InputCreatorRestorer
+	// splitStages maps the stages to the stage they were split from
+	splitStages map[string]string
 }
 
 type StagedManagerConfiguration struct {
@@ -47,11 +57,24 @@ type Step interface {
 	Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error)
 }
 
+// CompensatingStep is a Step which is able to revert changes made by its Run method.
+// If a provisioning or update operation fails, the StagedManager compensates all executed compensating steps in the reverse order.
+type CompensatingStep interface {
+	Step
+	Compensate(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error)
+}
+
 type StepCondition func(operation internal.Operation) bool
 
+// InputCreatorRestorer recreates the input creator of the operation from the provisioner input saved with the finished stage
+type InputCreatorRestorer interface {
+	Restore(operation internal.Operation) (internal.ProvisionerInputCreator, error)
+}
+
 type StepWithCondition struct {
 	Step
-	condition StepCondition
+	condition   StepCondition
+	retryPolicy *RetryPolicy
 }
 
 type stage struct {
@@ -59,11 +82,15 @@ type stage struct {
 	steps []StepWithCondition
 }
 
-func (s *stage) AddStep(step Step, cnd StepCondition) {
-	s.steps = append(s.steps, StepWithCondition{
+func (s *stage) AddStep(step Step, cnd StepCondition, opts ...StepOption) {
+	stepWithCondition := StepWithCondition{
 		Step:      step,
 		condition: cnd,
-	})
+	}
+	for _, opt := range opts {
+		opt(&stepWithCondition)
+	}
+	s.steps = append(s.steps, stepWithCondition)
 }
 
 func NewStagedManager(storage storage.Operations, pub event.Publisher, operationTimeout time.Duration, cfg StagedManagerConfiguration, logger logrus.FieldLogger) *StagedManager {
@@ -83,6 +110,23 @@ func (m *StagedManager) SpeedUp(speedFactor int64) {
 	m.speedFactor = speedFactor
 }
 
+// UseInputCreatorRestorer makes the manager restore the input creator of the operation which is resumed after the stage
+// which created the input creator was finished, e.g. after KEB restart
+func (m *StagedManager) UseInputCreatorRestorer(restorer InputCreatorRestorer) {
+	m.inputRestorer = restorer
+}
+
+// DefineSplitStages marks the stages split from the given stage. They are not executed for the operations which finished that stage
+// before the split, the steps moved from that stage to the split stages were already executed.
+func (m *StagedManager) DefineSplitStages(splitFrom string, stages ...string) {
+	if m.splitStages == nil {
+		m.splitStages = map[string]string{}
+	}
+	for _, s := range stages {
+		m.splitStages[s] = splitFrom
+	}
+}
+
 func (m *StagedManager) DefineStages(names []string) {
 	m.stages = make([]*stage, len(names))
 	for i, n := range names {
@@ -90,10 +134,10 @@ func (m *StagedManager) DefineStages(names []string) {
 	}
 }
 
-func (m *StagedManager) AddStep(stageName string, step Step, cnd StepCondition) error {
+func (m *StagedManager) AddStep(stageName string, step Step, cnd StepCondition, opts ...StepOption) error {
 	for _, s := range m.stages {
 		if s.name == stageName {
-			s.AddStep(step, cnd)
+			s.AddStep(step, cnd, opts...)
 			return nil
 		}
 	}
@@ -109,6 +153,14 @@ func (m *StagedManager) GetAllStages() []string {
 }
 
 func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
+	if m.leases == nil {
+		return m.execute(operationID, nil)
+	}
+	return m.executeWithLease(operationID)
+}
+
+// execute processes the operation, the processing stops before the next step when the leaseLost channel is closed
+func (m *StagedManager) execute(operationID string, leaseLost <-chan struct{}) (time.Duration, error) {
 
 	operation, err := m.operationStorage.GetOperationByID(operationID)
 	if err != nil {
@@ -117,15 +169,31 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	}
 
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID, "planID": operation.ProvisioningParameters.PlanID})
+	if operation.CompensationInProgress {
+		return m.compensate(*operation, leaseLost, logOperation)
+	}
+
+	if operation.IsFinished() {
+		logOperation.Infof("Operation already finished with state %s", operation.State)
+		return 0, nil
+	}
+	if operation.FailureRequested {
+		return m.failOnRequest(*operation, leaseLost, logOperation)
+	}
+	if operation.Paused {
+		logOperation.Infof("Operation is paused, the processing continues when the operation is resumed")
+		return 0, nil
+	}
+
 	logOperation.Infof("Start process operation steps for GlobalAccount=%s, ", operation.ProvisioningParameters.ErsContext.GlobalAccountID)
-	if time.Since(operation.CreatedAt) > m.operationTimeout {
+	if m.operationTimeout > 0 && time.Since(operation.CreatedAt) > m.operationTimeout {
 		timeoutErr := kebError.TimeoutError("operation has reached the time limit")
 		operation.LastError = timeoutErr
 		defer m.callPubSubOutsideSteps(operation, timeoutErr)
 
 		logOperation.Infof("operation has reached the time limit: operation was created at: %s", operation.CreatedAt)
 		operation.State = domain.Failed
-		_, err = m.operationStorage.UpdateOperation(*operation)
+		failedOperation, err := m.operationStorage.UpdateOperation(*operation)
 		if err != nil {
 			logOperation.Infof("Unable to save operation with finished the provisioning process")
 			timeoutErr = timeoutErr.SetMessage(fmt.Sprintf("%s and %s", timeoutErr.Error(), err.Error()))
@@ -133,6 +201,9 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 			return time.Second, timeoutErr
 		}
 
+		if m.requiresCompensation(*failedOperation) {
+			return m.startCompensation(*failedOperation, leaseLost, logOperation)
+		}
 		return 0, timeoutErr
 	}
 
@@ -140,9 +211,18 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	processedOperation := *operation
 
 	for _, stage := range m.stages {
-		if processedOperation.IsStageFinished(stage.name) {
+		if m.isStageFinished(processedOperation, stage.name) {
 			continue
 		}
+		if processedOperation.InputCreator == nil && processedOperation.ProvisionerInput != nil && m.inputRestorer != nil {
+			creator, err := m.inputRestorer.Restore(processedOperation)
+			if err != nil {
+				logOperation.Errorf("unable to restore the input creator: %s", err)
+				return 10 * time.Second, nil
+			}
+			logOperation.Infof("Input creator restored before stage %s", stage.name)
+			processedOperation.InputCreator = creator
+		}
 
 		for _, step := range stage.steps {
 			logStep := logOperation.WithField("step", step.Name()).
@@ -151,17 +231,45 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 				logStep.Debugf("Skipping")
 				continue
 			}
+			if isClosed(leaseLost) {
+				logStep.Warnf("The lease of the operation was lost, the processing is continued by the lease owner")
+				return 0, nil
+			}
+			// the changes made by an administrator while the operation is processed are applied before the step
+			current, err := m.operationStorage.GetOperationByID(processedOperation.ID)
+			if err != nil {
+				logStep.Errorf("Cannot fetch operation from storage: %s", err)
+				return 3 * time.Second, nil
+			}
+			switch {
+			case current.FailureRequested:
+				// the progress which is not saved yet, e.g. the compensable steps, must be kept
+				processedOperation.Version = current.Version
+				return m.failOnRequest(processedOperation, leaseLost, logOperation)
+			case current.Paused:
+				logStep.Infof("Operation was paused, the processing continues when the operation is resumed")
+				return 0, nil
+			case current.IsStepSkipped(step.Name()):
+				logStep.Infof("Skipping, the step was skipped by an administrator")
+				continue
+			}
 			operation.EventInfof("processing step: %v", step.Name())
 
-			processedOperation, when, err = m.runStep(step, processedOperation, logStep)
+			processedOperation, when, err = m.runStep(step, stage.name, step.retryPolicy, processedOperation, logStep)
 			if err != nil {
 				logStep.Errorf("Process operation failed: %s", err)
 				operation.EventErrorf(err, "step %v processing returned error", step.Name())
+				if m.requiresCompensation(processedOperation) {
+					return m.startCompensation(processedOperation, leaseLost, logOperation)
+				}
 				return 0, err
 			}
-			if processedOperation.State == domain.Failed || processedOperation.State == domain.Succeeded {
+			if processedOperation.IsFinished() {
 				logStep.Infof("Operation %q got status %s. Process finished.", operation.ID, processedOperation.State)
 				operation.EventInfof("operation processing %v", processedOperation.State)
+				if m.requiresCompensation(processedOperation) {
+					return m.startCompensation(processedOperation, leaseLost, logOperation)
+				}
 				return 0, nil
 			}
 
@@ -170,6 +278,10 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 				logStep.Warnf("retrying step by restarting the operation in %d s", int64(when.Seconds()))
 				return when, nil
 			}
+
+			if _, ok := step.Step.(CompensatingStep); ok {
+				processedOperation.AddCompensableStep(step.Name())
+			}
 		}
 
 		processedOperation, err = m.saveFinishedStage(processedOperation, stage, logOperation)
@@ -195,8 +307,39 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	return 0, nil
 }
 
+// Reschedule sets the maintenance window of the operation, it is used by orchestrations to postpone the operation
+func (m *StagedManager) Reschedule(operationID string, maintenanceWindowBegin, maintenanceWindowEnd time.Time) error {
+	operation, err := m.operationStorage.GetOperationByID(operationID)
+	if err != nil {
+		m.log.Errorf("Cannot fetch operation %s from storage: %s", operationID, err)
+		return err
+	}
+	operation.MaintenanceWindowBegin = maintenanceWindowBegin
+	operation.MaintenanceWindowEnd = maintenanceWindowEnd
+	_, err = m.operationStorage.UpdateOperation(*operation)
+	if err != nil {
+		m.log.Errorf("Cannot update (reschedule) operation %s in storage: %s", operationID, err)
+	}
+
+	return err
+}
+
+// isStageFinished returns true also for the split stage if the stage it was split from is finished
+func (m *StagedManager) isStageFinished(operation internal.Operation, name string) bool {
+	if operation.IsStageFinished(name) {
+		return true
+	}
+	splitFrom, split := m.splitStages[name]
+	return split && operation.IsStageFinished(splitFrom)
+}
+
+// saveFinishedStage saves the state of the input creator together with the finished stage, so the next stages can be resumed with the same input
 func (m *StagedManager) saveFinishedStage(operation internal.Operation, s *stage, log logrus.FieldLogger) (internal.Operation, error) {
 	operation.FinishStage(s.name)
+	if operation.InputCreator != nil {
+		provisionerInput := operation.InputCreator.Snapshot()
+		operation.ProvisionerInput = &provisionerInput
+	}
 	op, err := m.operationStorage.UpdateOperation(operation)
 	if err != nil {
 		log.Infof("Unable to save operation with finished stage %s: %s", s.name, err.Error())
@@ -206,7 +349,7 @@ func (m *StagedManager) saveFinishedStage(operation internal.Operation, s *stage
 	return *op, nil
 }
 
-func (m *StagedManager) runStep(step Step, operation internal.Operation, logger logrus.FieldLogger) (processedOperation internal.Operation, backoff time.Duration, err error) {
+func (m *StagedManager) runStep(step Step, stageName string, policy *RetryPolicy, operation internal.Operation, logger logrus.FieldLogger) (processedOperation internal.Operation, backoff time.Duration, err error) {
 	var start time.Time
 	defer func() {
 		if pErr := recover(); pErr != nil {
@@ -223,23 +366,33 @@ func (m *StagedManager) runStep(step Step, operation internal.Operation, logger
 		start = time.Now()
 		logger.Infof("Start step")
 		processedOperation, backoff, err = step.Run(processedOperation, logger)
+		stepErr := err
+		if policy != nil {
+			processedOperation, backoff, err = m.applyRetryPolicy(step.Name(), *policy, processedOperation, backoff, err, logger)
+		}
 		if err != nil {
 			processedOperation.LastError = kebError.ReasonForError(err)
 			logOperation := m.log.WithFields(logrus.Fields{"operation": processedOperation.ID, "error_component": processedOperation.LastError.Component(), "error_reason": processedOperation.LastError.Reason()})
 			logOperation.Errorf("Last error from step %s: %s", step.Name(), processedOperation.LastError.Error())
 			// only save to storage, skip for alerting if error
-			_, err = m.operationStorage.UpdateOperation(processedOperation)
+			var op *internal.Operation
+			op, err = m.operationStorage.UpdateOperation(processedOperation)
 			if err != nil {
 				logOperation.Errorf("Unable to save operation with resolved last error from step: %s", step.Name())
+			} else {
+				processedOperation = *op
 			}
 		}
 
 		m.publisher.Publish(context.TODO(), OperationStepProcessed{
 			StepProcessed: StepProcessed{
-				StepName: step.Name(),
-				Duration: time.Since(start),
-				When:     backoff,
-				Error:    err,
+				StepName:  step.Name(),
+				Stage:     stageName,
+				StartedAt: start,
+				Duration:  time.Since(start),
+				When:      backoff,
+				Error:     err,
+				StepError: stepErr,
 			},
 			Operation:    processedOperation,
 			OldOperation: operation,
@@ -257,6 +410,165 @@ func (m *StagedManager) runStep(step Step, operation internal.Operation, logger
 	}
 }
 
+// applyRetryPolicy calculates the backoff of the step from its retry policy and fails the operation when the policy is exhausted
+func (m *StagedManager) applyRetryPolicy(stepName string, policy RetryPolicy, operation internal.Operation, backoff time.Duration, err error, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if operation.State == domain.Failed || operation.State == domain.Succeeded {
+		return operation, backoff, err
+	}
+	if err != nil && !policy.IsRetryable(err) {
+		logger.Errorf("Step returned error which is not retryable: %s", err)
+		return NewOperationManager(m.operationStorage).OperationFailed(operation, fmt.Sprintf("step %s failed", stepName), err, logger)
+	}
+	if err == nil && backoff == 0 {
+		return operation, backoff, err
+	}
+
+	retries := operation.RetryStep(stepName)
+	if err != nil {
+		operation.LastError = kebError.ReasonForError(err)
+		logger.Warnf("Step returned retryable error: %s", err)
+	}
+	if policy.Exhausted(retries.Attempts, retries.FirstAttemptAt) {
+		logger.Errorf("Aborting after %d attempts of the step, retry policy %s exhausted", retries.Attempts-1, policy)
+		return NewOperationManager(m.operationStorage).OperationFailed(operation, fmt.Sprintf("step %s exceeded the retry policy", stepName), err, logger)
+	}
+
+	op, updateErr := m.operationStorage.UpdateOperation(operation)
+	if updateErr != nil {
+		logger.Errorf("Unable to save operation with step retries: %s", updateErr)
+		return operation, defaultRetryInterval, nil
+	}
+	return *op, policy.Backoff(retries.Attempts, backoff), nil
+}
+
+// failOnRequest fails the operation on the request of an administrator, the failure is handled as a failure of a step
+func (m *StagedManager) failOnRequest(operation internal.Operation, leaseLost <-chan struct{}, log logrus.FieldLogger) (time.Duration, error) {
+	log.Infof("Failing the operation on the request of an administrator")
+	failureErr := fmt.Errorf("operation failed by an administrator")
+	operation.FailureRequested = false
+	operation.LastError = kebError.ReasonForError(failureErr)
+	failedOperation, when, _ := NewOperationManager(m.operationStorage).OperationFailed(operation,
+		fmt.Sprintf("Operation failed by an administrator: %s", operation.Description), nil, log)
+	if when > 0 {
+		return when, nil
+	}
+	m.callPubSubOutsideSteps(&failedOperation, failureErr)
+
+	if m.requiresCompensation(failedOperation) {
+		return m.startCompensation(failedOperation, leaseLost, log)
+	}
+	return 0, nil
+}
+
+// requiresCompensation returns true if the failed provisioning or update operation has executed steps which must be compensated
+func (m *StagedManager) requiresCompensation(operation internal.Operation) bool {
+	if operation.State != domain.Failed {
+		return false
+	}
+	if operation.Type != internal.OperationTypeProvision && operation.Type != internal.OperationTypeUpdate {
+		return false
+	}
+	return len(operation.PendingCompensations()) > 0
+}
+
+// startCompensation marks the failed operation as being compensated. The state of the operation remains failed,
+// the compensation progress is tracked by the CompensationInProgress flag.
+func (m *StagedManager) startCompensation(operation internal.Operation, leaseLost <-chan struct{}, log logrus.FieldLogger) (time.Duration, error) {
+	log.Infof("Operation failed, starting compensation of steps: %s", strings.Join(operation.PendingCompensations(), ", "))
+	operation.EventInfof("starting compensation of %d steps", len(operation.PendingCompensations()))
+
+	operation.CompensationInProgress = true
+	operation.CompensationStartedAt = time.Now()
+	op, err := m.operationStorage.UpdateOperation(operation)
+	if err != nil {
+		log.Errorf("Unable to save operation with started compensation: %s", err)
+		return time.Second, nil
+	}
+	return m.compensate(*op, leaseLost, log)
+}
+
+func (m *StagedManager) compensate(operation internal.Operation, leaseLost <-chan struct{}, log logrus.FieldLogger) (time.Duration, error) {
+	for _, stepName := range operation.PendingCompensations() {
+		if isClosed(leaseLost) {
+			log.Warnf("The lease of the operation was lost, the compensation is continued by the lease owner")
+			return 0, nil
+		}
+		logStep := log.WithField("step", stepName)
+		step, found := m.compensatingStep(stepName)
+		switch {
+		case m.operationTimeout > 0 && time.Since(operation.CompensationStartedAt) > m.operationTimeout:
+			logStep.Errorf("Compensation has reached the time limit, the step must be cleaned up manually")
+			operation.EventErrorf(kebError.TimeoutError("compensation has reached the time limit"), "compensation of step %v failed", stepName)
+			operation.FailCompensation(stepName)
+		case !found:
+			logStep.Errorf("Compensating step is not registered, the step must be cleaned up manually")
+			operation.EventErrorf(fmt.Errorf("step %s is not registered", stepName), "compensation of step %v failed", stepName)
+			operation.FailCompensation(stepName)
+		default:
+			processedOperation, when, err := m.runCompensation(step, operation, logStep)
+			switch {
+			case err != nil:
+				// the compensation is a best effort, the failure must not block compensation of other steps
+				logStep.Errorf("Compensation failed, the step must be cleaned up manually: %s", err)
+				operation.EventErrorf(err, "compensation of step %v failed", stepName)
+				operation.FailCompensation(stepName)
+			case when > 0:
+				logStep.Warnf("retrying compensation by restarting the operation in %d s", int64(when.Seconds()))
+				return when, nil
+			default:
+				operation = processedOperation
+				operation.CompensateStep(stepName)
+				operation.EventInfof("step %v compensated", stepName)
+			}
+		}
+
+		op, err := m.operationStorage.UpdateOperation(operation)
+		if err != nil {
+			logStep.Errorf("Unable to save operation with compensation progress: %s", err)
+			return time.Second, nil
+		}
+		operation = *op
+	}
+
+	if len(operation.FailedCompensations) > 0 {
+		log.Warnf("Compensation finished, steps which must be cleaned up manually: %s", strings.Join(operation.FailedCompensations, ", "))
+	} else {
+		log.Infof("Compensation finished")
+	}
+	operation.CompensationInProgress = false
+	_, err := m.operationStorage.UpdateOperation(operation)
+	if err != nil {
+		log.Errorf("Unable to save operation with finished compensation: %s", err)
+		return time.Second, nil
+	}
+	operation.EventInfof("compensation finished")
+
+	return 0, nil
+}
+
+func (m *StagedManager) runCompensation(step CompensatingStep, operation internal.Operation, logger logrus.FieldLogger) (processedOperation internal.Operation, backoff time.Duration, err error) {
+	defer func() {
+		if pErr := recover(); pErr != nil {
+			log.Println("panic in compensation in staged manager: ", pErr)
+			err = errors.New(fmt.Sprintf("%v", pErr))
+		}
+	}()
+
+	logger.Infof("Start compensation")
+	return step.Compensate(operation, logger)
+}
+
+func (m *StagedManager) compensatingStep(name string) (CompensatingStep, bool) {
+	for _, s := range m.stages {
+		for _, step := range s.steps {
+			if cs, ok := step.Step.(CompensatingStep); ok && step.Name() == name {
+				return cs, true
+			}
+		}
+	}
+	return nil, false
+}
+
 func (m *StagedManager) callPubSubOutsideSteps(operation *internal.Operation, err error) {
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operation.ID, "error_component": operation.LastError.Component(), "error_reason": operation.LastError.Reason()})
 	logOperation.Errorf("Last error: %s", operation.LastError.Error())
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
@@ -17,10 +18,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -138,6 +142,661 @@ func TestSkipFinishedStage(t *testing.T) {
 	assert.True(t, op.IsStageFinished("stage-2"))
 }
 
+func TestSkipStageSplitFromFinishedStage(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.FinishStage("stage-2")
+
+	mgr, _, eventCollector := SetupStagedManager(operation)
+	mgr.DefineStages([]string{"stage-1", "stage-2", "stage-3"})
+	mgr.DefineSplitStages("stage-2", "stage-1")
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-3", &testingStep{name: "first-3", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, _ := mgr.Execute(operation.ID)
+
+	// then
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first-3"})
+}
+
+func TestExecuteStageBeforeFinishedStage(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.FinishStage("stage-2")
+
+	mgr, _, eventCollector := SetupStagedManager(operation)
+	mgr.DefineStages([]string{"stage-1", "stage-2", "stage-3"})
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-3", &testingStep{name: "first-3", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, _ := mgr.Execute(operation.ID)
+
+	// then
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "first-3"})
+}
+
+func TestInputCreatorSavedWithFinishedStage(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.InputCreator = fixture.FixInputCreator(internal.Azure).SetShootName("c-1234567")
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	mgr.Execute(operation.ID)
+
+	// then
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.True(t, op.IsStageFinished("stage-1"))
+	require.NotNil(t, op.ProvisionerInput)
+	assert.Equal(t, "c-1234567", *op.ProvisionerInput.ShootName)
+}
+
+func TestInputCreatorRestored(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.FinishStage("stage-1")
+	operation.ProvisionerInput = &internal.ProvisionerInput{ShootName: ptr.String("c-1234567")}
+
+	mgr, _, eventCollector := SetupStagedManager(operation)
+	restorer := &inputCreatorRestorer{}
+	mgr.UseInputCreatorRestorer(restorer)
+	step := &inputCreatorStep{testingStep: testingStep{name: "first-2", eventPublisher: eventCollector}}
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", step, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first-2"})
+	assert.Equal(t, []string{"c-1234567"}, restorer.restoredShootNames)
+	assert.True(t, step.inputCreatorProvided)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-1", &retryingStep{name: "second", retries: 2, eventPublisher: eventCollector}, nil,
+		process.WithRetryPolicy(process.RetryPolicy{InitialInterval: time.Millisecond, MaxAttempts: 3}))
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "second", "second", "second", "first-2"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Succeeded, op.State)
+	assert.Equal(t, 2, op.StepRetries["second"].Attempts)
+}
+
+func TestRetryPolicyExhausted(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 5, eventPublisher: eventCollector}, nil,
+		process.WithRetryPolicy(process.RetryPolicy{InitialInterval: time.Millisecond, MaxAttempts: 2}))
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, _ := mgr.Execute(operation.ID)
+
+	// then
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "first", "first"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.Equal(t, 3, op.StepRetries["first"].Attempts)
+	assert.False(t, op.IsStageFinished("stage-1"))
+}
+
+func TestRetryPolicyWithRetryableError(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 1, err: kebError.TimeoutError("timeout"), eventPublisher: eventCollector}, nil,
+		process.WithRetryPolicy(process.RetryPolicy{InitialInterval: time.Millisecond, RetryableReasons: []kebError.ErrReason{kebError.ErrKEBTimeOut}}))
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "first"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Succeeded, op.State)
+	assert.Equal(t, 1, op.StepRetries["first"].Attempts)
+}
+
+func TestRetryPolicyWithNotRetryableError(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 1, err: fmt.Errorf("some error"), eventPublisher: eventCollector}, nil,
+		process.WithRetryPolicy(process.RetryPolicy{InitialInterval: time.Millisecond, RetryableReasons: []kebError.ErrReason{kebError.ErrKEBTimeOut}}))
+
+	// when
+	mgr.Execute(operation.ID)
+
+	// then
+	eventCollector.AssertProcessedSteps(t, []string{"first"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.Equal(t, "step first failed: some error", op.LastError.Error())
+	assert.Empty(t, op.StepRetries)
+}
+
+func TestCompensationAfterFailure(t *testing.T) {
+	for _, opType := range []internal.OperationType{internal.OperationTypeProvision, internal.OperationTypeUpdate} {
+		t.Run(string(opType), func(t *testing.T) {
+			// given
+			operation := FixOperation("op-0001234")
+			operation.Type = opType
+			mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+			mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+			mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+			mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "third", eventPublisher: eventCollector}}, nil)
+			mgr.AddStep("stage-2", &failingStep{name: "first-2", operationManager: process.NewOperationManager(operationStorage), eventPublisher: eventCollector}, nil)
+			mgr.AddStep("stage-2", &compensatingStep{testingStep: testingStep{name: "second-2", eventPublisher: eventCollector}}, nil)
+
+			// when
+			retry, err := mgr.Execute(operation.ID)
+
+			// then
+			assert.NoError(t, err)
+			assert.Zero(t, retry)
+			eventCollector.AssertProcessedSteps(t, []string{"first", "second", "third", "first-2"})
+			eventCollector.AssertCompensatedSteps(t, []string{"third", "first"})
+			op, _ := operationStorage.GetOperationByID(operation.ID)
+			assert.Equal(t, domain.Failed, op.State)
+			assert.False(t, op.CompensationInProgress)
+			assert.Equal(t, []string{"third", "first"}, op.CompensatedSteps)
+			assert.Empty(t, op.FailedCompensations)
+		})
+	}
+}
+
+func TestCompensationResumed(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.State = domain.Failed
+	operation.CompensableSteps = []string{"first", "third"}
+	operation.CompensatedSteps = []string{"third"}
+	operation.CompensationInProgress = true
+	operation.CompensationStartedAt = time.Now()
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+	mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "third", eventPublisher: eventCollector}}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertCompensatedSteps(t, []string{"first"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.False(t, op.CompensationInProgress)
+}
+
+func TestCompensationFailures(t *testing.T) {
+	for name, failing := range map[string]process.Step{
+		"error": &failingCompensationStep{testingStep: testingStep{name: "second"}},
+		"panic": &panicCompensationStep{testingStep: testingStep{name: "second"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			operation := FixOperation("op-0001234")
+			mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+			setEventPublisher(failing, eventCollector)
+			mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+			mgr.AddStep("stage-1", failing, nil)
+			mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "third", eventPublisher: eventCollector}}, nil)
+			mgr.AddStep("stage-2", &failingStep{name: "first-2", operationManager: process.NewOperationManager(operationStorage), eventPublisher: eventCollector}, nil)
+
+			// when
+			retry, err := mgr.Execute(operation.ID)
+
+			// then
+			assert.NoError(t, err)
+			assert.Zero(t, retry)
+			eventCollector.AssertCompensatedSteps(t, []string{"third", "first"})
+			op, _ := operationStorage.GetOperationByID(operation.ID)
+			assert.Equal(t, domain.Failed, op.State)
+			assert.False(t, op.CompensationInProgress)
+			assert.Equal(t, []string{"third", "first"}, op.CompensatedSteps)
+			assert.Equal(t, []string{"second"}, op.FailedCompensations)
+		})
+	}
+}
+
+func TestCompensationWithRetry(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+	mgr.AddStep("stage-1", &onceRetryingCompensationStep{testingStep: testingStep{name: "second", eventPublisher: eventCollector}}, nil)
+	mgr.AddStep("stage-2", &failingStep{name: "first-2", operationManager: process.NewOperationManager(operationStorage), eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, time.Millisecond, retry)
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.True(t, op.CompensationInProgress)
+	assert.Empty(t, op.CompensatedSteps)
+
+	// when
+	retry, err = mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertCompensatedSteps(t, []string{"second", "first"})
+	op, _ = operationStorage.GetOperationByID(operation.ID)
+	assert.False(t, op.CompensationInProgress)
+	assert.Equal(t, []string{"second", "first"}, op.CompensatedSteps)
+}
+
+func TestCompensationAfterOperationTimeout(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.CreatedAt = time.Now().Add(-time.Hour)
+	operation.CompensableSteps = []string{"first"}
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertCompensatedSteps(t, []string{"first"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.False(t, op.CompensationInProgress)
+	assert.Equal(t, []string{"first"}, op.CompensatedSteps)
+}
+
+func TestCompensationTimeout(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.State = domain.Failed
+	operation.CompensableSteps = []string{"first", "second"}
+	operation.CompensationInProgress = true
+	operation.CompensationStartedAt = time.Now().Add(-time.Hour)
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+	mgr.AddStep("stage-1", &onceRetryingCompensationStep{testingStep: testingStep{name: "second", eventPublisher: eventCollector}}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertCompensatedSteps(t, []string{})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.False(t, op.CompensationInProgress)
+	assert.Equal(t, []string{"second", "first"}, op.FailedCompensations)
+}
+
+func TestCompensationWithoutOperationTimeout(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.State = domain.Failed
+	operation.CompensableSteps = []string{"first"}
+	operation.CompensationInProgress = true
+	operation.CompensationStartedAt = time.Now().Add(-time.Hour)
+	memoryStorage := storage.NewMemoryStorage()
+	memoryStorage.Operations().InsertOperation(operation)
+	eventCollector := &CollectingEventHandler{}
+	mgr := process.NewStagedManager(memoryStorage.Operations(), eventCollector, 0, process.StagedManagerConfiguration{MaxStepProcessingTime: time.Second}, logrus.New())
+	mgr.DefineStages([]string{"stage-1"})
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertCompensatedSteps(t, []string{"first"})
+	op, _ := memoryStorage.Operations().GetOperationByID(operation.ID)
+	assert.Equal(t, []string{"first"}, op.CompensatedSteps)
+	assert.Empty(t, op.FailedCompensations)
+}
+
+func TestNoCompensationForDeprovisioning(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.Type = internal.OperationTypeDeprovision
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+	mgr.AddStep("stage-2", &failingStep{name: "first-2", operationManager: process.NewOperationManager(operationStorage), eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "first-2"})
+	eventCollector.AssertCompensatedSteps(t, []string{})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.False(t, op.CompensationInProgress)
+	assert.Empty(t, op.CompensatedSteps)
+}
+
+func TestPausedOperation(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.Paused = true
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.InProgress, op.State)
+}
+
+func TestFailureRequested(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.FailureRequested = true
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	step := &testingStep{name: "first", eventPublisher: eventCollector}
+	mgr.AddStep("stage-1", step, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.False(t, op.FailureRequested)
+	assert.Equal(t, "operation failed by an administrator", op.LastError.Error())
+}
+
+func TestInterventionBetweenSteps(t *testing.T) {
+	for name, tc := range map[string]struct {
+		intervene     func(operation *internal.Operation)
+		expectedState domain.LastOperationState
+		compensated   []string
+	}{
+		"pause": {
+			intervene:     func(operation *internal.Operation) { operation.Paused = true },
+			expectedState: domain.InProgress,
+			compensated:   []string{},
+		},
+		"failure": {
+			intervene:     func(operation *internal.Operation) { operation.FailureRequested = true },
+			expectedState: domain.Failed,
+			compensated:   []string{"first"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			operation := FixOperation("op-0001234")
+			mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+			mgr.AddStep("stage-1", &compensatingStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}}, nil)
+			mgr.AddStep("stage-1", &interveningStep{testingStep: testingStep{name: "second", eventPublisher: eventCollector}, operations: operationStorage, intervene: tc.intervene}, nil)
+			mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil)
+
+			// when
+			retry, err := mgr.Execute(operation.ID)
+
+			// then
+			assert.NoError(t, err)
+			assert.Zero(t, retry)
+			eventCollector.AssertExecutedSteps(t, []string{"first", "second"})
+			eventCollector.AssertCompensatedSteps(t, tc.compensated)
+			op, _ := operationStorage.GetOperationByID(operation.ID)
+			assert.Equal(t, tc.expectedState, op.State)
+		})
+	}
+}
+
+func TestSkippedStep(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.SkipStep("second")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	mgr.Execute(operation.ID)
+
+	// then
+	eventCollector.AssertProcessedSteps(t, []string{"first", "first-2"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Succeeded, op.State)
+}
+
+func TestFinishedOperation(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.State = domain.Failed
+	mgr, _, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{})
+}
+
+func TestCanceledOperation(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.Type = internal.OperationTypeUpgradeKyma
+	operation.State = orchestration.Canceled
+	mgr, _, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{})
+}
+
+func TestWithoutOperationTimeout(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.Type = internal.OperationTypeUpgradeCluster
+	operation.CreatedAt = time.Now().Add(-24 * time.Hour)
+	memoryStorage := storage.NewMemoryStorage()
+	err := memoryStorage.Operations().InsertOperation(operation)
+	assert.NoError(t, err)
+	eventCollector := &CollectingEventHandler{}
+	mgr := process.NewStagedManager(memoryStorage.Operations(), eventCollector, 0, process.StagedManagerConfiguration{}, logrus.New())
+	mgr.DefineStages([]string{"stage-1"})
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.WaitForEvents(t, 1)
+	eventCollector.AssertProcessedSteps(t, []string{"first"})
+	op, _ := memoryStorage.Operations().GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Succeeded, op.State)
+}
+
+func TestReschedule(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.Type = internal.OperationTypeUpgradeKyma
+	operation.State = orchestration.Pending
+	mgr, operationStorage, _ := SetupStagedManager(operation)
+	begin := time.Now().Add(time.Hour).Truncate(time.Second)
+	end := begin.Add(4 * time.Hour)
+
+	// when
+	err := mgr.Reschedule(operation.ID, begin, end)
+
+	// then
+	assert.NoError(t, err)
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, begin, op.MaintenanceWindowBegin)
+	assert.Equal(t, end, op.MaintenanceWindowEnd)
+	assert.Equal(t, domain.LastOperationState(orchestration.Pending), op.State)
+}
+
+func TestLeases(t *testing.T) {
+	leaseCfg := process.LeaseConfiguration{Enabled: true, TTL: time.Minute, HeartbeatInterval: time.Second}
+
+	t.Run("should not execute operation leased by another replica", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, _, eventCollector := SetupStagedManager(operation)
+		mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+		leases := storage.NewMemoryStorage().OperationLeases()
+		mgr.UseLeases(leases, "replica-1", leaseCfg)
+		_, err := leases.Acquire(operation.ID, "replica-2", time.Minute)
+		assert.NoError(t, err)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, retry)
+		eventCollector.AssertProcessedSteps(t, []string{})
+		lease, err := leases.GetByOperationID(operation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "replica-2", lease.Owner)
+	})
+
+	t.Run("should take over expired lease and release it after processing", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+		mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+		leases := storage.NewMemoryStorage().OperationLeases()
+		mgr.UseLeases(leases, "replica-1", leaseCfg)
+		_, err := leases.Acquire(operation.ID, "replica-2", -time.Second)
+		assert.NoError(t, err)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"first"})
+		op, _ := operationStorage.GetOperationByID(operation.ID)
+		assert.Equal(t, domain.Succeeded, op.State)
+		_, err = leases.GetByOperationID(operation.ID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should keep the lease while the operation is retried", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, _, eventCollector := SetupStagedManager(operation)
+		mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 1000000, eventPublisher: eventCollector}, nil)
+		leases := storage.NewMemoryStorage().OperationLeases()
+		mgr.UseLeases(leases, "replica-1", leaseCfg)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, retry)
+		lease, err := leases.GetByOperationID(operation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "replica-1", lease.Owner)
+	})
+
+	t.Run("should stop processing when the lease was taken over by another replica", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+		leases := storage.NewMemoryStorage().OperationLeases()
+		mgr.AddStep("stage-1", &takingOverLeaseStep{testingStep: testingStep{name: "first", eventPublisher: eventCollector}, leases: leases}, nil)
+		mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+		mgr.UseLeases(leases, "replica-1", process.LeaseConfiguration{Enabled: true, TTL: time.Minute, HeartbeatInterval: time.Millisecond})
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"first"})
+		op, _ := operationStorage.GetOperationByID(operation.ID)
+		assert.Equal(t, domain.InProgress, op.State)
+		lease, err := leases.GetByOperationID(operation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "replica-2", lease.Owner)
+	})
+
+	t.Run("should not execute operation processed by another replica", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = domain.Succeeded
+		mgr, _, eventCollector := SetupStagedManager(operation)
+		mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+		leases := storage.NewMemoryStorage().OperationLeases()
+		mgr.UseLeases(leases, "replica-1", leaseCfg)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{})
+		_, err = leases.GetByOperationID(operation.ID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
+
 func SetupStagedManager(op internal.Operation) (*process.StagedManager, storage.Operations, *CollectingEventHandler) {
 	memoryStorage := storage.NewMemoryStorage()
 	memoryStorage.Operations().InsertOperation(op)
@@ -166,6 +825,25 @@ func (s *testingStep) Run(operation internal.Operation, logger logrus.FieldLogge
 	return operation, 0, nil
 }
 
+type inputCreatorStep struct {
+	testingStep
+	inputCreatorProvided bool
+}
+
+func (s *inputCreatorStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	s.inputCreatorProvided = operation.InputCreator != nil
+	return s.testingStep.Run(operation, logger)
+}
+
+type inputCreatorRestorer struct {
+	restoredShootNames []string
+}
+
+func (r *inputCreatorRestorer) Restore(operation internal.Operation) (internal.ProvisionerInputCreator, error) {
+	r.restoredShootNames = append(r.restoredShootNames, *operation.ProvisionerInput.ShootName)
+	return fixture.FixInputCreator(internal.Azure), nil
+}
+
 type onceRetryingStep struct {
 	name           string
 	processed      bool
@@ -202,6 +880,122 @@ func (s *panicStep) Run(operation internal.Operation, logger logrus.FieldLogger)
 	return operation, 0, nil
 }
 
+type compensatingStep struct {
+	testingStep
+}
+
+func (s *compensatingStep) Compensate(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	logger.Infof("Compensating")
+	s.eventPublisher.Publish(context.Background(), compensatedStep(s.name))
+	return operation, 0, nil
+}
+
+// retryingStep needs the given number of retries, the retry is requested by a backoff or by the given error
+type retryingStep struct {
+	name           string
+	retries        int
+	err            error
+	eventPublisher event.Publisher
+}
+
+func (s *retryingStep) Name() string {
+	return s.name
+}
+
+func (s *retryingStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	if s.retries == 0 {
+		return operation, 0, nil
+	}
+	s.retries--
+	if s.err != nil {
+		return operation, 0, s.err
+	}
+	return operation, time.Minute, nil
+}
+
+// takingOverLeaseStep simulates another replica which takes over the lease of the operation while the step is running
+type takingOverLeaseStep struct {
+	testingStep
+	leases storage.OperationLeases
+}
+
+func (s *takingOverLeaseStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	_ = s.leases.Release(operation.ID, "replica-1")
+	_, _ = s.leases.Acquire(operation.ID, "replica-2", time.Minute)
+	// give the heartbeat time to notice the lost lease
+	time.Sleep(100 * time.Millisecond)
+	return s.testingStep.Run(operation, logger)
+}
+
+// interveningStep simulates an administrator who changes the operation while the step is running
+type interveningStep struct {
+	testingStep
+	operations storage.Operations
+	intervene  func(operation *internal.Operation)
+}
+
+func (s *interveningStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	current, _ := s.operations.GetOperationByID(operation.ID)
+	s.intervene(current)
+	_, _ = s.operations.UpdateOperation(*current)
+	return s.testingStep.Run(operation, logger)
+}
+
+type failingCompensationStep struct {
+	testingStep
+}
+
+func (s *failingCompensationStep) Compensate(operation internal.Operation, _ logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	return operation, 0, fmt.Errorf("compensation failed just for test")
+}
+
+type panicCompensationStep struct {
+	testingStep
+}
+
+func (s *panicCompensationStep) Compensate(operation internal.Operation, _ logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	panic("Panicking just for test")
+}
+
+type onceRetryingCompensationStep struct {
+	testingStep
+	processed bool
+}
+
+func (s *onceRetryingCompensationStep) Compensate(operation internal.Operation, _ logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if !s.processed {
+		s.processed = true
+		return operation, time.Millisecond, nil
+	}
+	s.eventPublisher.Publish(context.Background(), compensatedStep(s.name))
+	return operation, 0, nil
+}
+
+func setEventPublisher(step process.Step, publisher event.Publisher) {
+	switch s := step.(type) {
+	case *failingCompensationStep:
+		s.eventPublisher = publisher
+	case *panicCompensationStep:
+		s.eventPublisher = publisher
+	}
+}
+
+type failingStep struct {
+	name             string
+	operationManager *process.OperationManager
+	eventPublisher   event.Publisher
+}
+
+func (s *failingStep) Name() string {
+	return s.name
+}
+
+func (s *failingStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	return s.operationManager.OperationFailed(operation, "failing just for test", nil, logger)
+}
+
 func fixProvisioningParametersWithPlanID(planID, region string) internal.ProvisioningParameters {
 	return internal.ProvisioningParameters{
 		PlanID:    planID,
@@ -228,11 +1022,14 @@ func FixOperation(ID string) internal.Operation {
 }
 
 type CollectingEventHandler struct {
-	mu             sync.Mutex
-	StepsProcessed []string // collects events from the Manager
-	stepsExecuted  []string // collects events from testing steps
+	mu               sync.Mutex
+	StepsProcessed   []string // collects events from the Manager
+	stepsExecuted    []string // collects events from testing steps
+	stepsCompensated []string // collects compensation events from testing steps
 }
 
+type compensatedStep string
+
 func (h *CollectingEventHandler) OnStepExecuted(_ context.Context, ev interface{}) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -253,6 +1050,20 @@ func (h *CollectingEventHandler) Publish(ctx context.Context, ev interface{}) {
 		h.OnStepProcessed(ctx, ev)
 	case string:
 		h.OnStepExecuted(ctx, ev)
+	case compensatedStep:
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.stepsCompensated = append(h.stepsCompensated, string(ev.(compensatedStep)))
+	}
+}
+
+func (h *CollectingEventHandler) AssertCompensatedSteps(t *testing.T, stepNames []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	assert.Len(t, h.stepsCompensated, len(stepNames))
+	for i := 0; i < len(stepNames) && i < len(h.stepsCompensated); i++ {
+		assert.Equal(t, stepNames[i], h.stepsCompensated[i])
 	}
 }
 
@@ -262,6 +1073,13 @@ func (h *CollectingEventHandler) WaitForEvents(t *testing.T, count int) {
 	}))
 }
 
+func (h *CollectingEventHandler) AssertExecutedSteps(t *testing.T, stepNames []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	assert.Equal(t, stepNames, h.stepsExecuted)
+}
+
 func (h *CollectingEventHandler) AssertProcessedSteps(t *testing.T, stepNames []string) {
 	h.WaitForEvents(t, len(stepNames))
 	h.mu.Lock()
@@ -83,11 +83,14 @@ func (s *checkGardenerCluster) GetGardenerCluster(name string, namespace string)
 	return gc, nil
 }
 
+// syncGardenerCluster returns the errors of the KCP requests, the retry policy of the step decides if the operation is retried or failed
 type syncGardenerCluster struct {
 	k8sClient        client.Client
 	operationManager *process.OperationManager
 }
 
+var _ process.CompensatingStep = &syncGardenerCluster{}
+
 func (_ *syncGardenerCluster) Name() string {
 	return "Sync_GardenerCluster"
 }
@@ -106,7 +109,7 @@ func (s *syncGardenerCluster) Run(operation internal.Operation, log logrus.Field
 	gardenerCluster, err := s.GetOrCreateNewGardenerCluster(operation.RuntimeID, operation.KymaResourceNamespace)
 	if err != nil {
 		log.Errorf("unable to get GardenerCluster %s/%s", operation.KymaResourceNamespace, operation.RuntimeID)
-		return s.operationManager.RetryOperation(operation, "unable to get GardenerCluster", err, 3*time.Second, 20*time.Second, log)
+		return operation, 0, fmt.Errorf("unable to get GardenerCluster: %w", err)
 	}
 	gardenerCluster.SetShootName(operation.ShootName)
 	gardenerCluster.SetKubecofigSecret(fmt.Sprintf("kubeconfig-%s", operation.RuntimeID), operation.KymaResourceNamespace)
@@ -118,19 +121,37 @@ func (s *syncGardenerCluster) Run(operation internal.Operation, log logrus.Field
 		err := s.k8sClient.Update(context.Background(), obj)
 		if err != nil {
 			log.Errorf("unable to update GardenerCluster %s/%s: %s", operation.KymaResourceNamespace, operation.RuntimeID, err.Error())
-			return s.operationManager.RetryOperation(operation, "unable to update GardenerCluster", err, 3*time.Second, 20*time.Second, log)
+			return operation, 0, fmt.Errorf("unable to update GardenerCluster: %w", err)
 		}
 	} else {
 		err := s.k8sClient.Create(context.Background(), obj)
 		if err != nil {
-			log.Errorf("unable to create GardenerCluster %s/%s: ", operation.KymaResourceNamespace, operation.RuntimeID, err.Error())
-			return s.operationManager.RetryOperation(operation, "unable to create GardenerCluster", err, 3*time.Second, 20*time.Second, log)
+			log.Errorf("unable to create GardenerCluster %s/%s: %s", operation.KymaResourceNamespace, operation.RuntimeID, err.Error())
+			return operation, 0, fmt.Errorf("unable to create GardenerCluster: %w", err)
 		}
 	}
 
 	return operation, 0, nil
 }
 
+// Compensate deletes the GardenerCluster resource created by the operation
+func (s *syncGardenerCluster) Compensate(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if operation.GardenerClusterName == "" {
+		return operation, 0, nil
+	}
+
+	log.Infof("Deleting GardenerCluster resource: %s in namespace: %s", operation.GardenerClusterName, operation.KymaResourceNamespace)
+	gardenerCluster := &unstructured.Unstructured{}
+	gardenerCluster.SetGroupVersionKind(GardenerClusterGVK())
+	gardenerCluster.SetName(operation.GardenerClusterName)
+	gardenerCluster.SetNamespace(operation.KymaResourceNamespace)
+	err := s.k8sClient.Delete(context.Background(), gardenerCluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return operation, 0, fmt.Errorf("while deleting GardenerCluster: %w", err)
+	}
+	return operation, 0, nil
+}
+
 func (s *syncGardenerCluster) GetOrCreateNewGardenerCluster(name, namespace string) (*GardenerCluster, error) {
 	gardenerCluster := NewGardenerCluster(name, namespace)
 	existing := &unstructured.Unstructured{}
@@ -2,17 +2,25 @@ package steps
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -211,3 +219,92 @@ func assertGardenerClusterSpec(t *testing.T, s string, k8sClient client.Client)
 
 	assert.Equal(t, expected.Object["spec"], existing.Object["spec"])
 }
+
+func TestSyncGardenerCluster_Compensate(t *testing.T) {
+	// given
+	os := storage.NewMemoryStorage().Operations()
+	k8sClient := fake.NewClientBuilder().Build()
+	svc := NewSyncGardenerCluster(os, k8sClient)
+	operation := fixture.FixProvisioningOperation("op", "instance-id")
+	operation.KymaResourceNamespace = "kcp-system"
+	operation.RuntimeID = "runtime-id-000"
+	operation.ShootName = "c-12345"
+	os.InsertOperation(operation)
+	operation, _, err := svc.Run(operation, logrus.New())
+	require.NoError(t, err)
+
+	// when
+	_, backoff, err := svc.Compensate(operation, logrus.New())
+
+	// then
+	assert.Zero(t, backoff)
+	assert.NoError(t, err)
+	got := &unstructured.Unstructured{}
+	got.SetGroupVersionKind(GardenerClusterGVK())
+	err = k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "kcp-system", Name: "runtime-id-000"}, got)
+	assert.True(t, errors.IsNotFound(err))
+
+	// when the resource is already deleted
+	_, _, err = svc.Compensate(operation, logrus.New())
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestSyncGardenerCluster_RetryPolicy(t *testing.T) {
+	for name, tc := range map[string]struct {
+		createErr     error
+		expectedState domain.LastOperationState
+	}{
+		"should retry the temporary KCP error": {
+			createErr:     errors.NewServiceUnavailable("unavailable"),
+			expectedState: domain.Succeeded,
+		},
+		"should fail the operation with the permanent KCP error": {
+			createErr:     errors.NewForbidden(schema.GroupResource{}, "runtime-id-000", fmt.Errorf("forbidden")),
+			expectedState: domain.Failed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixture.FixProvisioningOperation("op", "instance-id")
+			operation.State = domain.InProgress
+			operation.KymaResourceNamespace = "kcp-system"
+			operation.RuntimeID = "runtime-id-000"
+			require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+			k8sClient := &failingCreateClient{Client: fake.NewClientBuilder().Build(), err: tc.createErr}
+			mgr := process.NewStagedManager(memoryStorage.Operations(), event.NewPubSub(nil), time.Hour, process.StagedManagerConfiguration{MaxStepProcessingTime: time.Minute}, logrus.New())
+			mgr.SpeedUp(100000)
+			mgr.DefineStages([]string{"create_runtime"})
+			require.NoError(t, mgr.AddStep("create_runtime", NewSyncGardenerCluster(memoryStorage.Operations(), k8sClient), nil,
+				process.WithRetryPolicy(process.RetryPolicy{InitialInterval: time.Second, MaxAttempts: 3, RetryableReasons: []kebError.ErrReason{
+					kebError.ErrReason(metav1.StatusReasonServiceUnavailable),
+				}})))
+
+			// when
+			_, _ = mgr.Execute(operation.ID)
+
+			// then
+			op, err := memoryStorage.Operations().GetOperationByID(operation.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedState, op.State)
+			assert.Equal(t, 1, k8sClient.failures)
+		})
+	}
+}
+
+// failingCreateClient fails the first creation of a resource
+type failingCreateClient struct {
+	client.Client
+	err      error
+	failures int
+}
+
+func (c *failingCreateClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.failures == 0 {
+		c.failures++
+		return c.err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
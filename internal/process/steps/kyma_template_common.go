@@ -2,8 +2,11 @@ package steps
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 
+	"github.com/kyma-project/kyma-environment-broker/internal"
+
 	"gopkg.in/yaml.v2"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -37,3 +40,33 @@ func EncodeKymaTemplate(kymaTemplate *unstructured.Unstructured) (string, error)
 	}
 	return string(result), nil
 }
+
+// ReplaceKymaModules sets the spec.modules section of the Kyma resource to the given modules,
+// the empty channel and customResourcePolicy are not set, so the defaults of the lifecycle manager are used
+func ReplaceKymaModules(kyma *unstructured.Unstructured, modules []internal.ModuleDTO) error {
+	// if field is "" convert it to nil to field will be not present in yaml
+	mapIfNeeded := func(field *string) *string {
+		if field != nil && *field == "" {
+			return nil
+		}
+		return field
+	}
+
+	section := make([]internal.ModuleDTO, 0, len(modules))
+	for _, customModule := range modules {
+		module := internal.ModuleDTO{Name: customModule.Name}
+		module.CustomResourcePolicy = mapIfNeeded(customModule.CustomResourcePolicy)
+		module.Channel = mapIfNeeded(customModule.Channel)
+		section = append(section, module)
+	}
+
+	marshaled, err := json.Marshal(section)
+	if err != nil {
+		return err
+	}
+	var unmarshaled interface{}
+	if err := json.Unmarshal(marshaled, &unmarshaled); err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(kyma.Object, unmarshaled, "spec", "modules")
+}
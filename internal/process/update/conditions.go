@@ -25,3 +25,7 @@ func SkipForOwnClusterPlan(op internal.Operation) bool {
 func RequiresBTPOperatorCredentials(op internal.Operation) bool {
 	return ForBTPOperatorCredentialsProvided(op) && !broker.IsPreviewPlan(op.ProvisioningParameters.PlanID)
 }
+
+func RequiresModulesUpdate(op internal.Operation) bool {
+	return op.UpdatingParameters.Modules != nil
+}
@@ -0,0 +1,104 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type UpdateKymaModulesStep struct {
+	operationManager   *process.OperationManager
+	k8sClient          client.Client
+	configProvider     input.ConfigurationProvider
+	defaultKymaVersion string
+}
+
+var _ process.Step = &UpdateKymaModulesStep{}
+
+func NewUpdateKymaModulesStep(os storage.Operations, cli client.Client, configProvider input.ConfigurationProvider, defaultKymaVersion string) *UpdateKymaModulesStep {
+	return &UpdateKymaModulesStep{
+		operationManager:   process.NewOperationManager(os),
+		k8sClient:          cli,
+		configProvider:     configProvider,
+		defaultKymaVersion: defaultKymaVersion,
+	}
+}
+
+func (s *UpdateKymaModulesStep) Name() string {
+	return "Update_Kyma_Modules"
+}
+
+// Run replaces the module list of the Kyma resource with the modules requested in the update parameters.
+// The cases are the same as in the provisioning: 'default' set to false removes all modules, the given 'list' replaces
+// the modules and otherwise the default modules from the Kyma template are restored.
+func (s *UpdateKymaModulesStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	modules := operation.UpdatingParameters.Modules
+	if modules == nil {
+		return operation, 0, nil
+	}
+	if operation.KymaResourceNamespace == "" {
+		log.Warnf("namespace for Kyma resource not specified, skipping")
+		return operation, 0, nil
+	}
+
+	cfg, err := s.configProvider.ProvideForGivenVersionAndPlan(s.defaultKymaVersion, broker.PlanNamesMapping[operation.ProvisioningParameters.PlanID])
+	if err != nil {
+		return s.operationManager.RetryOperation(operation, "unable to get config for given version and plan", err, 5*time.Second, 30*time.Second, log)
+	}
+	template, err := steps.DecodeKymaTemplate(cfg.KymaTemplate)
+	if err != nil {
+		return s.operationManager.OperationFailed(operation, "unable to decode the Kyma template", err, log)
+	}
+
+	kyma := &unstructured.Unstructured{}
+	kyma.SetGroupVersionKind(template.GroupVersionKind())
+	err = s.k8sClient.Get(context.Background(), client.ObjectKey{Namespace: operation.KymaResourceNamespace, Name: steps.KymaName(operation)}, kyma)
+	switch {
+	case errors.IsNotFound(err):
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("Kyma resource %s not found", steps.KymaName(operation)), err, log)
+	case err != nil:
+		return s.operationManager.RetryOperation(operation, "unable to get the Kyma resource", err, time.Second, 10*time.Second, log)
+	}
+
+	original := kyma.DeepCopy()
+	if err := setModules(kyma, template, *modules); err != nil {
+		return s.operationManager.OperationFailed(operation, "unable to set modules in the Kyma resource", err, log)
+	}
+	err = s.k8sClient.Patch(context.Background(), kyma, client.MergeFrom(original))
+	if err != nil {
+		return s.operationManager.RetryOperation(operation, "unable to patch the Kyma resource", err, time.Second, 10*time.Second, log)
+	}
+	log.Infof("modules of Kyma resource %s updated", kyma.GetName())
+
+	return operation, 0, nil
+}
+
+func setModules(kyma, template *unstructured.Unstructured, modules internal.ModulesDTO) error {
+	switch {
+	case modules.Default != nil && !*modules.Default:
+		return steps.ReplaceKymaModules(kyma, nil)
+	case modules.Default == nil && modules.List != nil:
+		return steps.ReplaceKymaModules(kyma, modules.List)
+	}
+
+	defaultModules, found, err := unstructured.NestedFieldCopy(template.Object, "spec", "modules")
+	if err != nil {
+		return err
+	}
+	if !found {
+		unstructured.RemoveNestedField(kyma.Object, "spec", "modules")
+		return nil
+	}
+	return unstructured.SetNestedField(kyma.Object, defaultModules, "spec", "modules")
+}
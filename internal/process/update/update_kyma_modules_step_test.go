@@ -0,0 +1,131 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const kymaTemplateWithModules = `
+apiVersion: operator.kyma-project.io/v1beta2
+kind: Kyma
+metadata:
+  name: my-kyma
+  namespace: kyma-system
+spec:
+  sync:
+    strategy: secret
+  channel: stable
+  modules:
+  - name: btp-operator
+    customResourcePolicy: CreateAndDelete
+  - name: keda
+    channel: fast
+`
+
+func TestUpdateKymaModulesStep_Run(t *testing.T) {
+	for name, tc := range map[string]struct {
+		modules  internal.ModulesDTO
+		expected []interface{}
+	}{
+		"custom list": {
+			modules: internal.ModulesDTO{List: []internal.ModuleDTO{
+				{Name: "api-gateway", Channel: ptr.String("regular"), CustomResourcePolicy: ptr.String("")},
+				{Name: "istio"},
+			}},
+			expected: []interface{}{
+				map[string]interface{}{"name": "api-gateway", "channel": "regular"},
+				map[string]interface{}{"name": "istio"},
+			},
+		},
+		"empty list": {
+			modules:  internal.ModulesDTO{List: []internal.ModuleDTO{}},
+			expected: []interface{}{},
+		},
+		"default set to false": {
+			modules:  internal.ModulesDTO{Default: ptr.Bool(false)},
+			expected: []interface{}{},
+		},
+		"default modules": {
+			modules: internal.ModulesDTO{Default: ptr.Bool(true)},
+			expected: []interface{}{
+				map[string]interface{}{"name": "btp-operator", "customResourcePolicy": "CreateAndDelete"},
+				map[string]interface{}{"name": "keda", "channel": "fast"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixture.FixUpdatingOperation("op-id", "inst-id").Operation
+			operation.UpdatingParameters = internal.UpdatingParametersDTO{Modules: &tc.modules}
+			require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+
+			kcpClient := fake.NewClientBuilder().WithRuntimeObjects(fixKyma(t, operation)).Build()
+			step := NewUpdateKymaModulesStep(memoryStorage.Operations(), kcpClient, fakeConfigProvider{}, "2.0")
+
+			// when
+			_, backoff, err := step.Run(operation, logrus.New())
+
+			// then
+			require.NoError(t, err)
+			assert.Zero(t, backoff)
+			kyma := getKyma(t, kcpClient, operation)
+			modules, found, err := unstructured.NestedSlice(kyma.Object, "spec", "modules")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, tc.expected, modules)
+			assert.Equal(t, "stable", kyma.Object["spec"].(map[string]interface{})["channel"])
+		})
+	}
+
+	t.Run("should fail when the Kyma resource does not exist", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixture.FixUpdatingOperation("op-id", "inst-id").Operation
+		operation.UpdatingParameters = internal.UpdatingParametersDTO{Modules: &internal.ModulesDTO{Default: ptr.Bool(false)}}
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+		step := NewUpdateKymaModulesStep(memoryStorage.Operations(), fake.NewClientBuilder().Build(), fakeConfigProvider{}, "2.0")
+
+		// when
+		_, _, err := step.Run(operation, logrus.New())
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+type fakeConfigProvider struct{}
+
+func (fakeConfigProvider) ProvideForGivenVersionAndPlan(_, _ string) (*internal.ConfigForPlan, error) {
+	return &internal.ConfigForPlan{KymaTemplate: kymaTemplateWithModules}, nil
+}
+
+func fixKyma(t *testing.T, operation internal.Operation) *unstructured.Unstructured {
+	kyma, err := steps.DecodeKymaTemplate(kymaTemplateWithModules)
+	require.NoError(t, err)
+	kyma.SetName(steps.KymaName(operation))
+	kyma.SetNamespace(operation.KymaResourceNamespace)
+	unstructured.SetNestedSlice(kyma.Object, []interface{}{map[string]interface{}{"name": "serverless"}}, "spec", "modules")
+	return kyma
+}
+
+func getKyma(t *testing.T, cli client.Client, operation internal.Operation) *unstructured.Unstructured {
+	kyma := &unstructured.Unstructured{}
+	kyma.SetAPIVersion("operator.kyma-project.io/v1beta2")
+	kyma.SetKind("Kyma")
+	err := cli.Get(context.Background(), client.ObjectKey{Namespace: operation.KymaResourceNamespace, Name: steps.KymaName(operation)}, kyma)
+	require.NoError(t, err)
+	return kyma
+}
@@ -31,7 +31,7 @@ const (
 const postUpgradeDescription = "Performing post-upgrade tasks"
 
 type InitialisationStep struct {
-	operationManager     *process.UpgradeClusterOperationManager
+	operationManager     *process.OperationManager
 	operationStorage     storage.Operations
 	orchestrationStorage storage.Orchestrations
 	provisionerClient    provisioner.Client
@@ -52,7 +52,7 @@ func NewInitialisationStep(os storage.Operations, ors storage.Orchestrations, pc
 		}
 	}
 	return &InitialisationStep{
-		operationManager:     process.NewUpgradeClusterOperationManager(os),
+		operationManager:     process.NewOperationManager(os),
 		operationStorage:     os,
 		orchestrationStorage: ors,
 		provisionerClient:    pc,
@@ -67,7 +67,7 @@ func (s *InitialisationStep) Name() string {
 	return "Upgrade_Cluster_Initialisation"
 }
 
-func (s *InitialisationStep) Run(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *InitialisationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	// Check concurrent deprovisioning (or suspension) operation (launched after target resolution)
 	// Terminate (preempt) upgrade immediately with succeeded
 	lastOp, err := s.operationStorage.GetLastOperation(operation.InstanceID)
@@ -105,7 +105,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeClusterOperation, log
 			}
 		}
 
-		op, delay, _ := s.operationManager.UpdateOperation(operation, func(op *internal.UpgradeClusterOperation) {
+		op, delay, _ := s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
 			op.ProvisioningParameters.ErsContext = internal.InheritMissingERSContext(op.ProvisioningParameters.ErsContext, lastOp.ProvisioningParameters.ErsContext)
 			op.State = domain.InProgress
 			op.RuntimeVersion = operation.RuntimeVersion
@@ -125,7 +125,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeClusterOperation, log
 	return s.checkRuntimeStatus(operation, log.WithField("runtimeID", operation.RuntimeOperation.RuntimeID))
 }
 
-func (s *InitialisationStep) initializeUpgradeShootRequest(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *InitialisationStep) initializeUpgradeShootRequest(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	log.Infof("create provisioner input creator for plan ID %q", operation.ProvisioningParameters)
 	creator, err := s.inputBuilder.CreateUpgradeShootInput(operation.ProvisioningParameters, operation.RuntimeVersion)
 	switch {
@@ -143,12 +143,12 @@ func (s *InitialisationStep) initializeUpgradeShootRequest(operation internal.Up
 
 // performRuntimeTasks Ensures that required logic on init and finish is executed.
 // Uses internal and external Avs monitor statuses to verify state.
-func (s *InitialisationStep) performRuntimeTasks(step int, operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *InitialisationStep) performRuntimeTasks(step int, operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	hasMonitors := s.evaluationManager.HasMonitors(operation.Avs)
 	inMaintenance := s.evaluationManager.InMaintenance(operation.Avs)
 	var err error = nil
 	var delay time.Duration = 0
-	var updateAvsStatus = func(op *internal.UpgradeClusterOperation) {
+	var updateAvsStatus = func(op *internal.Operation) {
 		op.Avs.AvsInternalEvaluationStatus = operation.Avs.AvsInternalEvaluationStatus
 		op.Avs.AvsExternalEvaluationStatus = operation.Avs.AvsExternalEvaluationStatus
 	}
@@ -183,12 +183,12 @@ func (s *InitialisationStep) performRuntimeTasks(step int, operation internal.Up
 	}
 }
 
-func (s *InitialisationStep) restoreAvsAndFailOperation(operation internal.UpgradeClusterOperation, description string, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *InitialisationStep) restoreAvsAndFailOperation(operation internal.Operation, description string, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	err := s.evaluationManager.RestoreStatus(&operation.Avs, log)
 	if err != nil {
 		return s.operationManager.RetryOperation(operation, "error while restoring AvS state", err, 3*time.Second, time.Minute, log)
 	}
-	operation, retry, _ := s.operationManager.UpdateOperation(operation, func(op *internal.UpgradeClusterOperation) {
+	operation, retry, _ := s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
 		op.Avs.AvsInternalEvaluationStatus = operation.Avs.AvsInternalEvaluationStatus
 		op.Avs.AvsExternalEvaluationStatus = operation.Avs.AvsExternalEvaluationStatus
 	}, log)
@@ -201,7 +201,7 @@ func (s *InitialisationStep) restoreAvsAndFailOperation(operation internal.Upgra
 // checkRuntimeStatus will check operation runtime status
 // It will also trigger performRuntimeTasks upgrade steps to ensure
 // all the required dependencies have been fulfilled for upgrade operation.
-func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *InitialisationStep) checkRuntimeStatus(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		//send customer notification
@@ -247,7 +247,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeCluste
 		}
 		// Set post-upgrade description which also reset UpdatedAt for operation retries to work properly
 		if operation.Description != postUpgradeDescription {
-			operation, delay, _ = s.operationManager.UpdateOperation(operation, func(operation *internal.UpgradeClusterOperation) {
+			operation, delay, _ = s.operationManager.UpdateOperation(operation, func(operation *internal.Operation) {
 				operation.Description = postUpgradeDescription
 			}, log)
 			if delay != 0 {
@@ -273,7 +273,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeCluste
 	return s.operationManager.OperationFailed(operation, fmt.Sprintf("unsupported provisioner client status: %s", status.State.String()), nil, log)
 }
 
-func (s *InitialisationStep) sendNotificationComplete(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) error {
+func (s *InitialisationStep) sendNotificationComplete(operation internal.Operation, log logrus.FieldLogger) error {
 	tenants := []notification.NotificationTenant{
 		{
 			InstanceID: operation.InstanceID,
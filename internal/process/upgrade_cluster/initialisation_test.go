@@ -164,7 +164,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		upgradeOperation := fixUpgradeClusterOperation()
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -207,7 +207,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, time.Duration(0), repeat)
 		assert.Equal(t, domain.Succeeded, upgradeOperation.State)
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 
@@ -228,7 +228,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 
 		upgradeOperation := fixUpgradeClusterOperation()
 		upgradeOperation.ProvisionerOperationID = ""
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -273,7 +273,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, time.Duration(0), repeat)
 		assert.NotNil(t, op.InputCreator)
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(op.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(op.ID)
 		op.InputCreator = nil
 		assert.Equal(t, op, *storedOp)
 		assert.NoError(t, err)
@@ -295,7 +295,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		upgradeOperation := fixUpgradeClusterOperation()
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		provisioningOperation := fixProvisioningOperation()
@@ -328,7 +328,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, time.Duration(0), repeat)
 		assert.Equal(t, orchestration.Canceled, string(upgradeOperation.State))
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		require.NoError(t, err)
 		assert.Equal(t, upgradeOperation, *storedOp)
 	})
@@ -350,7 +350,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, "", "")
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -394,7 +394,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -417,7 +417,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -461,7 +461,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -484,7 +484,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -528,7 +528,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -552,7 +552,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData.AVSEvaluationExternalId = 0
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -596,7 +596,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -620,7 +620,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData.AvsEvaluationInternalId = 0
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -664,7 +664,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -689,7 +689,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData.AVSEvaluationExternalId = 0
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -733,7 +733,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -757,7 +757,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -822,7 +822,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -904,7 +904,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -929,7 +929,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, avs.StatusActive, avs.StatusActive)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -974,7 +974,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusMaintenance, Original: avs.StatusActive})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusMaintenance, Original: avs.StatusActive})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -999,7 +999,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, avs.StatusActive, avs.StatusActive)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -1044,7 +1044,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -1069,7 +1069,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, avs.StatusActive, avs.StatusActive)
 		upgradeOperation := fixUpgradeClusterOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeClusterOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -1114,18 +1114,18 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
 }
 
-func fixUpgradeClusterOperation() internal.UpgradeClusterOperation {
+func fixUpgradeClusterOperation() internal.Operation {
 	return fixUpgradeClusterOperationWithAvs(internal.AvsLifecycleData{})
 }
 
-func fixUpgradeClusterOperationWithAvs(avsData internal.AvsLifecycleData) internal.UpgradeClusterOperation {
-	upgradeOperation := fixture.FixUpgradeClusterOperation(fixUpgradeOperationID, fixInstanceID)
+func fixUpgradeClusterOperationWithAvs(avsData internal.AvsLifecycleData) internal.Operation {
+	upgradeOperation := fixture.FixUpgradeClusterOperation(fixUpgradeOperationID, fixInstanceID).Operation
 	upgradeOperation.OrchestrationID = fixOrchestrationID
 	upgradeOperation.ProvisionerOperationID = fixProvisionerOperationID
 	upgradeOperation.State = orchestration.Pending
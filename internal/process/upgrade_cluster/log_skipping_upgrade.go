@@ -12,7 +12,7 @@ import (
 )
 
 type LogSkippingUpgradeStep struct {
-	operationManager *process.UpgradeClusterOperationManager
+	operationManager *process.OperationManager
 }
 
 func (s *LogSkippingUpgradeStep) Name() string {
@@ -21,11 +21,11 @@ func (s *LogSkippingUpgradeStep) Name() string {
 
 func NewLogSkippingUpgradeStep(os storage.Operations) *LogSkippingUpgradeStep {
 	return &LogSkippingUpgradeStep{
-		operationManager: process.NewUpgradeClusterOperationManager(os),
+		operationManager: process.NewOperationManager(os),
 	}
 }
 
-func (s *LogSkippingUpgradeStep) Run(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *LogSkippingUpgradeStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	log.Info("Skipping cluster upgrade due to step condition not met")
 
 	return s.operationManager.OperationSucceeded(operation, "upgrade cluster skipped due to step condition", log)
@@ -15,7 +15,7 @@ import (
 const DryRunPrefix = "dry_run-"
 
 type UpgradeClusterStep struct {
-	operationManager    *process.UpgradeClusterOperationManager
+	operationManager    *process.OperationManager
 	provisionerClient   provisioner.Client
 	runtimeStateStorage storage.RuntimeStates
 	timeSchedule        TimeSchedule
@@ -36,7 +36,7 @@ func NewUpgradeClusterStep(
 	}
 
 	return &UpgradeClusterStep{
-		operationManager:    process.NewUpgradeClusterOperationManager(os),
+		operationManager:    process.NewOperationManager(os),
 		provisionerClient:   cli,
 		runtimeStateStorage: runtimeStorage,
 		timeSchedule:        *ts,
@@ -47,7 +47,7 @@ func (s *UpgradeClusterStep) Name() string {
 	return "Upgrade_Cluster"
 }
 
-func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+func (s *UpgradeClusterStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > s.timeSchedule.UpgradeClusterTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", s.timeSchedule.UpgradeClusterTimeout), nil, log)
@@ -66,7 +66,7 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 	if operation.DryRun {
 		// runtimeID is set with prefix to indicate the fake runtime state
 		err = s.runtimeStateStorage.Insert(
-			internal.NewRuntimeState(fmt.Sprintf("%s%s", DryRunPrefix, operation.RuntimeOperation.RuntimeID), operation.Operation.ID, nil, gardenerUpgradeInputToConfigInput(input)),
+			internal.NewRuntimeState(fmt.Sprintf("%s%s", DryRunPrefix, operation.RuntimeOperation.RuntimeID), operation.ID, nil, gardenerUpgradeInputToConfigInput(input)),
 		)
 		if err != nil {
 			return operation, 10 * time.Second, nil
@@ -84,7 +84,7 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 		}
 
 		repeat := time.Duration(0)
-		operation, repeat, _ = s.operationManager.UpdateOperation(operation, func(op *internal.UpgradeClusterOperation) {
+		operation, repeat, _ = s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
 			op.ProvisionerOperationID = *provisionerResponse.ID
 			op.Description = "cluster upgrade in progress"
 		}, log)
@@ -107,7 +107,7 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 	log = log.WithField("runtimeID", *provisionerResponse.RuntimeID)
 	log.Infof("call to provisioner for upgrade succeeded, got operation ID %q", *provisionerResponse.ID)
 
-	rs := internal.NewRuntimeState(*provisionerResponse.RuntimeID, operation.Operation.ID, nil, gardenerUpgradeInputToConfigInput(input))
+	rs := internal.NewRuntimeState(*provisionerResponse.RuntimeID, operation.ID, nil, gardenerUpgradeInputToConfigInput(input))
 	err = s.runtimeStateStorage.Insert(rs)
 	if err != nil {
 		log.Errorf("cannot insert runtimeState: %s", err)
@@ -116,12 +116,10 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 
 	log.Infof("cluster upgrade process initiated successfully")
 
-	// return repeat mode to start the initialization step which will now check the runtime status
-	return operation, s.timeSchedule.Retry, nil
-
+	return operation, 0, nil
 }
 
-func (s *UpgradeClusterStep) createUpgradeShootInput(operation internal.UpgradeClusterOperation, lastClusterConfig *gqlschema.GardenerConfigInput) (gqlschema.UpgradeShootInput, error) {
+func (s *UpgradeClusterStep) createUpgradeShootInput(operation internal.Operation, lastClusterConfig *gqlschema.GardenerConfigInput) (gqlschema.UpgradeShootInput, error) {
 	operation.InputCreator.SetProvisioningParameters(operation.ProvisioningParameters)
 	if lastClusterConfig.OidcConfig != nil {
 		operation.InputCreator.SetOIDCLastValues(*lastClusterConfig.OidcConfig)
@@ -34,7 +34,7 @@ func TestUpgradeClusterStep_Run(t *testing.T) {
 	memoryStorage := storage.NewMemoryStorage()
 
 	operation := fixUpgradeClusterOperationWithInputCreator(t)
-	err := memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+	err := memoryStorage.Operations().InsertOperation(operation)
 	assert.NoError(t, err)
 
 	provisioningOperation := fixProvisioningOperation()
@@ -102,12 +102,12 @@ func TestUpgradeClusterStep_Run(t *testing.T) {
 
 	// then
 	assert.NoError(t, err)
-	assert.Equal(t, 5*time.Second, repeat)
+	assert.Equal(t, time.Duration(0), repeat)
 	assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
 }
 
-func fixUpgradeClusterOperationWithInputCreator(t *testing.T) internal.UpgradeClusterOperation {
-	upgradeOperation := fixture.FixUpgradeClusterOperation(fixUpgradeOperationID, fixInstanceID)
+func fixUpgradeClusterOperationWithInputCreator(t *testing.T) internal.Operation {
+	upgradeOperation := fixture.FixUpgradeClusterOperation(fixUpgradeOperationID, fixInstanceID).Operation
 	upgradeOperation.Description = ""
 	upgradeOperation.ProvisioningParameters = fixProvisioningParameters()
 	upgradeOperation.InstanceDetails.RuntimeID = fixRuntimeID
@@ -15,7 +15,7 @@ import (
 )
 
 type ApplyClusterConfigurationStep struct {
-	operationManager    *process.UpgradeKymaOperationManager
+	operationManager    *process.OperationManager
 	reconcilerClient    reconciler.Client
 	runtimeStateStorage storage.RuntimeStates
 	kubeconfigProvider  kubeconfigProvider
@@ -27,7 +27,7 @@ type kubeconfigProvider interface {
 
 func NewApplyClusterConfigurationStep(os storage.Operations, rs storage.RuntimeStates, reconcilerClient reconciler.Client, kcfgProvider kubeconfigProvider) *ApplyClusterConfigurationStep {
 	return &ApplyClusterConfigurationStep{
-		operationManager:    process.NewUpgradeKymaOperationManager(os),
+		operationManager:    process.NewOperationManager(os),
 		reconcilerClient:    reconcilerClient,
 		runtimeStateStorage: rs,
 		kubeconfigProvider:  kcfgProvider,
@@ -38,7 +38,7 @@ func (s *ApplyClusterConfigurationStep) Name() string {
 	return "Apply_Cluster_Configuration"
 }
 
-func (s *ApplyClusterConfigurationStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *ApplyClusterConfigurationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if operation.ClusterConfigurationApplied {
 		log.Infof("Cluster configuration already applied")
 		return operation, 0, nil
@@ -72,7 +72,7 @@ func (s *ApplyClusterConfigurationStep) Run(operation internal.UpgradeKymaOperat
 	}
 
 	err = s.runtimeStateStorage.Insert(
-		internal.NewRuntimeStateWithReconcilerInput(clusterConfiguration.RuntimeID, operation.Operation.ID, &clusterConfiguration))
+		internal.NewRuntimeStateWithReconcilerInput(clusterConfiguration.RuntimeID, operation.ID, &clusterConfiguration))
 	if err != nil {
 		log.Errorf("cannot insert runtimeState with reconciler payload: %s", err)
 		return operation, 10 * time.Second, nil
@@ -97,7 +97,7 @@ func (s *ApplyClusterConfigurationStep) Run(operation internal.UpgradeKymaOperat
 	}
 	log.Infof("Cluster configuration version %d", state.ConfigurationVersion)
 
-	updatedOperation, repeat, _ := s.operationManager.UpdateOperation(operation, func(operation *internal.UpgradeKymaOperation) {
+	updatedOperation, repeat, _ := s.operationManager.UpdateOperation(operation, func(operation *internal.Operation) {
 		operation.ClusterConfigurationVersion = state.ConfigurationVersion
 		operation.ClusterConfigurationApplied = true
 		operation.ClusterName = clusterConfiguration.RuntimeInput.Name
@@ -107,8 +107,7 @@ func (s *ApplyClusterConfigurationStep) Run(operation internal.UpgradeKymaOperat
 		return operation, 5 * time.Second, nil
 	}
 
-	// return some retry value to get back to initialisation step
-	return updatedOperation, 5 * time.Second, nil
+	return updatedOperation, 0, nil
 
 }
 
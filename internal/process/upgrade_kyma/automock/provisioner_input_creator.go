@@ -188,3 +188,17 @@ func (_m *ProvisionerInputCreator) SetShootName(_a0 string) internal.Provisioner
 
 	return r0
 }
+
+// Snapshot provides a mock function with given fields:
+func (_m *ProvisionerInputCreator) Snapshot() internal.ProvisionerInput {
+	ret := _m.Called()
+
+	var r0 internal.ProvisionerInput
+	if rf, ok := ret.Get(0).(func() internal.ProvisionerInput); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(internal.ProvisionerInput)
+	}
+
+	return r0
+}
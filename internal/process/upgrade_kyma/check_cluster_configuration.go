@@ -17,7 +17,7 @@ import (
 // CheckClusterConfigurationStep checks if the SKR configuration is applied (by reconciler)
 type CheckClusterConfigurationStep struct {
 	reconcilerClient      reconciler.Client
-	operationManager      *process.UpgradeKymaOperationManager
+	operationManager      *process.OperationManager
 	evaluationManager     *avs.EvaluationManager
 	reconciliationTimeout time.Duration
 }
@@ -28,19 +28,19 @@ func NewCheckClusterConfigurationStep(os storage.Operations,
 	provisioningTimeout time.Duration) *CheckClusterConfigurationStep {
 	return &CheckClusterConfigurationStep{
 		reconcilerClient:      reconcilerClient,
-		operationManager:      process.NewUpgradeKymaOperationManager(os),
+		operationManager:      process.NewOperationManager(os),
 		evaluationManager:     evaluationManager,
 		reconciliationTimeout: provisioningTimeout,
 	}
 }
 
-var _ Step = (*CheckClusterConfigurationStep)(nil)
+var _ process.Step = (*CheckClusterConfigurationStep)(nil)
 
 func (s *CheckClusterConfigurationStep) Name() string {
 	return "Check_Cluster_Configuration"
 }
 
-func (s *CheckClusterConfigurationStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *CheckClusterConfigurationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > s.reconciliationTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		return s.restoreAvsFailOperation(operation, fmt.Sprintf("operation has reached the time limit: %s", s.reconciliationTimeout), log)
@@ -99,7 +99,7 @@ func (s *CheckClusterConfigurationStep) Run(operation internal.UpgradeKymaOperat
 	}
 }
 
-func (s *CheckClusterConfigurationStep) restoreAvsFailOperation(operation internal.UpgradeKymaOperation, description string, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *CheckClusterConfigurationStep) restoreAvsFailOperation(operation internal.Operation, description string, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	operation, err := RestoreAvsStatus(s.evaluationManager, s.operationManager, operation, log)
 	if kebError.IsTemporaryError(err) {
 		return operation, 30 * time.Second, nil
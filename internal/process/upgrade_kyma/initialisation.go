@@ -35,7 +35,7 @@ const (
 const postUpgradeDescription = "Performing post-upgrade tasks"
 
 type InitialisationStep struct {
-	operationManager       *process.UpgradeKymaOperationManager
+	operationManager       *process.OperationManager
 	operationStorage       storage.Operations
 	orchestrationStorage   storage.Orchestrations
 	instanceStorage        storage.Instances
@@ -58,7 +58,7 @@ func NewInitialisationStep(os storage.Operations, ors storage.Orchestrations, is
 		}
 	}
 	return &InitialisationStep{
-		operationManager:       process.NewUpgradeKymaOperationManager(os),
+		operationManager:       process.NewOperationManager(os),
 		operationStorage:       os,
 		orchestrationStorage:   ors,
 		instanceStorage:        is,
@@ -75,7 +75,7 @@ func (s *InitialisationStep) Name() string {
 	return "Upgrade_Kyma_Initialisation"
 }
 
-func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *InitialisationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 
 	if broker.IsPreviewPlan(operation.ProvisioningParameters.PlanID) {
 		log.Infof("Preview Plan  does not support upgrade Kyma process, setting the operation state to succeeded")
@@ -119,7 +119,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 			log.Errorf("while getting provisioning operation from storage")
 			return operation, s.timeSchedule.Retry, nil
 		}
-		op, delay, _ := s.operationManager.UpdateOperation(operation, func(op *internal.UpgradeKymaOperation) {
+		op, delay, _ := s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
 			op.ProvisioningParameters = provisioningOperation.ProvisioningParameters
 			op.ProvisioningParameters.ErsContext = internal.InheritMissingERSContext(op.ProvisioningParameters.ErsContext, lastOp.ProvisioningParameters.ErsContext)
 			op.State = domain.InProgress
@@ -143,7 +143,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 	return operation, 0, nil
 }
 
-func (s *InitialisationStep) initializeUpgradeRuntimeRequest(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *InitialisationStep) initializeUpgradeRuntimeRequest(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if err := s.configureKymaVersion(&operation, log); err != nil {
 		return s.operationManager.RetryOperation(operation, "error while configuring kyma version", err, 5*time.Second, 5*time.Minute, log)
 	}
@@ -164,7 +164,7 @@ func (s *InitialisationStep) initializeUpgradeRuntimeRequest(operation internal.
 	}
 }
 
-func (s *InitialisationStep) configureKymaVersion(operation *internal.UpgradeKymaOperation, log logrus.FieldLogger) error {
+func (s *InitialisationStep) configureKymaVersion(operation *internal.Operation, log logrus.FieldLogger) error {
 	if !operation.RuntimeVersion.IsEmpty() {
 		return nil
 	}
@@ -175,14 +175,14 @@ func (s *InitialisationStep) configureKymaVersion(operation *internal.UpgradeKym
 		version *internal.RuntimeVersionData
 	)
 
-	version, err = s.runtimeVerConfigurator.ForUpgrade(*operation)
+	version, err = s.runtimeVerConfigurator.ForUpgrade(internal.UpgradeKymaOperation{Operation: *operation})
 	if err != nil {
 		return fmt.Errorf("while getting runtime version for upgrade: %w", err)
 	}
 
 	// update operation version
 	var repeat time.Duration
-	if *operation, repeat, err = s.operationManager.UpdateOperation(*operation, func(operation *internal.UpgradeKymaOperation) {
+	if *operation, repeat, err = s.operationManager.UpdateOperation(*operation, func(operation *internal.Operation) {
 		operation.RuntimeVersion = *version
 	}, log); repeat != 0 {
 		return fmt.Errorf("unable to update operation with RuntimeVersion property: %w", err)
@@ -194,7 +194,7 @@ func (s *InitialisationStep) configureKymaVersion(operation *internal.UpgradeKym
 // checkRuntimeStatus will check operation runtime status
 // It will also trigger performRuntimeTasks upgrade steps to ensure
 // all the required dependencies have been fulfilled for upgrade operation.
-func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *InitialisationStep) checkRuntimeStatus(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		if operation.RuntimeOperation.Notification {
@@ -252,7 +252,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeKymaOp
 		}
 		// Set post-upgrade description which also reset UpdatedAt for operation retries to work properly
 		if operation.Description != postUpgradeDescription {
-			operation, delay, _ = s.operationManager.UpdateOperation(operation, func(operation *internal.UpgradeKymaOperation) {
+			operation, delay, _ = s.operationManager.UpdateOperation(operation, func(operation *internal.Operation) {
 				operation.Description = postUpgradeDescription
 			}, log)
 			if delay != 0 {
@@ -281,7 +281,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeKymaOp
 	return s.operationManager.OperationFailed(operation, fmt.Sprintf("unsupported provisioner client status: %s", status.State.String()), nil, log)
 }
 
-func (s *InitialisationStep) sendNotificationComplete(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) error {
+func (s *InitialisationStep) sendNotificationComplete(operation internal.Operation, log logrus.FieldLogger) error {
 	tenants := []notification.NotificationTenant{
 		{
 			InstanceID: operation.InstanceID,
@@ -307,12 +307,12 @@ func (s *InitialisationStep) sendNotificationComplete(operation internal.Upgrade
 	return nil
 }
 
-func (s *InitialisationStep) restoreAvsAndFailOperation(operation internal.UpgradeKymaOperation, description string, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *InitialisationStep) restoreAvsAndFailOperation(operation internal.Operation, description string, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	err := s.evaluationManager.RestoreStatus(&operation.Avs, log)
 	if err != nil {
 		return s.operationManager.RetryOperation(operation, "error while restoring AvS state", err, 3*time.Second, time.Minute, log)
 	}
-	operation, retry, _ := s.operationManager.UpdateOperation(operation, func(op *internal.UpgradeKymaOperation) {
+	operation, retry, _ := s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
 		op.Avs.AvsInternalEvaluationStatus = operation.Avs.AvsInternalEvaluationStatus
 		op.Avs.AvsExternalEvaluationStatus = operation.Avs.AvsExternalEvaluationStatus
 	}, log)
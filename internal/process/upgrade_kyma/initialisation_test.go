@@ -151,7 +151,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		upgradeOperation := fixUpgradeKymaOperation()
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -203,7 +203,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, time.Duration(0), repeat)
 		assert.Equal(t, domain.Succeeded, upgradeOperation.State)
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 
@@ -232,7 +232,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		upgradeOperation := fixUpgradeKymaOperation()
 		upgradeOperation.ProvisionerOperationID = ""
 		upgradeOperation.InputCreator = newInputCreator()
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -279,7 +279,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, time.Duration(0), repeat)
 		assert.NotNil(t, op.InputCreator)
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(op.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(op.ID)
 		assert.Equal(t, op, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -300,7 +300,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		upgradeOperation := fixUpgradeKymaOperation()
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		provisioningOperation := fixProvisioningOperation()
@@ -334,7 +334,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, time.Duration(0), repeat)
 		assert.Equal(t, orchestration.Canceled, string(upgradeOperation.State))
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		require.NoError(t, err)
 		assert.Equal(t, upgradeOperation, *storedOp)
 	})
@@ -361,7 +361,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, "", "")
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -413,7 +413,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -441,7 +441,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -494,7 +494,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -516,7 +516,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -568,7 +568,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -597,7 +597,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData.AVSEvaluationExternalId = 0
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -649,7 +649,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -678,7 +678,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData.AvsEvaluationInternalId = 0
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -730,7 +730,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -761,7 +761,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData.AVSEvaluationExternalId = 0
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -812,7 +812,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: "", Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -841,7 +841,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -919,7 +919,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, internalStatus, externalStatus)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -1009,7 +1009,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: internalStatus, Original: avs.StatusMaintenance})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: externalStatus, Original: avs.StatusMaintenance})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -1033,7 +1033,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, avs.StatusActive, avs.StatusActive)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -1085,7 +1085,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusMaintenance, Original: avs.StatusActive})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusMaintenance, Original: avs.StatusActive})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -1109,7 +1109,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, avs.StatusActive, avs.StatusActive)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -1161,7 +1161,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
@@ -1185,7 +1185,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		avsData := createMonitors(t, client, avs.StatusActive, avs.StatusActive)
 		upgradeOperation := fixUpgradeKymaOperationWithAvs(avsData)
 
-		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		err = memoryStorage.Operations().InsertOperation(upgradeOperation)
 		require.NoError(t, err)
 
 		instance := fixInstanceRuntimeStatus()
@@ -1237,18 +1237,18 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, upgradeOperation.Avs.AvsInternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 		assert.Equal(t, upgradeOperation.Avs.AvsExternalEvaluationStatus, internal.AvsEvaluationStatus{Current: avs.StatusActive, Original: ""})
 
-		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		storedOp, err := memoryStorage.Operations().GetOperationByID(upgradeOperation.ID)
 		assert.Equal(t, upgradeOperation, *storedOp)
 		assert.NoError(t, err)
 	})
 }
 
-func fixUpgradeKymaOperation() internal.UpgradeKymaOperation {
+func fixUpgradeKymaOperation() internal.Operation {
 	return fixUpgradeKymaOperationWithAvs(internal.AvsLifecycleData{})
 }
 
-func fixUpgradeKymaOperationWithAvs(avsData internal.AvsLifecycleData) internal.UpgradeKymaOperation {
-	upgradeOperation := fixture.FixUpgradeKymaOperation(fixUpgradeOperationID, fixInstanceID)
+func fixUpgradeKymaOperationWithAvs(avsData internal.AvsLifecycleData) internal.Operation {
+	upgradeOperation := fixture.FixUpgradeKymaOperation(fixUpgradeOperationID, fixInstanceID).Operation
 	upgradeOperation.OrchestrationID = fixOrchestrationID
 	upgradeOperation.ProvisionerOperationID = fixProvisionerOperationID
 	upgradeOperation.State = orchestration.Pending
@@ -1,9 +1,11 @@
 package upgrade_kyma
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/runtimeoverrides"
 
@@ -23,7 +25,7 @@ type RuntimeVersionConfiguratorForUpgrade interface {
 }
 
 type OverridesFromSecretsAndConfigStep struct {
-	operationManager       *process.UpgradeKymaOperationManager
+	operationManager       *process.OperationManager
 	runtimeOverrides       RuntimeOverridesAppender
 	runtimeVerConfigurator RuntimeVersionConfiguratorForUpgrade
 }
@@ -31,7 +33,7 @@ type OverridesFromSecretsAndConfigStep struct {
 func NewOverridesFromSecretsAndConfigStep(os storage.Operations, runtimeOverrides RuntimeOverridesAppender,
 	rvc RuntimeVersionConfiguratorForUpgrade) *OverridesFromSecretsAndConfigStep {
 	return &OverridesFromSecretsAndConfigStep{
-		operationManager:       process.NewUpgradeKymaOperationManager(os),
+		operationManager:       process.NewOperationManager(os),
 		runtimeOverrides:       runtimeOverrides,
 		runtimeVerConfigurator: rvc,
 	}
@@ -41,11 +43,12 @@ func (s *OverridesFromSecretsAndConfigStep) Name() string {
 	return "Overrides_From_Secrets_And_Config_Step"
 }
 
-func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	planName, exists := broker.PlanNamesMapping[operation.ProvisioningParameters.PlanID]
 	if !exists {
-		log.Errorf("cannot map planID '%s' to planName", operation.ProvisioningParameters.PlanID)
-		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters", nil, log)
+		errMsg := fmt.Sprintf("cannot map planID '%s' to planName", operation.ProvisioningParameters.PlanID)
+		log.Error(errMsg)
+		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters", kebError.InvalidParametersError(errMsg), log)
 	}
 
 	version, err := s.getRuntimeVersion(operation)
@@ -61,7 +64,7 @@ func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.UpgradeKymaOp
 	return operation, 0, nil
 }
 
-func (s *OverridesFromSecretsAndConfigStep) getRuntimeVersion(operation internal.UpgradeKymaOperation) (*internal.RuntimeVersionData, error) {
+func (s *OverridesFromSecretsAndConfigStep) getRuntimeVersion(operation internal.Operation) (*internal.RuntimeVersionData, error) {
 	// for some previously stored operations the RuntimeVersion property may not be initialized
 	if operation.RuntimeVersion.Version != "" {
 		return &operation.RuntimeVersion, nil
@@ -70,5 +73,5 @@ func (s *OverridesFromSecretsAndConfigStep) getRuntimeVersion(operation internal
 	// if so, we manually compute the correct version using the same algorithm as when preparing
 	// the provisioning operation. The following code can be removed after all operations will use
 	// new approach for setting up runtime version in operation struct
-	return s.runtimeVerConfigurator.ForUpgrade(operation)
+	return s.runtimeVerConfigurator.ForUpgrade(internal.UpgradeKymaOperation{Operation: operation})
 }
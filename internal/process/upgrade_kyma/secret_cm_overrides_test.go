@@ -28,21 +28,19 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionComputed(t *testing.T)
 		defer runtimeOverridesMock.AssertExpectations(t)
 		runtimeOverridesMock.On("Append", inputCreatorMock, planName, kymaVersion).Return(nil).Once()
 
-		operation := internal.UpgradeKymaOperation{
-			Operation: internal.Operation{
-				ProvisioningParameters: fixProvisioningParameters(),
-				InputCreator:           inputCreatorMock,
-			},
+		operation := internal.Operation{
+			ProvisioningParameters: fixProvisioningParameters(),
+			InputCreator:           inputCreatorMock,
 		}
 
 		rvcMock := &automock.RuntimeVersionConfiguratorForUpgrade{}
 		defer rvcMock.AssertExpectations(t)
-		rvcMock.On("ForUpgrade", operation).Return(&internal.RuntimeVersionData{Version: kymaVersion}, nil).Once()
+		rvcMock.On("ForUpgrade", internal.UpgradeKymaOperation{Operation: operation}).Return(&internal.RuntimeVersionData{Version: kymaVersion}, nil).Once()
 
 		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock)
 
 		// When
-		operation, repeat, err := step.Run(operation, logrus.New())
+		_, repeat, err := step.Run(operation, logrus.New())
 
 		// Then
 		assert.NoError(t, err)
@@ -64,13 +62,11 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		defer runtimeOverridesMock.AssertExpectations(t)
 		runtimeOverridesMock.On("Append", inputCreatorMock, planName, kymaVersion).Return(nil).Once()
 
-		operation := internal.UpgradeKymaOperation{
-			Operation: internal.Operation{
-				ProvisioningParameters: fixProvisioningParameters(),
-				InputCreator:           inputCreatorMock,
-				RuntimeVersion: internal.RuntimeVersionData{
-					Version: kymaVersion,
-				},
+		operation := internal.Operation{
+			ProvisioningParameters: fixProvisioningParameters(),
+			InputCreator:           inputCreatorMock,
+			RuntimeVersion: internal.RuntimeVersionData{
+				Version: kymaVersion,
 			},
 		}
 
@@ -80,7 +76,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock)
 
 		// When
-		operation, repeat, err := step.Run(operation, logrus.New())
+		_, repeat, err := step.Run(operation, logrus.New())
 
 		// Then
 		assert.NoError(t, err)
@@ -33,11 +33,9 @@ func TestSendNotificationStep_Run(t *testing.T) {
 	bundleBuilder.On("NewBundle", notification.FakeOrchestrationID, paras).Return(bundle, nil).Once()
 	bundle.On("UpdateNotificationEvent").Return(nil).Once()
 
-	operation := internal.UpgradeKymaOperation{
-		Operation: internal.Operation{
-			InstanceID:      notification.FakeInstanceID,
-			OrchestrationID: notification.FakeOrchestrationID,
-		},
+	operation := internal.Operation{
+		InstanceID:      notification.FakeInstanceID,
+		OrchestrationID: notification.FakeOrchestrationID,
 	}
 	step := NewSendNotificationStep(memoryStorage.Operations(), bundleBuilder)
 
@@ -156,3 +156,7 @@ func (c *simpleInputCreator) SetInstanceID(_ string) internal.ProvisionerInputCr
 func (c *simpleInputCreator) SetOIDCLastValues(oidcConfig gqlschema.OIDCConfigInput) internal.ProvisionerInputCreator {
 	return c
 }
+
+func (c *simpleInputCreator) Snapshot() internal.ProvisionerInput {
+	return internal.ProvisionerInput{}
+}
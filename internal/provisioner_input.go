@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+)
+
+// ProvisionerInput is the serializable state of the ProvisionerInputCreator which is not derived from the provisioning parameters.
+// It is stored in the operation, so the input creator can be restored after KEB restart without repeating the steps which prepared it.
+// The state contains the overrides and the kubeconfig, it must be encrypted in the storage.
+type ProvisionerInput struct {
+	ShootName         *string                                  `json:"shoot_name,omitempty"`
+	ShootDomain       string                                   `json:"shoot_domain,omitempty"`
+	ShootDNSProviders gardener.DNSProvidersData                `json:"shoot_dns_providers,omitempty"`
+	InstanceID        string                                   `json:"instance_id,omitempty"`
+	RuntimeID         string                                   `json:"runtime_id,omitempty"`
+	Kubeconfig        string                                   `json:"kubeconfig,omitempty"`
+	ClusterName       string                                   `json:"cluster_name,omitempty"`
+	OIDCLastValues    gqlschema.OIDCConfigInput                `json:"oidc_last_values,omitempty"`
+	Labels            gqlschema.Labels                         `json:"labels,omitempty"`
+	Overrides         map[string][]*gqlschema.ConfigEntryInput `json:"overrides,omitempty"`
+	GlobalOverrides   []*gqlschema.ConfigEntryInput            `json:"global_overrides,omitempty"`
+	// DisabledComponents are the optional components disabled in the input creator, they are disabled before EnabledComponents are enabled
+	DisabledComponents []string `json:"disabled_components,omitempty"`
+	EnabledComponents  []string `json:"enabled_components,omitempty"`
+}
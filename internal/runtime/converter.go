@@ -4,6 +4,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 )
 
@@ -66,6 +67,23 @@ func (c *converter) applyOperation(source *internal.Operation, target *pkg.Opera
 		target.RuntimeVersion = source.RuntimeVersion.Version
 		target.FinishedStages = source.FinishedStages
 		target.ExecutedButNotCompletedSteps = source.ExcutedButNotCompleted
+		if source.State == domain.Failed {
+			target.Error = c.operationError(source.LastError)
+		}
+	}
+}
+
+func (c *converter) operationError(lastError kebError.LastError) *pkg.OperationError {
+	details := kebError.Details(lastError)
+	if details == nil {
+		return nil
+	}
+	return &pkg.OperationError{
+		Code:      string(details.Code),
+		Reason:    string(details.Reason),
+		Component: string(details.Component),
+		Action:    string(details.Action),
+		Hint:      details.Hint,
 	}
 }
 
@@ -85,14 +103,18 @@ func (c *converter) NewDTO(instance internal.Instance) (pkg.RuntimeDTO, error) {
 		UserID:                      instance.Parameters.ErsContext.UserID,
 		ShootName:                   instance.InstanceDetails.ShootName,
 		Status: pkg.RuntimeStatus{
-			CreatedAt:  instance.CreatedAt,
-			ModifiedAt: instance.UpdatedAt,
-			ExpiredAt:  instance.ExpiredAt,
+			CreatedAt:    instance.CreatedAt,
+			ModifiedAt:   instance.UpdatedAt,
+			ExpiredAt:    instance.ExpiredAt,
+			HibernatedAt: instance.HibernatedAt,
 		},
 	}
 	if !instance.DeletedAt.IsZero() {
 		toReturn.Status.DeletedAt = &instance.DeletedAt
 	}
+	if instance.IsDeactivated() && instance.IsHibernated() {
+		toReturn.Status.SuspensionReason = pkg.SuspensionReasonHibernated
+	}
 
 	c.setRegionOrDefault(instance, &toReturn)
 
@@ -190,13 +212,25 @@ func (c *converter) ApplyUpdateOperations(dto *pkg.RuntimeDTO, oprs []internal.U
 }
 
 func (c *converter) adjustRuntimeState(dto *pkg.RuntimeDTO) {
+	// the hibernation by the suspension is taken from the instance, the suspension by the deprovisioning is determined again from the operations
+	if dto.Status.SuspensionReason == pkg.SuspensionReasonSuspended {
+		dto.Status.SuspensionReason = ""
+	}
+
 	lastOp := dto.LastOperation()
 	switch lastOp.State {
 	case string(domain.Succeeded):
 		dto.Status.State = pkg.StateSucceeded
+		if dto.Status.HibernatedAt != nil {
+			dto.Status.State = pkg.StateHibernated
+			if dto.Status.SuspensionReason == pkg.SuspensionReasonHibernated {
+				dto.Status.State = pkg.StateSuspended
+			}
+		}
 		switch lastOp.Type {
 		case pkg.Suspension:
 			dto.Status.State = pkg.StateSuspended
+			dto.Status.SuspensionReason = pkg.SuspensionReasonSuspended
 		case pkg.Deprovision:
 			if len(lastOp.ExecutedButNotCompletedSteps) == 0 {
 				dto.Status.State = pkg.StateDeprovisioned
@@ -237,6 +271,7 @@ func (c *converter) adjustRuntimeState(dto *pkg.RuntimeDTO) {
 				dto.Status.State = pkg.StateFailed
 			default:
 				dto.Status.State = pkg.StateSuspended
+				dto.Status.SuspensionReason = pkg.SuspensionReasonSuspended
 			}
 		}
 	}
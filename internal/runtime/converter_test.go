@@ -8,6 +8,8 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/stretchr/testify/assert"
 )
@@ -38,6 +40,39 @@ func TestConverting_Provisioned(t *testing.T) {
 	assert.Equal(t, runtime.StateSucceeded, dto.Status.State)
 }
 
+func TestConverting_Hibernated(t *testing.T) {
+	// given
+	instance := fixInstance()
+	hibernatedAt := time.Now()
+	instance.HibernatedAt = &hibernatedAt
+	svc := NewConverter("eu")
+
+	// when
+	dto, _ := svc.NewDTO(instance)
+	svc.ApplyProvisioningOperation(&dto, fixProvisioningOperation(domain.Succeeded, time.Now()))
+
+	// then
+	assert.Equal(t, runtime.StateHibernated, dto.Status.State)
+	assert.Equal(t, &hibernatedAt, dto.Status.HibernatedAt)
+}
+
+func TestConverting_HibernatedBySuspension(t *testing.T) {
+	// given
+	instance := fixInstance()
+	hibernatedAt := time.Now()
+	instance.HibernatedAt = &hibernatedAt
+	instance.Parameters.ErsContext.Active = ptr.Bool(false)
+	svc := NewConverter("eu")
+
+	// when
+	dto, _ := svc.NewDTO(instance)
+	svc.ApplyProvisioningOperation(&dto, fixProvisioningOperation(domain.Succeeded, time.Now()))
+
+	// then
+	assert.Equal(t, runtime.StateSuspended, dto.Status.State)
+	assert.Equal(t, runtime.SuspensionReasonHibernated, dto.Status.SuspensionReason)
+}
+
 func TestConverting_ProvisioningFailed(t *testing.T) {
 	// given
 	instance := fixInstance()
@@ -51,6 +86,29 @@ func TestConverting_ProvisioningFailed(t *testing.T) {
 	assert.Equal(t, runtime.StateFailed, dto.Status.State)
 }
 
+func TestConverting_ProvisioningFailedWithError(t *testing.T) {
+	// given
+	instance := fixInstance()
+	svc := NewConverter("eu")
+	operation := fixProvisioningOperation(domain.Failed, time.Now())
+	operation.LastError = kebError.TimeoutError("operation has reached the time limit")
+
+	// when
+	dto, _ := svc.NewDTO(instance)
+	svc.ApplyProvisioningOperation(&dto, operation)
+
+	// then
+	assert.Equal(t, runtime.StateFailed, dto.Status.State)
+	assert.Equal(t, &runtime.OperationError{
+		Code:      "KEB_TIMEOUT",
+		Reason:    "err_keb_timeout",
+		Component: "keb",
+		Action:    "retry",
+		Hint:      dto.Status.Provisioning.Error.Hint,
+	}, dto.Status.Provisioning.Error)
+	assert.NotEmpty(t, dto.Status.Provisioning.Error.Hint)
+}
+
 func TestConverting_Updating(t *testing.T) {
 	// given
 	instance := fixInstance()
@@ -198,6 +256,7 @@ func TestConverting_SuspendedAndUpdated(t *testing.T) {
 
 	// then
 	assert.Equal(t, runtime.StateSuspended, dto.Status.State)
+	assert.Equal(t, runtime.SuspensionReasonSuspended, dto.Status.SuspensionReason)
 }
 
 func TestConverting_SuspendedAndUpdateFAiled(t *testing.T) {
@@ -27,16 +27,18 @@ type Handler struct {
 	instancesDb       storage.Instances
 	operationsDb      storage.Operations
 	runtimeStatesDb   storage.RuntimeStates
+	runtimeDriftsDb   storage.RuntimeDrifts
 	converter         Converter
 	defaultMaxPage    int
 	provisionerClient provisioner.Client
 }
 
-func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, runtimeStatesDb storage.RuntimeStates, defaultMaxPage int, defaultRequestRegion string, provisionerClient provisioner.Client) *Handler {
+func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, runtimeStatesDb storage.RuntimeStates, runtimeDriftsDb storage.RuntimeDrifts, defaultMaxPage int, defaultRequestRegion string, provisionerClient provisioner.Client) *Handler {
 	return &Handler{
 		instancesDb:       instanceDb,
 		operationsDb:      operationDb,
 		runtimeStatesDb:   runtimeStatesDb,
+		runtimeDriftsDb:   runtimeDriftsDb,
 		converter:         NewConverter(defaultRequestRegion),
 		defaultMaxPage:    defaultMaxPage,
 		provisionerClient: provisionerClient,
@@ -135,14 +137,37 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
 		return
 	}
+	cursor, err := pagination.ExtractCursorFromRequest(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
+		return
+	}
 	filter := h.getFilters(req)
 	filter.PageSize = pageSize
 	filter.Page = page
+	filter.Cursor = cursor
+	if cursor != nil && slices.Contains(filter.States, dbmodel.InstanceDeprovisioned) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("cursor cannot be used with the %s state", pkg.StateDeprovisioned))
+		return
+	}
 	opDetail := getOpDetail(req)
 	kymaConfig := getBoolParam(pkg.KymaConfigParam, req)
 	clusterConfig := getBoolParam(pkg.ClusterConfigParam, req)
 	gardenerConfig := getBoolParam(pkg.GardenerConfigParam, req)
 
+	var drifts map[string]internal.RuntimeDrift
+	if getBoolParam(pkg.DriftParam, req) {
+		drifts, err = h.applyDriftFilter(&filter)
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		if len(filter.InstanceIDs) == 0 {
+			httputil.WriteResponse(w, http.StatusOK, pkg.RuntimesPage{Data: toReturn})
+			return
+		}
+	}
+
 	instances, count, totalCount, err := h.listInstances(filter)
 	if err != nil {
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while fetching instances: %w", err))
@@ -178,6 +203,10 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 			return
 		}
 
+		if drift, found := drifts[instance.InstanceID]; found {
+			dto.Drift = toDriftDTO(drift)
+		}
+
 		toReturn = append(toReturn, dto)
 	}
 
@@ -186,6 +215,10 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		Count:      count,
 		TotalCount: totalCount,
 	}
+	if len(instances) > 0 && !slices.Contains(filter.States, dbmodel.InstanceDeprovisioned) {
+		last := instances[len(instances)-1]
+		runtimePage.NextCursor = pagination.NextCursor(pageSize, len(instances), last.CreatedAt, last.InstanceID)
+	}
 	httputil.WriteResponse(w, http.StatusOK, runtimePage)
 }
 
@@ -384,6 +417,36 @@ func (h *Handler) setRuntimeOptionalAttributes(instance internal.Instance, dto *
 	return nil
 }
 
+// applyDriftFilter limits the filter to the instances with the detected drift and returns the drifts by the instance ID
+func (h *Handler) applyDriftFilter(filter *dbmodel.InstanceFilter) (map[string]internal.RuntimeDrift, error) {
+	list, err := h.runtimeDriftsDb.List()
+	if err != nil {
+		return nil, fmt.Errorf("while fetching runtime drifts: %w", err)
+	}
+	drifts := make(map[string]internal.RuntimeDrift, len(list))
+	instanceIDs := make([]string, 0, len(list))
+	for _, drift := range list {
+		if len(filter.InstanceIDs) > 0 && !slices.Contains(filter.InstanceIDs, drift.InstanceID) {
+			continue
+		}
+		drifts[drift.InstanceID] = drift
+		instanceIDs = append(instanceIDs, drift.InstanceID)
+	}
+	filter.InstanceIDs = instanceIDs
+	return drifts, nil
+}
+
+func toDriftDTO(drift internal.RuntimeDrift) *pkg.RuntimeDrift {
+	findings := make([]pkg.DriftFinding, 0, len(drift.Findings))
+	for _, f := range drift.Findings {
+		findings = append(findings, pkg.DriftFinding{Field: f.Field, Expected: f.Expected, Actual: f.Actual})
+	}
+	return &pkg.RuntimeDrift{
+		DetectedAt: drift.DetectedAt,
+		Findings:   findings,
+	}
+}
+
 func determineKymaVersion(pOprs []internal.ProvisioningOperation, uOprs []internal.UpgradeKymaOperation) string {
 	kymaVersion := ""
 	kymaVersionSetAt := time.Time{}
@@ -448,6 +511,8 @@ func (h *Handler) getFilters(req *http.Request) dbmodel.InstanceFilter {
 				filter.States = append(filter.States, dbmodel.InstanceUpgrading)
 			case pkg.StateUpdating:
 				filter.States = append(filter.States, dbmodel.InstanceUpdating)
+			case pkg.StateHibernated:
+				filter.States = append(filter.States, dbmodel.InstanceHibernated)
 			case pkg.StateSuspended:
 				filter.States = append(filter.States, dbmodel.InstanceDeprovisioned)
 			case pkg.StateDeprovisioned:
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
@@ -51,7 +52,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = instances.Insert(testInstance2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=1", nil)
 		require.NoError(t, err)
@@ -96,6 +97,87 @@ func TestRuntimeHandler(t *testing.T) {
 
 	})
 
+	t.Run("test cursor pagination should work", func(t *testing.T) {
+		// given
+		provisionerClient := provisioner.NewFakeClient()
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		states := memory.NewRuntimeStates()
+		createdAt := time.Now()
+		for _, id := range []string{"Test3", "Test1", "Test2"} {
+			err := instances.Insert(internal.Instance{
+				InstanceID: id,
+				CreatedAt:  createdAt,
+				Parameters: internal.ProvisioningParameters{},
+			})
+			require.NoError(t, err)
+		}
+
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		var fetched []string
+		cursor := ""
+		for i := 0; i < 3; i++ {
+			urlPath := "/runtimes?page_size=2"
+			if cursor != "" {
+				urlPath = fmt.Sprintf("%s&cursor=%s", urlPath, cursor)
+			}
+			req, err := http.NewRequest(http.MethodGet, urlPath, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rr, req)
+
+			// then
+			require.Equal(t, http.StatusOK, rr.Code)
+			var out pkg.RuntimesPage
+			err = json.Unmarshal(rr.Body.Bytes(), &out)
+			require.NoError(t, err)
+			assert.Equal(t, 3, out.TotalCount)
+			for _, rt := range out.Data {
+				fetched = append(fetched, rt.InstanceID)
+			}
+			cursor = out.NextCursor
+			if cursor == "" {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"Test1", "Test2", "Test3"}, fetched)
+	})
+
+	t.Run("test cursor validation should work", func(t *testing.T) {
+		// given
+		provisionerClient := provisioner.NewFakeClient()
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		states := memory.NewRuntimeStates()
+
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+		cursor := pagination.EncodeCursor(pagination.Cursor{CreatedAt: time.Now(), ID: "Test1"})
+
+		for _, urlPath := range []string{
+			"/runtimes?cursor=malformed",
+			"/runtimes?page=2&cursor=" + cursor,
+			"/runtimes?state=deprovisioned&cursor=" + cursor,
+		} {
+			req, err := http.NewRequest(http.MethodGet, urlPath, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rr, req)
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, rr.Code, urlPath)
+		}
+	})
+
 	t.Run("test validation should work", func(t *testing.T) {
 		// given
 		provisionerClient := provisioner.NewFakeClient()
@@ -103,7 +185,7 @@ func TestRuntimeHandler(t *testing.T) {
 		instances := memory.NewInstance(operations)
 		states := memory.NewRuntimeStates()
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "region", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "region", provisionerClient)
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=a", nil)
 		require.NoError(t, err)
@@ -159,7 +241,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = operations.InsertOperation(testOp2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		req, err := http.NewRequest("GET", fmt.Sprintf("/runtimes?account=%s&subaccount=%s&instance_id=%s&runtime_id=%s&region=%s&shoot=%s", testID1, testID1, testID1, testID1, testID1, fmt.Sprintf("Shoot-%s", testID1)), nil)
 		require.NoError(t, err)
@@ -233,7 +315,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = operations.InsertDeprovisioningOperation(deprovOp3)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -340,7 +422,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -411,7 +493,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -481,7 +563,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -534,7 +616,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = operations.InsertUpgradeKymaOperation(upgOp)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -666,7 +748,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = states.Insert(fixOpgClusterState)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -718,7 +800,7 @@ func TestRuntimeHandler(t *testing.T) {
 		_, err = provisionerClient.ProvisionRuntimeWithIDs(operation.GlobalAccountID, operation.SubAccountID, operation.RuntimeID, operation.ID, input)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "", provisionerClient)
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewRuntimeDrifts(), 2, "", provisionerClient)
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -766,3 +848,84 @@ func fixInstance(id string, t time.Time) internal.Instance {
 func fixRandomID() string {
 	return rand.String(16)
 }
+
+func TestRuntimeHandler_Drift(t *testing.T) {
+	// given
+	provisionerClient := provisioner.NewFakeClient()
+	operations := memory.NewOperation()
+	instances := memory.NewInstance(operations)
+	states := memory.NewRuntimeStates()
+	drifts := memory.NewRuntimeDrifts()
+	for i, id := range []string{"instance-1", "instance-2", "instance-3"} {
+		err := instances.Insert(internal.Instance{InstanceID: id, CreatedAt: time.Now().Add(time.Duration(i) * time.Minute)})
+		require.NoError(t, err)
+	}
+	detectedAt := time.Now().UTC().Truncate(time.Second)
+	err := drifts.Upsert(internal.RuntimeDrift{
+		InstanceID: "instance-2",
+		Findings:   []internal.DriftFinding{{Field: "machineType", Expected: "m5.xlarge", Actual: "m5.2xlarge"}},
+		DetectedAt: detectedAt,
+	})
+	require.NoError(t, err)
+
+	runtimeHandler := runtime.NewHandler(instances, operations, states, drifts, 2, "", provisionerClient)
+	router := mux.NewRouter()
+	runtimeHandler.AttachRoutes(router)
+
+	t.Run("should return only drifted runtimes with the findings", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/runtimes?drift=true", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var out pkg.RuntimesPage
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		require.Len(t, out.Data, 1)
+		assert.Equal(t, "instance-2", out.Data[0].InstanceID)
+		require.NotNil(t, out.Data[0].Drift)
+		assert.Equal(t, detectedAt, out.Data[0].Drift.DetectedAt.UTC())
+		assert.Equal(t, []pkg.DriftFinding{{Field: "machineType", Expected: "m5.xlarge", Actual: "m5.2xlarge"}}, out.Data[0].Drift.Findings)
+	})
+
+	t.Run("should return no runtimes if the filtered runtime has no drift", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/runtimes?drift=true&instance_id=instance-1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var out pkg.RuntimesPage
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		assert.Empty(t, out.Data)
+		assert.Equal(t, 0, out.TotalCount)
+	})
+
+	t.Run("should not return drift without the drift parameter", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/runtimes?instance_id=instance-2", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var out pkg.RuntimesPage
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		require.Len(t, out.Data, 1)
+		assert.Nil(t, out.Data[0].Drift)
+	})
+}
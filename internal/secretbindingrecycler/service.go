@@ -0,0 +1,219 @@
+package secretbindingrecycler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+type Action string
+
+const (
+	ReturnedToPool    Action = "ReturnedToPool"
+	FlaggedForCleanup Action = "FlaggedForManualCleanup"
+	Skipped           Action = "Skipped"
+	Failed            Action = "Failed"
+)
+
+type Config struct {
+	DryRun bool
+	// HyperscalerTypes lists the hyperscaler types whose dirty accounts are recycled
+	HyperscalerTypes []hyperscaler.Type
+	// ReturnToPoolHyperscalerTypes lists the keys of the hyperscaler types whose accounts are returned to the pool once
+	// they are verified. The unused dirty accounts of other types are flagged for manual cleanup.
+	ReturnToPoolHyperscalerTypes []string
+}
+
+// ReportEntry describes what was done with the dirty secret binding and why
+type ReportEntry struct {
+	SecretBinding   string   `json:"secretBinding"`
+	HyperscalerType string   `json:"hyperscalerType"`
+	TenantName      string   `json:"tenantName"`
+	Action          Action   `json:"action"`
+	Reason          string   `json:"reason"`
+	Shoots          []string `json:"shoots,omitempty"`
+	Instances       []string `json:"instances,omitempty"`
+}
+
+type Report struct {
+	DryRun  bool          `json:"dryRun"`
+	Entries []ReportEntry `json:"entries"`
+}
+
+// Count returns the number of the secret bindings with the given action
+func (r Report) Count(action Action) int {
+	count := 0
+	for _, entry := range r.Entries {
+		if entry.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Service finds the dirty accounts of the pool, verifies that nothing uses them anymore and returns them to the pool
+// or flags them for manual cleanup. The accounts are handled by the backend configured for their hyperscaler type.
+type Service struct {
+	pool           hyperscaler.AccountRecycler
+	gardenerClient dynamic.Interface
+	namespace      string
+	instances      hyperscaler.InstanceLister
+	cfg            Config
+	log            logrus.FieldLogger
+}
+
+func NewService(pool hyperscaler.AccountRecycler, gardenerClient dynamic.Interface, gardenerNamespace string, instances hyperscaler.InstanceLister, cfg Config, log logrus.FieldLogger) *Service {
+	return &Service{
+		pool:           pool,
+		gardenerClient: gardenerClient,
+		namespace:      gardenerNamespace,
+		instances:      instances,
+		cfg:            cfg,
+		log:            log,
+	}
+}
+
+func (s *Service) Run() (Report, error) {
+	report := Report{DryRun: s.cfg.DryRun, Entries: []ReportEntry{}}
+
+	usage, err := s.shootsBySecretBinding()
+	if err != nil {
+		return report, err
+	}
+
+	for _, hyperscalerType := range s.cfg.HyperscalerTypes {
+		accounts, err := s.pool.DirtyAccounts(hyperscalerType)
+		if err != nil {
+			return report, fmt.Errorf("while listing dirty accounts of %s: %w", hyperscalerType.GetKey(), err)
+		}
+		for _, account := range accounts {
+			entry := s.recycle(hyperscalerType, account, usage[account.Name])
+			s.log.WithFields(logrus.Fields{
+				"secretBinding":   entry.SecretBinding,
+				"hyperscalerType": entry.HyperscalerType,
+				"tenantName":      entry.TenantName,
+				"action":          entry.Action,
+				"shoots":          entry.Shoots,
+				"instances":       entry.Instances,
+				"dryRun":          s.cfg.DryRun,
+			}).Info(entry.Reason)
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Service) recycle(hyperscalerType hyperscaler.Type, account hyperscaler.DirtyAccount, shoots []string) ReportEntry {
+	entry := ReportEntry{
+		SecretBinding:   account.Name,
+		HyperscalerType: hyperscalerType.GetKey(),
+		TenantName:      account.TenantName,
+	}
+
+	switch {
+	case account.Shared:
+		entry.Action = Skipped
+		entry.Reason = "shared secret bindings are not recycled"
+		return entry
+	case len(shoots) > 0:
+		entry.Action = Skipped
+		entry.Reason = "the secret binding is still referenced by shoots"
+		entry.Shoots = shoots
+		return entry
+	case account.ManualCleanup:
+		entry.Action = Skipped
+		entry.Reason = "the secret binding is waiting for manual cleanup"
+		return entry
+	}
+
+	instances, err := s.instancesBeingDeprovisioned(hyperscalerType, account)
+	if err != nil {
+		entry.Action = Failed
+		entry.Reason = fmt.Sprintf("while verifying the instances of the tenant: %s", err)
+		return entry
+	}
+	if len(instances) > 0 {
+		entry.Action = Skipped
+		entry.Reason = "the deprovisioning of the instances of the tenant is not finished, the account may still have their resources"
+		entry.Instances = instances
+		return entry
+	}
+
+	update := s.pool.FlagForManualCleanup
+	if slices.Contains(s.cfg.ReturnToPoolHyperscalerTypes, entry.HyperscalerType) {
+		update = s.pool.ReturnToPool
+		entry.Action = ReturnedToPool
+		entry.Reason = "no shoot references the secret binding and no instance of the tenant is being deprovisioned, it is returned to the pool"
+	} else {
+		entry.Action = FlaggedForCleanup
+		entry.Reason = "no shoot references the secret binding, the account of the hyperscaler type must be cleaned up manually"
+	}
+	if s.cfg.DryRun {
+		return entry
+	}
+
+	if err := update(hyperscalerType, account); err != nil {
+		entry.Reason = fmt.Sprintf("while updating the account (%s): %s", entry.Action, err)
+		entry.Action = Failed
+	}
+	return entry
+}
+
+// instancesBeingDeprovisioned returns the IDs of the instances of the tenant on the hyperscaler whose deprovisioning
+// has started but the instance is not removed yet, because it is in progress or failed
+func (s *Service) instancesBeingDeprovisioned(hyperscalerType hyperscaler.Type, account hyperscaler.DirtyAccount) ([]string, error) {
+	var ids []string
+	for _, filter := range []dbmodel.InstanceFilter{
+		{GlobalAccountIDs: []string{account.TenantName}},
+		{SubscriptionGlobalAccountIDs: []string{account.TenantName}},
+	} {
+		instances, _, _, err := s.instances.List(filter)
+		if err != nil {
+			return nil, fmt.Errorf("while listing instances: %w", err)
+		}
+		for _, instance := range instances {
+			if instance.DeletedAt.IsZero() || slices.Contains(ids, instance.InstanceID) ||
+				instance.GetSubscriptionGlobalAccoundID() != account.TenantName ||
+				internal.IsEuAccess(instance.Parameters.PlatformRegion) != account.EUAccess {
+				continue
+			}
+			instanceType, err := hyperscaler.HypTypeFromCloudProviderWithRegion(instance.Provider, &instance.ProviderRegion)
+			if err != nil || instanceType.GetKey() != hyperscalerType.GetKey() {
+				continue
+			}
+			ids = append(ids, instance.InstanceID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *Service) shootsBySecretBinding() (map[string][]string, error) {
+	shoots, err := s.gardenerClient.Resource(gardener.ShootResource).Namespace(s.namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing shoots: %w", err)
+	}
+
+	usage := make(map[string][]string)
+	for _, item := range shoots.Items {
+		shoot := gardener.Shoot{Unstructured: item}
+		name := shoot.GetSpecSecretBindingName()
+		if name != "" {
+			usage[name] = append(usage[name], shoot.GetName())
+		}
+	}
+	for _, names := range usage {
+		sort.Strings(names)
+	}
+	return usage, nil
+}
@@ -0,0 +1,199 @@
+package secretbindingrecycler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+const testNamespace = "garden-test"
+
+func TestService_Run(t *testing.T) {
+	t.Run("should recycle dirty secret bindings not used by shoots", func(t *testing.T) {
+		// given
+		client := fixGardenerClient()
+		svc := newService(client, storage.NewMemoryStorage(), Config{ReturnToPoolHyperscalerTypes: []string{"aws"}})
+
+		// when
+		report, err := svc.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		require.Len(t, report.Entries, 4)
+		assert.Equal(t, ReportEntry{SecretBinding: "sb-gcp-flagged", HyperscalerType: "gcp", TenantName: "tenant5", Action: Skipped,
+			Reason: "the secret binding is waiting for manual cleanup"}, report.Entries[0])
+		assert.Equal(t, FlaggedForCleanup, report.Entries[1].Action)
+		assert.Equal(t, "sb-gcp-free", report.Entries[1].SecretBinding)
+		assert.Equal(t, ReportEntry{SecretBinding: "sb-aws-free", HyperscalerType: "aws", TenantName: "tenant1", Action: ReturnedToPool,
+			Reason: "no shoot references the secret binding and no instance of the tenant is being deprovisioned, it is returned to the pool"}, report.Entries[2])
+		assert.Equal(t, Skipped, report.Entries[3].Action)
+		assert.Equal(t, []string{"shoot1", "shoot2"}, report.Entries[3].Shoots)
+
+		assert.Equal(t, map[string]string{"hyperscalerType": "aws"}, getLabels(t, client, "sb-aws-free"))
+		assert.Equal(t, map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant3", "dirty": "true", hyperscaler.ManualCleanupLabel: "true"}, getLabels(t, client, "sb-gcp-free"))
+		assert.Equal(t, "true", getLabels(t, client, "sb-aws-used")["dirty"])
+
+		// when
+		report, err = svc.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.Count(ReturnedToPool))
+		assert.Equal(t, 0, report.Count(FlaggedForCleanup))
+		assert.Equal(t, 3, report.Count(Skipped))
+	})
+
+	t.Run("should not change secret bindings in dry run", func(t *testing.T) {
+		// given
+		client := fixGardenerClient()
+		svc := newService(client, storage.NewMemoryStorage(), Config{DryRun: true, ReturnToPoolHyperscalerTypes: []string{"aws"}})
+
+		// when
+		report, err := svc.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 1, report.Count(ReturnedToPool))
+		assert.Equal(t, 1, report.Count(FlaggedForCleanup))
+		assert.Equal(t, "true", getLabels(t, client, "sb-aws-free")["dirty"])
+		assert.Equal(t, "tenant1", getLabels(t, client, "sb-aws-free")["tenantName"])
+		assert.NotContains(t, getLabels(t, client, "sb-gcp-free"), hyperscaler.ManualCleanupLabel)
+	})
+
+	t.Run("should not recycle the account of the tenant whose instance is being deprovisioned", func(t *testing.T) {
+		// given
+		client := fixGardenerClient()
+		db := storage.NewMemoryStorage()
+		instance := fixture.FixInstance("instance-1")
+		instance.GlobalAccountID = "tenant1"
+		instance.SubscriptionGlobalAccountID = ""
+		instance.Provider = internal.AWS
+		instance.Parameters.PlatformRegion = "cf-us10"
+		instance.DeletedAt = time.Now()
+		require.NoError(t, db.Instances().Insert(instance))
+		svc := newService(client, db, Config{ReturnToPoolHyperscalerTypes: []string{"aws"}})
+
+		// when
+		report, err := svc.Run()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.Count(ReturnedToPool))
+		assert.Equal(t, []string{"instance-1"}, report.Entries[2].Instances)
+		assert.Equal(t, "true", getLabels(t, client, "sb-aws-free")["dirty"])
+	})
+
+	t.Run("should recycle the accounts of the db backend", func(t *testing.T) {
+		// given
+		client := fixGardenerClient()
+		db := storage.NewMemoryStorage()
+		for _, account := range []internal.HyperscalerAccount{
+			{Name: "aws-free", SecretName: "aws-free", HyperscalerType: "aws", TenantName: "tenant1", Dirty: true},
+			{Name: "aws-clean", SecretName: "aws-clean", HyperscalerType: "aws", TenantName: "tenant2"},
+			{Name: "gcp-free", SecretName: "gcp-free", HyperscalerType: "gcp", TenantName: "tenant3", Dirty: true},
+		} {
+			require.NoError(t, db.HyperscalerAccounts().Insert(account))
+		}
+		pool, err := hyperscaler.NewConfiguredPoolRegistry(hyperscaler.PoolConfig{Backend: hyperscaler.DatabaseBackend}, client, testNamespace, db.HyperscalerAccounts(), db.Instances())
+		require.NoError(t, err)
+		svc := NewService(pool, client, testNamespace, db.Instances(), Config{
+			HyperscalerTypes:             []hyperscaler.Type{hyperscaler.GCP(), hyperscaler.AWS()},
+			ReturnToPoolHyperscalerTypes: []string{"aws"},
+		}, logrus.New())
+
+		// when
+		report, err := svc.Run()
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, report.Entries, 2)
+		assert.Equal(t, FlaggedForCleanup, report.Entries[0].Action)
+		assert.Equal(t, ReturnedToPool, report.Entries[1].Action)
+
+		accounts, err := db.HyperscalerAccounts().List("aws")
+		require.NoError(t, err)
+		for _, account := range accounts {
+			assert.False(t, account.Dirty)
+		}
+		accounts, err = db.HyperscalerAccounts().List("gcp")
+		require.NoError(t, err)
+		assert.True(t, accounts[0].Dirty)
+		assert.True(t, accounts[0].ManualCleanup)
+	})
+}
+
+func newService(client *fake.FakeDynamicClient, db storage.BrokerStorage, cfg Config) *Service {
+	cfg.HyperscalerTypes = []hyperscaler.Type{hyperscaler.GCP(), hyperscaler.AWS()}
+	pool := hyperscaler.NewPoolRegistry(hyperscaler.PoolConfig{Backend: hyperscaler.SecretBindingBackend})
+	pool.Register(hyperscaler.SecretBindingBackend, hyperscaler.NewAccountPool(client, testNamespace), hyperscaler.NewSharedGardenerAccountPool(client, testNamespace))
+	return NewService(pool, client, testNamespace, db.Instances(), cfg, logrus.New())
+}
+
+func fixGardenerClient() *fake.FakeDynamicClient {
+	return gardener.NewDynamicFakeClient(
+		fixSecretBinding("sb-aws-free", map[string]interface{}{"hyperscalerType": "aws", "tenantName": "tenant1", "dirty": "true"}),
+		fixSecretBinding("sb-aws-used", map[string]interface{}{"hyperscalerType": "aws", "tenantName": "tenant2", "dirty": "true"}),
+		fixSecretBinding("sb-aws-clean", map[string]interface{}{"hyperscalerType": "aws", "tenantName": "tenant4"}),
+		fixSecretBinding("sb-gcp-free", map[string]interface{}{"hyperscalerType": "gcp", "tenantName": "tenant3", "dirty": "true"}),
+		fixSecretBinding("sb-gcp-flagged", map[string]interface{}{"hyperscalerType": "gcp", "tenantName": "tenant5", "dirty": "true", hyperscaler.ManualCleanupLabel: "true"}),
+		fixShoot("shoot2", "sb-aws-used"),
+		fixShoot("shoot1", "sb-aws-used"),
+		fixShoot("shoot3", "sb-aws-clean"),
+	)
+}
+
+func fixSecretBinding(name string, labels map[string]interface{}) runtime.Object {
+	secretBinding := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": testNamespace,
+				"labels":    labels,
+			},
+			"secretRef": map[string]interface{}{
+				"name":      name,
+				"namespace": testNamespace,
+			},
+		},
+	}
+	secretBinding.SetGroupVersionKind(schema.GroupVersionKind{Group: "core.gardener.cloud", Version: "v1beta1", Kind: "SecretBinding"})
+	return secretBinding
+}
+
+func fixShoot(name, secretBindingName string) runtime.Object {
+	shoot := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": testNamespace,
+			},
+			"spec": map[string]interface{}{
+				"secretBindingName": secretBindingName,
+			},
+		},
+	}
+	shoot.SetGroupVersionKind(schema.GroupVersionKind{Group: "core.gardener.cloud", Version: "v1beta1", Kind: "Shoot"})
+	return shoot
+}
+
+func getLabels(t *testing.T, client *fake.FakeDynamicClient, name string) map[string]string {
+	secretBinding, err := client.Resource(gardener.SecretBindingResource).Namespace(testNamespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return secretBinding.GetLabels()
+}
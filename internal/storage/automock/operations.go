@@ -154,6 +154,29 @@ func (_m *Operations) GetOperationByInstanceID(instanceID string) (*internal.Ope
 	return r0, r1
 }
 
+// GetOperationsWithCompensationInProgress provides a mock function with given fields: operationType
+func (_m *Operations) GetOperationsWithCompensationInProgress(operationType internal.OperationType) ([]internal.Operation, error) {
+	ret := _m.Called(operationType)
+
+	var r0 []internal.Operation
+	if rf, ok := ret.Get(0).(func(internal.OperationType) []internal.Operation); ok {
+		r0 = rf(operationType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]internal.Operation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(internal.OperationType) error); ok {
+		r1 = rf(operationType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOperationStatsByPlan provides a mock function with given fields:
 func (_m *Operations) GetOperationStatsByPlan() (map[string]internal.OperationStats, error) {
 	ret := _m.Called()
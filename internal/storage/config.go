@@ -18,7 +18,12 @@ type Config struct {
 	SSLMode     string `envconfig:"default=disable"`
 	SSLRootCert string `envconfig:"optional"`
 
+	// SecretKey is the legacy key used to decrypt the values which do not carry the key ID
 	SecretKey string `envconfig:"optional"`
+	// SecretKeys are the versioned keys in the keyID:key format
+	SecretKeys []string `envconfig:"optional"`
+	// ActiveSecretKeyID is the ID of the key used to encrypt the new values
+	ActiveSecretKeyID string `envconfig:"optional"`
 
 	MaxOpenConns    int           `envconfig:"default=8"`
 	MaxIdleConns    int           `envconfig:"default=2"`
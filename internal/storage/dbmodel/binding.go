@@ -0,0 +1,17 @@
+package dbmodel
+
+import "time"
+
+type BindingDTO struct {
+	ID         string
+	InstanceID string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+	ExpiredAt *time.Time
+
+	Kubeconfig  string
+	State       string
+	Description string
+}
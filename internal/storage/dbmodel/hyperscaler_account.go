@@ -0,0 +1,20 @@
+package dbmodel
+
+import "time"
+
+type HyperscalerAccountDTO struct {
+	Name            string
+	SecretName      string
+	HyperscalerType string
+	TenantName      string
+
+	Shared        bool
+	Dirty         bool
+	Internal      bool
+	EUAccess      bool
+	ManualCleanup bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   int
+}
@@ -3,6 +3,8 @@ package dbmodel
 import (
 	"database/sql"
 	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
 )
 
 type InstanceState string
@@ -15,14 +17,17 @@ const (
 	InstanceDeprovisioning   InstanceState = "deprovisioning"
 	InstanceUpgrading        InstanceState = "upgrading"
 	InstanceUpdating         InstanceState = "updating"
+	InstanceHibernated       InstanceState = "hibernated"
 	InstanceDeprovisioned    InstanceState = "deprovisioned"
 	InstanceNotDeprovisioned InstanceState = "notDeprovisioned"
 )
 
 // InstanceFilter holds the filters when querying Instances
 type InstanceFilter struct {
-	PageSize                     int
-	Page                         int
+	PageSize int
+	Page     int
+	// Cursor enables the keyset pagination, the page starts after the cursor and the Page is ignored
+	Cursor                       *pagination.Cursor
 	GlobalAccountIDs             []string
 	SubscriptionGlobalAccountIDs []string
 	SubAccountIDs                []string
@@ -53,10 +58,11 @@ type InstanceDTO struct {
 	ProviderRegion         string
 	Provider               string
 
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt time.Time
-	ExpiredAt *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    time.Time
+	ExpiredAt    *time.Time
+	HibernatedAt *time.Time
 
 	Version int
 }
@@ -70,3 +76,15 @@ type InstanceWithOperationDTO struct {
 	Data               sql.NullString
 	Description        sql.NullString
 }
+
+// ProvisioningParametersRecordDTO holds the provisioning parameters of the instance or the operation with the encrypted secrets
+type ProvisioningParametersRecordDTO struct {
+	ID                     string
+	ProvisioningParameters string
+}
+
+// ProvisionerInputRecordDTO holds the encrypted provisioner input of the operation
+type ProvisionerInputRecordDTO struct {
+	ID               string
+	ProvisionerInput string
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 )
 
@@ -12,7 +13,9 @@ type OperationFilter struct {
 	InstanceFilter *InstanceFilter
 	Page           int
 	PageSize       int
-	States         []string
+	// Cursor enables the keyset pagination, the page starts after the cursor and the Page is ignored
+	Cursor *pagination.Cursor
+	States []string
 }
 
 type OperationDTO struct {
@@ -30,6 +33,7 @@ type OperationDTO struct {
 	Description            string
 	FinishedStages         sql.NullString
 	ProvisioningParameters sql.NullString
+	ProvisionerInput       sql.NullString
 
 	Type internal.OperationType
 }
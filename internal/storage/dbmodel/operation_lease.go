@@ -0,0 +1,10 @@
+package dbmodel
+
+import "time"
+
+type OperationLeaseDTO struct {
+	OperationID string
+	Owner       string
+	AcquiredAt  time.Time
+	ExpiresAt   time.Time
+}
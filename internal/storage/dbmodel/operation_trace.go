@@ -0,0 +1,20 @@
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+type OperationTraceDTO struct {
+	ID          string
+	OperationID string
+	InstanceID  string
+	Stage       string
+	StepName    string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	// Backoff is the retry duration returned by the step in milliseconds
+	Backoff int64
+	Error   sql.NullString
+	State   string
+}
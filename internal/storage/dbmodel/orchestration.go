@@ -1,20 +1,24 @@
 package dbmodel
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
 
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
 )
 
 // OrchestrationFilter holds the filters when listing orchestrations
 type OrchestrationFilter struct {
 	Page     int
 	PageSize int
-	Types    []string
-	States   []string
+	// Cursor enables the keyset pagination, the page starts after the cursor and the Page is ignored
+	Cursor *pagination.Cursor
+	Types  []string
+	States []string
 }
 
 type OrchestrationDTO struct {
@@ -25,6 +29,7 @@ type OrchestrationDTO struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	Parameters      string
+	CanaryState     sql.NullString
 }
 
 func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
@@ -32,6 +37,14 @@ func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
 	if err != nil {
 		return OrchestrationDTO{}, err
 	}
+	var canaryState sql.NullString
+	if o.Canary != nil {
+		state, err := json.Marshal(o.Canary)
+		if err != nil {
+			return OrchestrationDTO{}, err
+		}
+		canaryState = sql.NullString{String: string(state), Valid: true}
+	}
 
 	dto := OrchestrationDTO{
 		OrchestrationID: o.OrchestrationID,
@@ -41,6 +54,7 @@ func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
 		UpdatedAt:       o.UpdatedAt,
 		Description:     o.Description,
 		Parameters:      string(params),
+		CanaryState:     canaryState,
 	}
 	return dto, nil
 }
@@ -51,6 +65,14 @@ func (o *OrchestrationDTO) ToOrchestration() (internal.Orchestration, error) {
 	if err != nil {
 		return internal.Orchestration{}, err
 	}
+	var canaryState *internal.CanaryState
+	if o.CanaryState.Valid {
+		canaryState = &internal.CanaryState{}
+		err = json.Unmarshal([]byte(o.CanaryState.String), canaryState)
+		if err != nil {
+			return internal.Orchestration{}, err
+		}
+	}
 	return internal.Orchestration{
 		OrchestrationID: o.OrchestrationID,
 		Type:            orchestration.Type(o.Type),
@@ -59,5 +81,6 @@ func (o *OrchestrationDTO) ToOrchestration() (internal.Orchestration, error) {
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
 		Parameters:      params,
+		Canary:          canaryState,
 	}, nil
 }
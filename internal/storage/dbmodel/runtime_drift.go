@@ -0,0 +1,12 @@
+package dbmodel
+
+import "time"
+
+type RuntimeDriftDTO struct {
+	InstanceID string
+	RuntimeID  string
+	ShootName  string
+	// Findings keeps the JSON list of the drift findings
+	Findings   string
+	DetectedAt time.Time
+}
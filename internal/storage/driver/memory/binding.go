@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+)
+
+type bindings struct {
+	mu sync.Mutex
+
+	data map[string]internal.Binding
+}
+
+func NewBindings() *bindings {
+	return &bindings{
+		data: make(map[string]internal.Binding),
+	}
+}
+
+func (s *bindings) Insert(binding *internal.Binding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := bindingKey(binding.InstanceID, binding.ID)
+	if _, found := s.data[key]; found {
+		return dberr.AlreadyExists("binding %s of the instance %s already exist", binding.ID, binding.InstanceID)
+	}
+	s.data[key] = *binding
+
+	return nil
+}
+
+func (s *bindings) Update(binding *internal.Binding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := bindingKey(binding.InstanceID, binding.ID)
+	if _, found := s.data[key]; !found {
+		return dberr.NotFound("binding %s of the instance %s not found", binding.ID, binding.InstanceID)
+	}
+	s.data[key] = *binding
+
+	return nil
+}
+
+func (s *bindings) Get(instanceID, bindingID string) (*internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	binding, found := s.data[bindingKey(instanceID, bindingID)]
+	if !found {
+		return nil, dberr.NotFound("binding %s of the instance %s not found", bindingID, instanceID)
+	}
+
+	return &binding, nil
+}
+
+func (s *bindings) ListByInstanceID(instanceID string) ([]internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Binding, 0)
+	for _, binding := range s.data {
+		if binding.InstanceID == instanceID {
+			result = append(result, binding)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (s *bindings) ListExpired(now time.Time) ([]internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Binding, 0)
+	for _, binding := range s.data {
+		if binding.State == domain.Succeeded && !binding.IsExpired() && !binding.ExpiresAt.After(now) {
+			result = append(result, binding)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ExpiresAt.Before(result[j].ExpiresAt)
+	})
+
+	return result, nil
+}
+
+func (s *bindings) Delete(instanceID, bindingID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, bindingKey(instanceID, bindingID))
+
+	return nil
+}
+
+func bindingKey(instanceID, bindingID string) string {
+	return instanceID + "/" + bindingID
+}
@@ -0,0 +1,28 @@
+package memory
+
+// encryptedData does nothing because the memory storage does not encrypt the secrets
+type encryptedData struct{}
+
+func NewEncryptedData() *encryptedData {
+	return &encryptedData{}
+}
+
+func (s *encryptedData) ReencryptInstances(afterID string, batchSize int) (string, int, error) {
+	return "", 0, nil
+}
+
+func (s *encryptedData) ReencryptOperations(afterID string, batchSize int) (string, int, error) {
+	return "", 0, nil
+}
+
+func (s *encryptedData) ReencryptProvisionerInputs(afterID string, batchSize int) (string, int, error) {
+	return "", 0, nil
+}
+
+func (s *encryptedData) ReencryptBindings(afterID string, batchSize int) (string, int, error) {
+	return "", 0, nil
+}
+
+func (s *encryptedData) ReencryptRuntimeStates(afterID string, batchSize int) (string, int, error) {
+	return "", 0, nil
+}
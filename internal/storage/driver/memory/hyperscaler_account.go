@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+)
+
+type hyperscalerAccounts struct {
+	mu sync.Mutex
+
+	data map[string]internal.HyperscalerAccount
+}
+
+func NewHyperscalerAccounts() *hyperscalerAccounts {
+	return &hyperscalerAccounts{
+		data: make(map[string]internal.HyperscalerAccount),
+	}
+}
+
+func (s *hyperscalerAccounts) Insert(account internal.HyperscalerAccount) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.data[account.Name]; found {
+		return dberr.AlreadyExists("hyperscaler account %s already exists", account.Name)
+	}
+	s.data[account.Name] = account
+
+	return nil
+}
+
+func (s *hyperscalerAccounts) Update(account internal.HyperscalerAccount) (*internal.HyperscalerAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, found := s.data[account.Name]
+	if !found {
+		return nil, dberr.NotFound("hyperscaler account %s not found", account.Name)
+	}
+	if old.Version != account.Version {
+		return nil, dberr.Conflict("unable to update hyperscaler account %s - conflict", account.Name)
+	}
+	account.Version = account.Version + 1
+	s.data[account.Name] = account
+
+	return &account, nil
+}
+
+func (s *hyperscalerAccounts) List(hyperscalerType string) ([]internal.HyperscalerAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.HyperscalerAccount, 0)
+	for _, account := range s.data {
+		if account.HyperscalerType == hyperscalerType {
+			result = append(result, account)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
@@ -227,6 +227,11 @@ func (s *instances) List(filter dbmodel.InstanceFilter) ([]internal.Instance, in
 
 	instances := s.filterInstances(filter)
 	sortInstancesByCreatedAt(instances)
+	if filter.Cursor != nil {
+		offset = sort.Search(len(instances), func(i int) bool {
+			return filter.Cursor.Precedes(instances[i].CreatedAt, instances[i].InstanceID)
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(instances); i++ {
 		toReturn = append(toReturn, s.instances[instances[i].InstanceID])
@@ -240,6 +245,9 @@ func (s *instances) List(filter dbmodel.InstanceFilter) ([]internal.Instance, in
 
 func sortInstancesByCreatedAt(instances []internal.Instance) {
 	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].CreatedAt.Equal(instances[j].CreatedAt) {
+			return instances[i].InstanceID < instances[j].InstanceID
+		}
 		return instances[i].CreatedAt.Before(instances[j].CreatedAt)
 	})
 }
@@ -273,6 +281,9 @@ func (s *instances) filterInstances(filter dbmodel.InstanceFilter) []internal.In
 		if ok = matchFilter(v.ServicePlanName, filter.Plans, equal); !ok {
 			continue
 		}
+		if ok = matchFilter(v.ServicePlanID, filter.PlanIDs, equal); !ok {
+			continue
+		}
 		if ok = matchFilter(v.ProviderRegion, filter.Regions, equal); !ok {
 			continue
 		}
@@ -283,7 +294,7 @@ func (s *instances) filterInstances(filter dbmodel.InstanceFilter) []internal.In
 				continue
 			}
 		}
-		if ok = s.matchInstanceState(v.InstanceID, filter.States); !ok {
+		if ok = s.matchInstanceState(v, filter.States); !ok {
 			continue
 		}
 
@@ -305,11 +316,11 @@ func matchFilter(value string, filters []string, match func(string, string) bool
 	return false
 }
 
-func (s *instances) matchInstanceState(instanceID string, states []dbmodel.InstanceState) bool {
+func (s *instances) matchInstanceState(instance internal.Instance, states []dbmodel.InstanceState) bool {
 	if len(states) == 0 {
 		return true
 	}
-	op, err := s.operationsStorage.GetLastOperation(instanceID)
+	op, err := s.operationsStorage.GetLastOperation(instance.InstanceID)
 	if err != nil {
 		// To support instance test cases without any operations
 		return true
@@ -321,6 +332,10 @@ func (s *instances) matchInstanceState(instanceID string, states []dbmodel.Insta
 			if op.State == domain.Succeeded && op.Type != internal.OperationTypeDeprovision {
 				return true
 			}
+		case dbmodel.InstanceHibernated:
+			if op.State == domain.Succeeded && op.Type != internal.OperationTypeDeprovision && instance.IsHibernated() {
+				return true
+			}
 		case dbmodel.InstanceFailed:
 			if op.State == domain.Failed && (op.Type == internal.OperationTypeProvision || op.Type == internal.OperationTypeDeprovision) {
 				return true
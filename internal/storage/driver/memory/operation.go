@@ -135,6 +135,16 @@ func (s *operations) UpdateOperation(op internal.Operation) (*internal.Operation
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if upgradeClusterOp, exists := s.upgradeClusterOperations[op.ID]; exists {
+		if upgradeClusterOp.Version != op.Version {
+			return nil, dberr.Conflict("unable to update operation with id %s (for instance id %s) - conflict", op.ID, op.InstanceID)
+		}
+		op.Version = op.Version + 1
+		upgradeClusterOp.Operation = op
+		s.upgradeClusterOperations[op.ID] = upgradeClusterOp
+		return &op, nil
+	}
+
 	oldOp, exists := s.operations[op.ID]
 	if !exists {
 		return nil, dberr.NotFound("instance operation with id %s not found", op.ID)
@@ -466,6 +476,20 @@ func (s *operations) GetNotFinishedOperationsByType(opType internal.OperationTyp
 	return ops, nil
 }
 
+func (s *operations) GetOperationsWithCompensationInProgress(opType internal.OperationType) ([]internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ops := make([]internal.Operation, 0)
+	for _, op := range s.operations {
+		if op.Type == opType && op.State == domain.Failed && op.CompensationInProgress {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops, nil
+}
+
 func (s *operations) GetOperationsForIDs(opIdList []string) ([]internal.Operation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -602,8 +626,13 @@ func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.
 		return nil, 0, 0, fmt.Errorf("while listing operations: %w", err)
 	}
 	s.sortByCreatedAt(operations)
+	if filter.Cursor != nil {
+		offset = sort.Search(len(operations), func(i int) bool {
+			return filter.Cursor.Precedes(operations[i].CreatedAt, operations[i].ID)
+		})
+	}
 
-	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations)+offset; i++ {
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
 		result = append(result, operations[i])
 	}
 
@@ -633,6 +662,11 @@ func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID
 
 	operations := s.filterUpgradeKyma(orchestrationID, filter)
 	s.sortUpgradeKymaByCreatedAt(operations)
+	if filter.Cursor != nil {
+		offset = sort.Search(len(operations), func(i int) bool {
+			return filter.Cursor.Precedes(operations[i].CreatedAt, operations[i].ID)
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
 		result = append(result, internal.UpgradeKymaOperation{Operation: s.operations[operations[i].ID]})
@@ -653,6 +687,11 @@ func (s *operations) ListOperationsByOrchestrationID(orchestrationID string, fil
 
 	operations := s.filterOperations(orchestrationID, filter)
 	s.sortByCreatedAt(operations)
+	if filter.Cursor != nil {
+		offset = sort.Search(len(operations), func(i int) bool {
+			return filter.Cursor.Precedes(operations[i].CreatedAt, operations[i].ID)
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
 		result = append(result, s.operations[operations[i].ID])
@@ -688,6 +727,11 @@ func (s *operations) ListUpgradeClusterOperationsByOrchestrationID(orchestration
 
 	operations := s.filterUpgradeCluster(orchestrationID, filter)
 	s.sortUpgradeClusterByCreatedAt(operations)
+	if filter.Cursor != nil {
+		offset = sort.Search(len(operations), func(i int) bool {
+			return filter.Cursor.Precedes(operations[i].CreatedAt, operations[i].ID)
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
 		result = append(result, s.upgradeClusterOperations[operations[i].Operation.ID])
@@ -779,6 +823,9 @@ func (s *operations) ListUpdatingOperationsByInstanceID(instanceID string) ([]in
 
 func (s *operations) sortUpgradeKymaByCreatedAt(operations []internal.UpgradeKymaOperation) {
 	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].CreatedAt.Equal(operations[j].CreatedAt) {
+			return operations[i].ID < operations[j].ID
+		}
 		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
 	})
 }
@@ -791,6 +838,9 @@ func (s *operations) sortUpgradeKymaByCreatedAtDesc(operations []internal.Upgrad
 
 func (s *operations) sortUpgradeClusterByCreatedAt(operations []internal.UpgradeClusterOperation) {
 	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].CreatedAt.Equal(operations[j].CreatedAt) {
+			return operations[i].ID < operations[j].ID
+		}
 		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
 	})
 }
@@ -821,6 +871,9 @@ func (s *operations) sortDeprovisioningByCreatedAtDesc(operations []internal.Dep
 
 func (s *operations) sortByCreatedAt(operations []internal.Operation) {
 	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].CreatedAt.Equal(operations[j].CreatedAt) {
+			return operations[i].ID < operations[j].ID
+		}
 		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
 	})
 }
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+)
+
+type operationLeases struct {
+	mu sync.Mutex
+
+	leases map[string]internal.OperationLease
+}
+
+func NewOperationLeases() *operationLeases {
+	return &operationLeases{
+		leases: make(map[string]internal.OperationLease),
+	}
+}
+
+func (s *operationLeases) Acquire(operationID, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	lease, found := s.leases[operationID]
+	if found && lease.Owner != owner && lease.ExpiresAt.After(now) {
+		return false, nil
+	}
+	s.leases[operationID] = internal.OperationLease{
+		OperationID: operationID,
+		Owner:       owner,
+		AcquiredAt:  now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	return true, nil
+}
+
+func (s *operationLeases) Renew(operationID, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, found := s.leases[operationID]
+	if !found || lease.Owner != owner {
+		return false, nil
+	}
+	lease.ExpiresAt = time.Now().Add(ttl)
+	s.leases[operationID] = lease
+
+	return true, nil
+}
+
+func (s *operationLeases) Release(operationID, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lease, found := s.leases[operationID]; found && lease.Owner == owner {
+		delete(s.leases, operationID)
+	}
+
+	return nil
+}
+
+func (s *operationLeases) GetByOperationID(operationID string) (internal.OperationLease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, found := s.leases[operationID]
+	if !found {
+		return internal.OperationLease{}, dberr.NotFound("lease of the operation %s not found", operationID)
+	}
+
+	return lease, nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_operations_ListOperations(t *testing.T) {
+	// given
+	operations := NewOperation()
+	createdAt := time.Now()
+	for i := 0; i < 5; i++ {
+		operation := fixture.FixOperation(fmt.Sprintf("op-%d", i), fmt.Sprintf("inst-%d", i), internal.OperationTypeProvision)
+		operation.CreatedAt = createdAt.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, operations.InsertOperation(operation))
+	}
+
+	t.Run("should return an empty page after the last operation", func(t *testing.T) {
+		// when
+		result, count, total, err := operations.ListOperations(dbmodel.OperationFilter{Page: 3, PageSize: 3})
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.Equal(t, 5, total)
+		assert.Empty(t, result)
+	})
+
+	t.Run("should return the page after the cursor", func(t *testing.T) {
+		// when
+		result, count, _, err := operations.ListOperations(dbmodel.OperationFilter{
+			PageSize: 3,
+			Cursor:   &pagination.Cursor{CreatedAt: createdAt.Add(3 * time.Minute), ID: "op-3"},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, "op-4", result[0].ID)
+	})
+
+	t.Run("should return an empty page without the page size", func(t *testing.T) {
+		// when
+		result, count, _, err := operations.ListOperations(dbmodel.OperationFilter{
+			Cursor: &pagination.Cursor{CreatedAt: createdAt.Add(2 * time.Minute), ID: "op-2"},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Len(t, result, 2)
+	})
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+)
+
+type operationTraces struct {
+	mu sync.Mutex
+
+	traces []internal.OperationTrace
+}
+
+func NewOperationTraces() *operationTraces {
+	return &operationTraces{
+		traces: make([]internal.OperationTrace, 0),
+	}
+}
+
+func (s *operationTraces) Insert(trace internal.OperationTrace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.traces = append(s.traces, trace)
+
+	return nil
+}
+
+func (s *operationTraces) ListByOperationID(operationID string) ([]internal.OperationTrace, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.OperationTrace, 0)
+	for _, trace := range s.traces {
+		if trace.OperationID == operationID {
+			result = append(result, trace)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result, nil
+}
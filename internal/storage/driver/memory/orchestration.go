@@ -53,6 +53,11 @@ func (s *orchestrations) List(filter dbmodel.OrchestrationFilter) ([]internal.Or
 
 	orchestrations := s.filter(filter)
 	s.sortByCreatedAt(orchestrations)
+	if filter.Cursor != nil {
+		offset = sort.Search(len(orchestrations), func(i int) bool {
+			return filter.Cursor.Precedes(orchestrations[i].CreatedAt, orchestrations[i].OrchestrationID)
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(orchestrations); i++ {
 		result = append(result, s.orchestrations[orchestrations[i].OrchestrationID])
@@ -78,6 +83,9 @@ func (s *orchestrations) Update(orchestration internal.Orchestration) error {
 
 func (s *orchestrations) sortByCreatedAt(orchestrations []internal.Orchestration) {
 	sort.Slice(orchestrations, func(i, j int) bool {
+		if orchestrations[i].CreatedAt.Equal(orchestrations[j].CreatedAt) {
+			return orchestrations[i].OrchestrationID < orchestrations[j].OrchestrationID
+		}
 		return orchestrations[i].CreatedAt.Before(orchestrations[j].CreatedAt)
 	})
 }
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+)
+
+type runtimeDrifts struct {
+	mu sync.Mutex
+
+	data map[string]internal.RuntimeDrift
+}
+
+func NewRuntimeDrifts() *runtimeDrifts {
+	return &runtimeDrifts{
+		data: make(map[string]internal.RuntimeDrift),
+	}
+}
+
+func (s *runtimeDrifts) Upsert(drift internal.RuntimeDrift) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[drift.InstanceID] = drift
+
+	return nil
+}
+
+func (s *runtimeDrifts) GetByInstanceID(instanceID string) (*internal.RuntimeDrift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	drift, found := s.data[instanceID]
+	if !found {
+		return nil, dberr.NotFound("runtime drift for instance %s not found", instanceID)
+	}
+
+	return &drift, nil
+}
+
+func (s *runtimeDrifts) List() ([]internal.RuntimeDrift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.RuntimeDrift, 0, len(s.data))
+	for _, drift := range s.data {
+		result = append(result, drift)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].InstanceID < result[j].InstanceID
+	})
+
+	return result, nil
+}
+
+func (s *runtimeDrifts) Delete(instanceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, instanceID)
+
+	return nil
+}
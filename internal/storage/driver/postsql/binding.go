@@ -0,0 +1,199 @@
+package postsql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type bindings struct {
+	postsql.Factory
+	cipher Cipher
+}
+
+func NewBindings(sess postsql.Factory, cipher Cipher) *bindings {
+	return &bindings{
+		Factory: sess,
+		cipher:  cipher,
+	}
+}
+
+func (s *bindings) Insert(binding *internal.Binding) error {
+	dto, err := s.toBindingDTO(binding)
+	if err != nil {
+		return err
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertBinding(dto)
+		if lastErr != nil {
+			if dberr.IsAlreadyExists(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while inserting binding %s: %v", binding.ID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *bindings) Update(binding *internal.Binding) error {
+	dto, err := s.toBindingDTO(binding)
+	if err != nil {
+		return err
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.UpdateBinding(dto)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while updating binding %s: %v", binding.ID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *bindings) Get(instanceID, bindingID string) (*internal.Binding, error) {
+	sess := s.NewReadSession()
+	var dto dbmodel.BindingDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, lastErr = sess.GetBinding(instanceID, bindingID)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while getting binding %s: %v", bindingID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	return s.toBinding(dto)
+}
+
+func (s *bindings) ListByInstanceID(instanceID string) ([]internal.Binding, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.BindingDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListBindings(instanceID)
+		if lastErr != nil {
+			log.Errorf("while listing bindings of the instance %s: %v", instanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	return s.toBindings(dtos)
+}
+
+func (s *bindings) ListExpired(now time.Time) ([]internal.Binding, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.BindingDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListExpiredBindings(now)
+		if lastErr != nil {
+			log.Errorf("while listing expired bindings: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	return s.toBindings(dtos)
+}
+
+func (s *bindings) Delete(instanceID, bindingID string) error {
+	sess := s.NewWriteSession()
+	return wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		err := sess.DeleteBinding(instanceID, bindingID)
+		if err != nil {
+			log.Errorf("while deleting binding %s: %v", bindingID, err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func (s *bindings) toBindingDTO(binding *internal.Binding) (dbmodel.BindingDTO, error) {
+	kubeconfig := ""
+	if binding.Kubeconfig != "" {
+		encrypted, err := s.cipher.Encrypt([]byte(binding.Kubeconfig))
+		if err != nil {
+			return dbmodel.BindingDTO{}, fmt.Errorf("while encrypting kubeconfig: %w", err)
+		}
+		kubeconfig = string(encrypted)
+	}
+	return dbmodel.BindingDTO{
+		ID:          binding.ID,
+		InstanceID:  binding.InstanceID,
+		CreatedAt:   binding.CreatedAt,
+		UpdatedAt:   binding.UpdatedAt,
+		ExpiresAt:   binding.ExpiresAt,
+		ExpiredAt:   binding.ExpiredAt,
+		Kubeconfig:  kubeconfig,
+		State:       string(binding.State),
+		Description: binding.Description,
+	}, nil
+}
+
+func (s *bindings) toBindings(dtos []dbmodel.BindingDTO) ([]internal.Binding, error) {
+	result := make([]internal.Binding, 0, len(dtos))
+	for _, dto := range dtos {
+		binding, err := s.toBinding(dto)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *binding)
+	}
+	return result, nil
+}
+
+func (s *bindings) toBinding(dto dbmodel.BindingDTO) (*internal.Binding, error) {
+	kubeconfig := ""
+	if dto.Kubeconfig != "" {
+		decrypted, err := s.cipher.Decrypt([]byte(dto.Kubeconfig))
+		if err != nil {
+			return nil, fmt.Errorf("while decrypting kubeconfig: %w", err)
+		}
+		kubeconfig = string(decrypted)
+	}
+	return &internal.Binding{
+		ID:          dto.ID,
+		InstanceID:  dto.InstanceID,
+		CreatedAt:   dto.CreatedAt,
+		UpdatedAt:   dto.UpdatedAt,
+		ExpiresAt:   dto.ExpiresAt,
+		ExpiredAt:   dto.ExpiredAt,
+		Kubeconfig:  kubeconfig,
+		State:       domain.LastOperationState(dto.State),
+		Description: dto.Description,
+	}, nil
+}
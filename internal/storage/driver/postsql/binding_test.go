@@ -0,0 +1,84 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindings(t *testing.T) {
+
+	t.Run("should insert, update, list and delete bindings", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		svc := brokerStorage.Bindings()
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		binding := internal.Binding{
+			ID:         "binding-1",
+			InstanceID: "instance-1",
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			ExpiresAt:  now,
+			State:      domain.InProgress,
+		}
+
+		err = svc.Insert(&binding)
+		require.NoError(t, err)
+		err = svc.Insert(&binding)
+		assert.Error(t, err)
+		err = svc.Insert(&internal.Binding{ID: "binding-2", InstanceID: "instance-1", CreatedAt: now.Add(time.Second), UpdatedAt: now, ExpiresAt: now, State: domain.InProgress})
+		require.NoError(t, err)
+
+		binding.State = domain.Succeeded
+		binding.Kubeconfig = "apiVersion: v1"
+		binding.ExpiresAt = now.Add(10 * time.Minute)
+		err = svc.Update(&binding)
+		require.NoError(t, err)
+
+		got, err := svc.Get("instance-1", "binding-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, got.State)
+		assert.Equal(t, "apiVersion: v1", got.Kubeconfig)
+		assert.True(t, binding.ExpiresAt.Equal(got.ExpiresAt))
+
+		list, err := svc.ListByInstanceID("instance-1")
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "binding-1", list[0].ID)
+		assert.Equal(t, "binding-2", list[1].ID)
+
+		expired, err := svc.ListExpired(now.Add(10 * time.Minute))
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, "binding-1", expired[0].ID)
+		expired, err = svc.ListExpired(now)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+
+		expiredAt := now.Add(10 * time.Minute)
+		binding.ExpiredAt = &expiredAt
+		err = svc.Update(&binding)
+		require.NoError(t, err)
+		expired, err = svc.ListExpired(now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+
+		err = svc.Delete("instance-1", "binding-1")
+		require.NoError(t, err)
+		_, err = svc.Get("instance-1", "binding-1")
+		assert.True(t, dberr.IsNotFound(err))
+		err = svc.Update(&binding)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
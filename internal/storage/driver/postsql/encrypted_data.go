@@ -0,0 +1,219 @@
+package postsql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// bindingIDSeparator separates the instance ID and the binding ID in the ID of the last re-encrypted binding
+const bindingIDSeparator = "/"
+
+type encryptedData struct {
+	postsql.Factory
+	cipher Cipher
+}
+
+func NewEncryptedData(sess postsql.Factory, cipher Cipher) *encryptedData {
+	return &encryptedData{
+		Factory: sess,
+		cipher:  cipher,
+	}
+}
+
+func (s *encryptedData) ReencryptInstances(afterID string, batchSize int) (string, int, error) {
+	records, err := s.NewReadSession().ListInstancesProvisioningParameters(afterID, batchSize)
+	if err != nil {
+		return "", 0, err
+	}
+	sess := s.NewWriteSession()
+	lastID, failed := "", 0
+	for _, record := range records {
+		lastID = record.ID
+		params, err := s.reencryptProvisioningParameters(record.ProvisioningParameters)
+		if err != nil {
+			log.Errorf("unable to re-encrypt provisioning parameters of instance %s, leaving them unchanged: %s", record.ID, err)
+			failed++
+			continue
+		}
+		err = sess.UpdateInstanceProvisioningParameters(record.ID, record.ProvisioningParameters, params)
+		if err != nil && !dberr.IsNotFound(err) {
+			return "", failed, err
+		}
+		// the parameters changed in the meantime are already encrypted with the active key
+		if err != nil {
+			log.Infof("provisioning parameters of instance %s changed in the meantime, skipping", record.ID)
+		}
+	}
+	return lastID, failed, nil
+}
+
+func (s *encryptedData) ReencryptOperations(afterID string, batchSize int) (string, int, error) {
+	records, err := s.NewReadSession().ListOperationsProvisioningParameters(afterID, batchSize)
+	if err != nil {
+		return "", 0, err
+	}
+	sess := s.NewWriteSession()
+	lastID, failed := "", 0
+	for _, record := range records {
+		lastID = record.ID
+		params, err := s.reencryptProvisioningParameters(record.ProvisioningParameters)
+		if err != nil {
+			log.Errorf("unable to re-encrypt provisioning parameters of operation %s, leaving them unchanged: %s", record.ID, err)
+			failed++
+			continue
+		}
+		err = sess.UpdateOperationProvisioningParameters(record.ID, record.ProvisioningParameters, params)
+		if err != nil && !dberr.IsNotFound(err) {
+			return "", failed, err
+		}
+		if err != nil {
+			log.Infof("provisioning parameters of operation %s changed in the meantime, skipping", record.ID)
+		}
+	}
+	return lastID, failed, nil
+}
+
+func (s *encryptedData) ReencryptProvisionerInputs(afterID string, batchSize int) (string, int, error) {
+	records, err := s.NewReadSession().ListOperationsProvisionerInputs(afterID, batchSize)
+	if err != nil {
+		return "", 0, err
+	}
+	sess := s.NewWriteSession()
+	lastID, failed := "", 0
+	for _, record := range records {
+		lastID = record.ID
+		input, err := s.reencrypt(record.ProvisionerInput)
+		if err != nil {
+			log.Errorf("unable to re-encrypt provisioner input of operation %s, leaving it unchanged: %s", record.ID, err)
+			failed++
+			continue
+		}
+		err = sess.UpdateOperationProvisionerInput(record.ID, record.ProvisionerInput, input)
+		if err != nil && !dberr.IsNotFound(err) {
+			return "", failed, err
+		}
+		// the input is removed when the operation finishes
+		if err != nil {
+			log.Infof("provisioner input of operation %s changed in the meantime, skipping", record.ID)
+		}
+	}
+	return lastID, failed, nil
+}
+
+// ReencryptBindings re-encrypts the kubeconfigs of the bindings, the ID of the binding is <instance ID>/<binding ID>
+func (s *encryptedData) ReencryptBindings(afterID string, batchSize int) (string, int, error) {
+	afterInstanceID, afterBindingID, _ := strings.Cut(afterID, bindingIDSeparator)
+	bindings, err := s.NewReadSession().ListBindingsKubeconfigs(afterInstanceID, afterBindingID, batchSize)
+	if err != nil {
+		return "", 0, err
+	}
+	sess := s.NewWriteSession()
+	lastID, failed := "", 0
+	for _, binding := range bindings {
+		lastID = binding.InstanceID + bindingIDSeparator + binding.ID
+		kubeconfig, err := s.reencrypt(binding.Kubeconfig)
+		if err != nil {
+			log.Errorf("unable to re-encrypt kubeconfig of binding %s of instance %s, leaving it unchanged: %s", binding.ID, binding.InstanceID, err)
+			failed++
+			continue
+		}
+		err = sess.UpdateBindingKubeconfig(binding.InstanceID, binding.ID, binding.Kubeconfig, kubeconfig)
+		if err != nil && !dberr.IsNotFound(err) {
+			return "", failed, err
+		}
+		if err != nil {
+			log.Infof("kubeconfig of binding %s of instance %s changed in the meantime, skipping", binding.ID, binding.InstanceID)
+		}
+	}
+	return lastID, failed, nil
+}
+
+func (s *encryptedData) ReencryptRuntimeStates(afterID string, batchSize int) (string, int, error) {
+	states, err := s.NewReadSession().ListRuntimeStatesConfigs(afterID, batchSize)
+	if err != nil {
+		return "", 0, err
+	}
+	sess := s.NewWriteSession()
+	lastID, failed := "", 0
+	for _, state := range states {
+		lastID = state.ID
+		kymaConfig, err := s.reencrypt(state.KymaConfig)
+		if err != nil {
+			log.Errorf("unable to re-encrypt kyma config of runtime state %s, leaving it unchanged: %s", state.ID, err)
+			failed++
+			continue
+		}
+		clusterSetup, err := s.reencrypt(state.ClusterSetup)
+		if err != nil {
+			log.Errorf("unable to re-encrypt cluster setup of runtime state %s, leaving it unchanged: %s", state.ID, err)
+			failed++
+			continue
+		}
+		reencrypted := dbmodel.RuntimeStateDTO{ID: state.ID, KymaConfig: kymaConfig, ClusterSetup: clusterSetup}
+		err = sess.UpdateRuntimeStateConfigs(state, reencrypted)
+		if err != nil && !dberr.IsNotFound(err) {
+			return "", failed, err
+		}
+	}
+	return lastID, failed, nil
+}
+
+// reencryptProvisioningParameters re-encrypts the secrets of the provisioning parameters. The kubeconfig which cannot be decrypted
+// is encrypted only if it is a plain text kubeconfig, otherwise the error is returned so the value is not encrypted twice.
+func (s *encryptedData) reencryptProvisioningParameters(value string) (string, error) {
+	var params internal.ProvisioningParameters
+	if err := json.Unmarshal([]byte(value), &params); err != nil {
+		return "", fmt.Errorf("while unmarshal parameters: %w", err)
+	}
+	if err := s.cipher.DecryptSMCreds(&params); err != nil {
+		return "", fmt.Errorf("while decrypting parameters: %w", err)
+	}
+	if err := s.cipher.DecryptKubeconfig(&params); err != nil {
+		if !isPlainTextKubeconfig(params.Parameters.Kubeconfig) {
+			return "", err
+		}
+		log.Info("encrypting the kubeconfig stored in a plain text")
+	}
+	if err := s.cipher.EncryptSMCreds(&params); err != nil {
+		return "", fmt.Errorf("while encrypting parameters: %w", err)
+	}
+	if err := s.cipher.EncryptKubeconfig(&params); err != nil {
+		return "", fmt.Errorf("while encrypting kubeconfig: %w", err)
+	}
+	result, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling parameters: %w", err)
+	}
+	return string(result), nil
+}
+
+// isPlainTextKubeconfig returns true only for the value which is a valid kubeconfig with clusters,
+// the encrypted values are base64 encoded and never parse as such
+func isPlainTextKubeconfig(value string) bool {
+	config, err := clientcmd.Load([]byte(value))
+	return err == nil && len(config.Clusters) > 0
+}
+
+// reencrypt decrypts the value with the key it was encrypted with and encrypts it with the active key, the empty values are not encrypted
+func (s *encryptedData) reencrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	decrypted, err := s.cipher.Decrypt([]byte(value))
+	if err != nil {
+		return "", fmt.Errorf("while decrypting: %w", err)
+	}
+	encrypted, err := s.cipher.Encrypt(decrypted)
+	if err != nil {
+		return "", fmt.Errorf("while encrypting: %w", err)
+	}
+	return string(encrypted), nil
+}
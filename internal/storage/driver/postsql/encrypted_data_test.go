@@ -0,0 +1,145 @@
+package postsql_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedData(t *testing.T) {
+
+	t.Run("should re-encrypt the secrets with the active key", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		// given
+		instance := fixture.FixInstance("instance-1")
+		instance.Parameters.ErsContext.SMOperatorCredentials = &internal.ServiceManagerOperatorCredentials{ClientID: "client-id", ClientSecret: "client-secret"}
+		require.NoError(t, brokerStorage.Instances().Insert(instance))
+		operation := fixture.FixProvisioningOperation("operation-1", "instance-1")
+		operation.ProvisioningParameters = instance.Parameters
+		operation.State = domain.InProgress
+		operation.ProvisionerInput = &internal.ProvisionerInput{RuntimeID: "runtime-1", Kubeconfig: "kubeconfig"}
+		require.NoError(t, brokerStorage.Operations().InsertOperation(operation))
+		binding := &internal.Binding{ID: "binding-1", InstanceID: "instance-1", Kubeconfig: "binding-kubeconfig", State: domain.Succeeded}
+		require.NoError(t, brokerStorage.Bindings().Insert(binding))
+		state := fixture.FixRuntimeState("state-1", "runtime-1", "operation-1")
+		clusterSetup := fixture.FixClusterSetup("runtime-1")
+		state.ClusterSetup = &clusterSetup
+		require.NoError(t, brokerStorage.RuntimeStates().Insert(state))
+
+		cfg := brokerStorageDatabaseTestConfig()
+		cfg.SecretKeys = []string{"v1:abcdefghijklmnopqrstuvwxyz012345"}
+		cfg.ActiveSecretKeyID = "v1"
+		cipher, err := storage.NewEncrypterFromConfig(cfg)
+		require.NoError(t, err)
+		rotatedStorage, conn, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		defer conn.Close()
+		svc := rotatedStorage.EncryptedData()
+
+		// when
+		lastID, failed, err := svc.ReencryptInstances("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "instance-1", lastID)
+		assert.Zero(t, failed)
+		lastID, _, err = svc.ReencryptInstances(lastID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, lastID)
+
+		lastID, failed, err = svc.ReencryptOperations("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "operation-1", lastID)
+		assert.Zero(t, failed)
+
+		lastID, failed, err = svc.ReencryptProvisionerInputs("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "operation-1", lastID)
+		assert.Zero(t, failed)
+
+		lastID, failed, err = svc.ReencryptBindings("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "instance-1/binding-1", lastID)
+		assert.Zero(t, failed)
+		lastID, _, err = svc.ReencryptBindings(lastID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, lastID)
+
+		lastID, failed, err = svc.ReencryptRuntimeStates("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "state-1", lastID)
+		assert.Zero(t, failed)
+
+		// then
+		gotInstance, err := rotatedStorage.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		assert.Equal(t, "client-secret", gotInstance.Parameters.ErsContext.SMOperatorCredentials.ClientSecret)
+		_, err = brokerStorage.Instances().GetByID("instance-1")
+		assert.Error(t, err)
+
+		gotOperation, err := rotatedStorage.Operations().GetOperationByID("operation-1")
+		require.NoError(t, err)
+		assert.Equal(t, "client-id", gotOperation.ProvisioningParameters.ErsContext.SMOperatorCredentials.ClientID)
+		require.NotNil(t, gotOperation.ProvisionerInput)
+		assert.Equal(t, "kubeconfig", gotOperation.ProvisionerInput.Kubeconfig)
+
+		gotBinding, err := rotatedStorage.Bindings().Get("instance-1", "binding-1")
+		require.NoError(t, err)
+		assert.Equal(t, "binding-kubeconfig", gotBinding.Kubeconfig)
+		_, err = brokerStorage.Bindings().Get("instance-1", "binding-1")
+		assert.Error(t, err)
+
+		gotState, err := rotatedStorage.RuntimeStates().GetByOperationID("operation-1")
+		require.NoError(t, err)
+		assert.Equal(t, clusterSetup.Kubeconfig, gotState.ClusterSetup.Kubeconfig)
+		_, err = brokerStorage.RuntimeStates().GetByOperationID("operation-1")
+		assert.Error(t, err)
+	})
+	t.Run("should leave unchanged the kubeconfig which cannot be decrypted", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		// given
+		instance := fixture.FixInstance("instance-1")
+		instance.Parameters.Parameters.Kubeconfig = "apiVersion: v1"
+		require.NoError(t, brokerStorage.Instances().Insert(instance))
+
+		cfg := brokerStorageDatabaseTestConfig()
+		cfg.SecretKey = "zyxwvutsrqponmlkjihgfedcba543210"
+		cfg.SecretKeys = []string{"v1:abcdefghijklmnopqrstuvwxyz012345"}
+		cfg.ActiveSecretKeyID = "v1"
+		cipher, err := storage.NewEncrypterFromConfig(cfg)
+		require.NoError(t, err)
+		rotatedStorage, conn, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// when
+		lastID, failed, err := rotatedStorage.EncryptedData().ReencryptInstances("", 10)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "instance-1", lastID)
+		assert.Equal(t, 1, failed)
+		gotInstance, err := brokerStorage.Instances().GetByID("instance-1")
+		require.NoError(t, err)
+		assert.Equal(t, "apiVersion: v1", gotInstance.Parameters.Parameters.Kubeconfig)
+	})
+}
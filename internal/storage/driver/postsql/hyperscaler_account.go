@@ -0,0 +1,127 @@
+package postsql
+
+import (
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type hyperscalerAccounts struct {
+	postsql.Factory
+}
+
+func NewHyperscalerAccounts(sess postsql.Factory) *hyperscalerAccounts {
+	return &hyperscalerAccounts{
+		Factory: sess,
+	}
+}
+
+func (s *hyperscalerAccounts) Insert(account internal.HyperscalerAccount) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertHyperscalerAccount(toHyperscalerAccountDTO(account))
+		if lastErr != nil {
+			if dberr.IsAlreadyExists(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while inserting hyperscaler account %s: %v", account.Name, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+// Update stores the account if its version has not changed in the meantime, otherwise the conflict error is returned
+func (s *hyperscalerAccounts) Update(account internal.HyperscalerAccount) (*internal.HyperscalerAccount, error) {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.UpdateHyperscalerAccount(toHyperscalerAccountDTO(account))
+		if lastErr != nil && dberr.IsNotFound(lastErr) {
+			_, lastErr = s.NewReadSession().GetHyperscalerAccount(account.Name)
+			if lastErr != nil {
+				if dberr.IsNotFound(lastErr) {
+					return false, lastErr
+				}
+				log.Errorf("while getting hyperscaler account %s: %v", account.Name, lastErr)
+				return false, nil
+			}
+			lastErr = dberr.Conflict("unable to update hyperscaler account %s - conflict", account.Name)
+			return false, lastErr
+		}
+		if lastErr != nil {
+			log.Errorf("while updating hyperscaler account %s: %v", account.Name, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	account.Version = account.Version + 1
+	return &account, nil
+}
+
+func (s *hyperscalerAccounts) List(hyperscalerType string) ([]internal.HyperscalerAccount, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.HyperscalerAccountDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListHyperscalerAccounts(hyperscalerType)
+		if lastErr != nil {
+			log.Errorf("while listing hyperscaler accounts of type %s: %v", hyperscalerType, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	result := make([]internal.HyperscalerAccount, 0, len(dtos))
+	for _, dto := range dtos {
+		result = append(result, toHyperscalerAccount(dto))
+	}
+	return result, nil
+}
+
+func toHyperscalerAccountDTO(account internal.HyperscalerAccount) dbmodel.HyperscalerAccountDTO {
+	return dbmodel.HyperscalerAccountDTO{
+		Name:            account.Name,
+		SecretName:      account.SecretName,
+		HyperscalerType: account.HyperscalerType,
+		TenantName:      account.TenantName,
+		Shared:          account.Shared,
+		Dirty:           account.Dirty,
+		Internal:        account.Internal,
+		EUAccess:        account.EUAccess,
+		ManualCleanup:   account.ManualCleanup,
+		CreatedAt:       account.CreatedAt,
+		UpdatedAt:       account.UpdatedAt,
+		Version:         account.Version,
+	}
+}
+
+func toHyperscalerAccount(dto dbmodel.HyperscalerAccountDTO) internal.HyperscalerAccount {
+	return internal.HyperscalerAccount{
+		Name:            dto.Name,
+		SecretName:      dto.SecretName,
+		HyperscalerType: dto.HyperscalerType,
+		TenantName:      dto.TenantName,
+		Shared:          dto.Shared,
+		Dirty:           dto.Dirty,
+		Internal:        dto.Internal,
+		EUAccess:        dto.EUAccess,
+		ManualCleanup:   dto.ManualCleanup,
+		CreatedAt:       dto.CreatedAt,
+		UpdatedAt:       dto.UpdatedAt,
+		Version:         dto.Version,
+	}
+}
@@ -0,0 +1,73 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperscalerAccounts(t *testing.T) {
+
+	t.Run("should insert, list and update hyperscaler accounts", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		svc := brokerStorage.HyperscalerAccounts()
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		account := internal.HyperscalerAccount{
+			Name:            "aws-2",
+			SecretName:      "secret-aws-2",
+			HyperscalerType: "aws",
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+
+		err = svc.Insert(account)
+		require.NoError(t, err)
+		err = svc.Insert(account)
+		assert.True(t, dberr.IsAlreadyExists(err))
+		err = svc.Insert(internal.HyperscalerAccount{Name: "aws-1", SecretName: "secret-aws-1", HyperscalerType: "aws", Shared: true, CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+		err = svc.Insert(internal.HyperscalerAccount{Name: "gcp-1", SecretName: "secret-gcp-1", HyperscalerType: "gcp", EUAccess: true, CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+
+		list, err := svc.List("aws")
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "aws-1", list[0].Name)
+		assert.True(t, list[0].Shared)
+		assert.Equal(t, "aws-2", list[1].Name)
+
+		account.TenantName = "tenant-1"
+		updated, err := svc.Update(account)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.Version)
+
+		// the stale version must not overwrite the assignment
+		account.TenantName = "tenant-2"
+		_, err = svc.Update(account)
+		assert.True(t, dberr.IsConflict(err))
+
+		updated.Dirty = true
+		_, err = svc.Update(*updated)
+		require.NoError(t, err)
+
+		list, err = svc.List("aws")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-1", list[1].TenantName)
+		assert.True(t, list[1].Dirty)
+		assert.Equal(t, 2, list[1].Version)
+
+		_, err = svc.Update(internal.HyperscalerAccount{Name: "not-existing"})
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
@@ -370,6 +370,7 @@ func (s *Instance) toInstance(dto dbmodel.InstanceDTO) (internal.Instance, error
 		UpdatedAt:                   dto.UpdatedAt,
 		DeletedAt:                   dto.DeletedAt,
 		ExpiredAt:                   dto.ExpiredAt,
+		HibernatedAt:                dto.HibernatedAt,
 		Version:                     dto.Version,
 		Provider:                    internal.CloudProvider(dto.Provider),
 	}, nil
@@ -464,6 +465,7 @@ func (s *Instance) toInstanceDTO(instance internal.Instance) (dbmodel.InstanceDT
 		UpdatedAt:                   instance.UpdatedAt,
 		DeletedAt:                   instance.DeletedAt,
 		ExpiredAt:                   instance.ExpiredAt,
+		HibernatedAt:                instance.HibernatedAt,
 		Version:                     instance.Version,
 		Provider:                    string(instance.Provider),
 	}, nil
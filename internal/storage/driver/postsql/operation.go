@@ -1,6 +1,7 @@
 package postsql
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -406,6 +407,24 @@ func (s *operations) GetNotFinishedOperationsByType(operationType internal.Opera
 	return s.toOperations(operations)
 }
 
+func (s *operations) GetOperationsWithCompensationInProgress(operationType internal.OperationType) ([]internal.Operation, error) {
+	session := s.NewReadSession()
+	operations := make([]dbmodel.OperationDTO, 0)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, err := session.GetOperationsWithCompensationInProgress(operationType)
+		if err != nil {
+			log.Errorf("while getting operations from the storage: %v", err)
+			return false, nil
+		}
+		operations = dto
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toOperations(operations)
+}
+
 func (s *operations) GetOperationStatsByPlan() (map[string]internal.OperationStats, error) {
 	entries, err := s.NewReadSession().GetOperationStats()
 	if err != nil {
@@ -910,6 +929,10 @@ func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO,
 	if err != nil {
 		return dbmodel.OperationDTO{}, fmt.Errorf("while marshal provisioning parameters: %w", err)
 	}
+	provisionerInput, err := s.encryptProvisionerInput(op)
+	if err != nil {
+		return dbmodel.OperationDTO{}, err
+	}
 
 	return dbmodel.OperationDTO{
 		ID:                     op.ID,
@@ -923,10 +946,43 @@ func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO,
 		InstanceID:             op.InstanceID,
 		OrchestrationID:        storage.StringToSQLNullString(op.OrchestrationID),
 		ProvisioningParameters: storage.StringToSQLNullString(string(pp)),
+		ProvisionerInput:       provisionerInput,
 		FinishedStages:         storage.StringToSQLNullString(strings.Join(op.FinishedStages, ",")),
 	}, nil
 }
 
+// encryptProvisionerInput returns the encrypted provisioner input, the input is needed only to resume the processing
+// so it is not stored for the finished operations
+func (s *operations) encryptProvisionerInput(op internal.Operation) (sql.NullString, error) {
+	if op.ProvisionerInput == nil || op.IsFinished() {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(op.ProvisionerInput)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("while marshal provisioner input: %w", err)
+	}
+	encrypted, err := s.cipher.Encrypt(data)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("while encrypting provisioner input: %w", err)
+	}
+	return storage.StringToSQLNullString(string(encrypted)), nil
+}
+
+func (s *operations) decryptProvisionerInput(dto *dbmodel.OperationDTO) (*internal.ProvisionerInput, error) {
+	if !dto.ProvisionerInput.Valid || dto.ProvisionerInput.String == "" {
+		return nil, nil
+	}
+	data, err := s.cipher.Decrypt([]byte(dto.ProvisionerInput.String))
+	if err != nil {
+		return nil, fmt.Errorf("while decrypting provisioner input: %w", err)
+	}
+	provisionerInput := &internal.ProvisionerInput{}
+	if err := json.Unmarshal(data, provisionerInput); err != nil {
+		return nil, fmt.Errorf("while unmarshal provisioner input: %w", err)
+	}
+	return provisionerInput, nil
+}
+
 func (s *operations) toOperation(dto *dbmodel.OperationDTO, existingOp internal.Operation) (internal.Operation, error) {
 	provisioningParameters := internal.ProvisioningParameters{}
 	if dto.ProvisioningParameters.Valid {
@@ -945,6 +1001,11 @@ func (s *operations) toOperation(dto *dbmodel.OperationDTO, existingOp internal.
 		log.Warn("decrypting skipped because kubeconfig is in a plain text")
 	}
 
+	provisionerInput, err := s.decryptProvisionerInput(dto)
+	if err != nil {
+		return internal.Operation{}, err
+	}
+
 	stages := make([]string, 0)
 	finishedSteps := storage.SQLNullStringToString(dto.FinishedStages)
 	for _, s := range strings.Split(finishedSteps, ",") {
@@ -964,6 +1025,7 @@ func (s *operations) toOperation(dto *dbmodel.OperationDTO, existingOp internal.
 	existingOp.Version = dto.Version
 	existingOp.OrchestrationID = storage.SQLNullStringToString(dto.OrchestrationID)
 	existingOp.ProvisioningParameters = provisioningParameters
+	existingOp.ProvisionerInput = provisionerInput
 	existingOp.FinishedStages = stages
 
 	return existingOp, nil
@@ -0,0 +1,119 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operationLeases struct {
+	postsql.Factory
+}
+
+func NewOperationLeases(sess postsql.Factory) *operationLeases {
+	return &operationLeases{
+		Factory: sess,
+	}
+}
+
+func (s *operationLeases) Acquire(operationID, owner string, ttl time.Duration) (bool, error) {
+	sess := s.NewWriteSession()
+	now := time.Now()
+	lease := dbmodel.OperationLeaseDTO{
+		OperationID: operationID,
+		Owner:       owner,
+		AcquiredAt:  now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	acquired := false
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertOperationLease(lease)
+		if lastErr == nil {
+			acquired = true
+			return true, nil
+		}
+		if lastErr.Code() == dberr.CodeAlreadyExists {
+			lastErr = sess.TakeOverOperationLease(lease, now)
+			switch {
+			case lastErr == nil:
+				acquired = true
+				return true, nil
+			case dberr.IsConflict(lastErr):
+				return true, nil
+			}
+		}
+		log.Errorf("while acquiring lease of the operation %s: %v", operationID, lastErr)
+		return false, nil
+	})
+	if err != nil {
+		return false, lastErr
+	}
+	return acquired, nil
+}
+
+func (s *operationLeases) Renew(operationID, owner string, ttl time.Duration) (bool, error) {
+	sess := s.NewWriteSession()
+	renewed := false
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.RenewOperationLease(operationID, owner, time.Now().Add(ttl))
+		switch {
+		case lastErr == nil:
+			renewed = true
+			return true, nil
+		case dberr.IsConflict(lastErr):
+			return true, nil
+		}
+		log.Errorf("while renewing lease of the operation %s: %v", operationID, lastErr)
+		return false, nil
+	})
+	if err != nil {
+		return false, lastErr
+	}
+	return renewed, nil
+}
+
+func (s *operationLeases) Release(operationID, owner string) error {
+	sess := s.NewWriteSession()
+	return wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		err := sess.DeleteOperationLease(operationID, owner)
+		if err != nil {
+			log.Errorf("while releasing lease of the operation %s: %v", operationID, err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func (s *operationLeases) GetByOperationID(operationID string) (internal.OperationLease, error) {
+	sess := s.NewReadSession()
+	var dto dbmodel.OperationLeaseDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, lastErr = sess.GetOperationLease(operationID)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while getting lease of the operation %s: %v", operationID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return internal.OperationLease{}, lastErr
+	}
+
+	return internal.OperationLease{
+		OperationID: dto.OperationID,
+		Owner:       dto.Owner,
+		AcquiredAt:  dto.AcquiredAt,
+		ExpiresAt:   dto.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationLeases(t *testing.T) {
+
+	t.Run("should acquire, renew and release the lease", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		svc := brokerStorage.OperationLeases()
+
+		acquired, err := svc.Acquire("op-1", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = svc.Acquire("op-1", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+
+		acquired, err = svc.Acquire("op-1", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		renewed, err := svc.Renew("op-1", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, renewed)
+
+		renewed, err = svc.Renew("op-1", "replica-1", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, renewed)
+
+		lease, err := svc.GetByOperationID("op-1")
+		require.NoError(t, err)
+		assert.Equal(t, "replica-1", lease.Owner)
+		assert.True(t, lease.ExpiresAt.After(time.Now().Add(time.Minute)))
+
+		err = svc.Release("op-1", "replica-2")
+		require.NoError(t, err)
+		_, err = svc.GetByOperationID("op-1")
+		require.NoError(t, err)
+
+		err = svc.Release("op-1", "replica-1")
+		require.NoError(t, err)
+		_, err = svc.GetByOperationID("op-1")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should take over the expired lease", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		svc := brokerStorage.OperationLeases()
+
+		acquired, err := svc.Acquire("op-1", "replica-1", -time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = svc.Acquire("op-1", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		lease, err := svc.GetByOperationID("op-1")
+		require.NoError(t, err)
+		assert.Equal(t, "replica-2", lease.Owner)
+
+		renewed, err := svc.Renew("op-1", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, renewed)
+	})
+}
@@ -5,10 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -212,6 +214,45 @@ func TestOperation(t *testing.T) {
 		assert.Equal(t, 3, len(opList))
 	})
 
+	t.Run("Provisioner input", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		givenOperation := fixture.FixProvisioningOperation("operation-id", "inst-id")
+		givenOperation.InputCreator = nil
+		givenOperation.State = domain.InProgress
+		givenOperation.ProvisionerInput = &internal.ProvisionerInput{
+			ShootName:  ptr.String("c-1234567"),
+			Kubeconfig: "kubeconfig",
+			Labels:     gqlschema.Labels{"broker_instance_id": "inst-id"},
+		}
+		svc := brokerStorage.Operations()
+
+		// when
+		err = svc.InsertOperation(givenOperation)
+		require.NoError(t, err)
+
+		// then
+		op, err := svc.GetOperationByID("operation-id")
+		require.NoError(t, err)
+		assert.Equal(t, givenOperation.ProvisionerInput, op.ProvisionerInput)
+
+		// when
+		op.State = domain.Succeeded
+		_, err = svc.UpdateOperation(*op)
+		require.NoError(t, err)
+
+		// then
+		op, err = svc.GetOperationByID("operation-id")
+		require.NoError(t, err)
+		assert.Nil(t, op.ProvisionerInput)
+	})
+
 	t.Run("Deprovisioning", func(t *testing.T) {
 		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
 		require.NoError(t, err)
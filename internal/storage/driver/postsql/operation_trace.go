@@ -0,0 +1,81 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operationTraces struct {
+	postsql.Factory
+}
+
+func NewOperationTraces(sess postsql.Factory) *operationTraces {
+	return &operationTraces{
+		Factory: sess,
+	}
+}
+
+func (s *operationTraces) Insert(trace internal.OperationTrace) error {
+	sess := s.NewWriteSession()
+	return wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		err := sess.InsertOperationTrace(dbmodel.OperationTraceDTO{
+			ID:          trace.ID,
+			OperationID: trace.OperationID,
+			InstanceID:  trace.InstanceID,
+			Stage:       trace.Stage,
+			StepName:    trace.StepName,
+			StartedAt:   trace.StartedAt,
+			FinishedAt:  trace.FinishedAt,
+			Backoff:     trace.Backoff.Milliseconds(),
+			Error:       storage.StringToSQLNullString(trace.Error),
+			State:       string(trace.State),
+		})
+		if err != nil {
+			log.Errorf("while saving operation trace ID %s: %v", trace.ID, err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func (s *operationTraces) ListByOperationID(operationID string) ([]internal.OperationTrace, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.OperationTraceDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListOperationTracesByOperationID(operationID)
+		if lastErr != nil {
+			log.Errorf("while getting operation traces: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	traces := make([]internal.OperationTrace, 0, len(dtos))
+	for _, dto := range dtos {
+		traces = append(traces, internal.OperationTrace{
+			ID:          dto.ID,
+			OperationID: dto.OperationID,
+			InstanceID:  dto.InstanceID,
+			Stage:       dto.Stage,
+			StepName:    dto.StepName,
+			StartedAt:   dto.StartedAt,
+			FinishedAt:  dto.FinishedAt,
+			Backoff:     time.Duration(dto.Backoff) * time.Millisecond,
+			Error:       storage.SQLNullStringToString(dto.Error),
+			State:       domain.LastOperationState(dto.State),
+		})
+	}
+	return traces, nil
+}
@@ -0,0 +1,72 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationTraces(t *testing.T) {
+
+	t.Run("should insert and list traces of the operation", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		svc := brokerStorage.OperationTraces()
+		startedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+		err = svc.Insert(internal.OperationTrace{
+			ID:          "trace-2",
+			OperationID: "op-1",
+			InstanceID:  "inst-1",
+			Stage:       "create_runtime",
+			StepName:    "Check_GardenerCluster",
+			StartedAt:   startedAt.Add(time.Second),
+			FinishedAt:  startedAt.Add(2 * time.Second),
+			Backoff:     10 * time.Second,
+			State:       domain.InProgress,
+		})
+		require.NoError(t, err)
+		err = svc.Insert(internal.OperationTrace{
+			ID:          "trace-1",
+			OperationID: "op-1",
+			InstanceID:  "inst-1",
+			Stage:       "create_runtime",
+			StepName:    "Sync_GardenerCluster",
+			StartedAt:   startedAt,
+			FinishedAt:  startedAt.Add(time.Second),
+			Error:       "unable to create GardenerCluster",
+			State:       domain.InProgress,
+		})
+		require.NoError(t, err)
+		err = svc.Insert(internal.OperationTrace{
+			ID:          "trace-3",
+			OperationID: "op-2",
+			InstanceID:  "inst-2",
+			Stage:       "start",
+			StepName:    "Starting",
+			StartedAt:   startedAt,
+			FinishedAt:  startedAt,
+			State:       domain.Succeeded,
+		})
+		require.NoError(t, err)
+
+		traces, err := svc.ListByOperationID("op-1")
+		require.NoError(t, err)
+		require.Len(t, traces, 2)
+		assert.Equal(t, "Sync_GardenerCluster", traces[0].StepName)
+		assert.Equal(t, "unable to create GardenerCluster", traces[0].Error)
+		assert.Equal(t, "Check_GardenerCluster", traces[1].StepName)
+		assert.Equal(t, 10*time.Second, traces[1].Backoff)
+		assert.Empty(t, traces[1].Error)
+	})
+}
@@ -0,0 +1,144 @@
+package postsql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type runtimeDrifts struct {
+	postsql.Factory
+}
+
+func NewRuntimeDrifts(sess postsql.Factory) *runtimeDrifts {
+	return &runtimeDrifts{
+		Factory: sess,
+	}
+}
+
+// Upsert updates the drift of the instance or inserts it if the instance has no drift stored
+func (s *runtimeDrifts) Upsert(drift internal.RuntimeDrift) error {
+	dto, err := toRuntimeDriftDTO(drift)
+	if err != nil {
+		return err
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.UpdateRuntimeDrift(dto)
+		if lastErr != nil && dberr.IsNotFound(lastErr) {
+			lastErr = sess.InsertRuntimeDrift(dto)
+		}
+		if lastErr != nil {
+			log.Errorf("while storing runtime drift of instance %s: %v", drift.InstanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *runtimeDrifts) GetByInstanceID(instanceID string) (*internal.RuntimeDrift, error) {
+	sess := s.NewReadSession()
+	var dto dbmodel.RuntimeDriftDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, lastErr = sess.GetRuntimeDrift(instanceID)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while getting runtime drift of instance %s: %v", instanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	drift, err := toRuntimeDrift(dto)
+	if err != nil {
+		return nil, err
+	}
+	return &drift, nil
+}
+
+func (s *runtimeDrifts) List() ([]internal.RuntimeDrift, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.RuntimeDriftDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListRuntimeDrifts()
+		if lastErr != nil {
+			log.Errorf("while listing runtime drifts: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	result := make([]internal.RuntimeDrift, 0, len(dtos))
+	for _, dto := range dtos {
+		drift, err := toRuntimeDrift(dto)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, drift)
+	}
+	return result, nil
+}
+
+func (s *runtimeDrifts) Delete(instanceID string) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.DeleteRuntimeDrift(instanceID)
+		if lastErr != nil {
+			log.Errorf("while deleting runtime drift of instance %s: %v", instanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func toRuntimeDriftDTO(drift internal.RuntimeDrift) (dbmodel.RuntimeDriftDTO, error) {
+	findings, err := json.Marshal(drift.Findings)
+	if err != nil {
+		return dbmodel.RuntimeDriftDTO{}, fmt.Errorf("while marshalling drift findings: %w", err)
+	}
+	return dbmodel.RuntimeDriftDTO{
+		InstanceID: drift.InstanceID,
+		RuntimeID:  drift.RuntimeID,
+		ShootName:  drift.ShootName,
+		Findings:   string(findings),
+		DetectedAt: drift.DetectedAt,
+	}, nil
+}
+
+func toRuntimeDrift(dto dbmodel.RuntimeDriftDTO) (internal.RuntimeDrift, error) {
+	var findings []internal.DriftFinding
+	if err := json.Unmarshal([]byte(dto.Findings), &findings); err != nil {
+		return internal.RuntimeDrift{}, fmt.Errorf("while unmarshalling drift findings: %w", err)
+	}
+	return internal.RuntimeDrift{
+		InstanceID: dto.InstanceID,
+		RuntimeID:  dto.RuntimeID,
+		ShootName:  dto.ShootName,
+		Findings:   findings,
+		DetectedAt: dto.DetectedAt,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeDrifts(t *testing.T) {
+
+	t.Run("should upsert, list and delete runtime drifts", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		svc := brokerStorage.RuntimeDrifts()
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		drift := internal.RuntimeDrift{
+			InstanceID: "instance-2",
+			RuntimeID:  "runtime-2",
+			ShootName:  "shoot-2",
+			Findings:   []internal.DriftFinding{{Field: "machineType", Expected: "m5.xlarge", Actual: "m5.2xlarge"}},
+			DetectedAt: now,
+		}
+
+		err = svc.Upsert(drift)
+		require.NoError(t, err)
+		err = svc.Upsert(internal.RuntimeDrift{InstanceID: "instance-1", Findings: []internal.DriftFinding{{Field: "autoScalerMax", Expected: "20", Actual: "10"}}, DetectedAt: now})
+		require.NoError(t, err)
+
+		drift.Findings = append(drift.Findings, internal.DriftFinding{Field: "zones", Expected: "a,b", Actual: "a"})
+		err = svc.Upsert(drift)
+		require.NoError(t, err)
+
+		got, err := svc.GetByInstanceID("instance-2")
+		require.NoError(t, err)
+		assert.Equal(t, "shoot-2", got.ShootName)
+		assert.Len(t, got.Findings, 2)
+
+		list, err := svc.List()
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "instance-1", list[0].InstanceID)
+		assert.Equal(t, "instance-2", list[1].InstanceID)
+
+		err = svc.Delete("instance-2")
+		require.NoError(t, err)
+		_, err = svc.GetByInstanceID("instance-2")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
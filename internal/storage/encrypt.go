@@ -7,19 +7,107 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
 )
 
+// keyIDSeparator separates the key ID from the encrypted value, it is not a part of the base64 alphabet so the legacy values never contain it
+const keyIDSeparator = ":"
+
+// NewEncrypter creates the encrypter which uses the single key in the legacy AES-CFB mode
 func NewEncrypter(secretKey string) *Encrypter {
 	return &Encrypter{key: []byte(secretKey)}
 }
 
+// NewEncrypterFromConfig creates the encrypter with the versioned keys. The values are encrypted with the active key in the AES-GCM mode
+// and prefixed with its ID, so they can be decrypted with any of the configured keys. The values without the key ID are decrypted with
+// the legacy secret key. If the active key is not set, the values are encrypted with the legacy secret key.
+func NewEncrypterFromConfig(cfg Config) (*Encrypter, error) {
+	e := &Encrypter{
+		key:         []byte(cfg.SecretKey),
+		keys:        make(map[string][]byte),
+		activeKeyID: cfg.ActiveSecretKeyID,
+	}
+	for _, entry := range cfg.SecretKeys {
+		keyID, key, found := strings.Cut(entry, keyIDSeparator)
+		if !found || keyID == "" {
+			return nil, fmt.Errorf("secret key must be in the keyID:key format")
+		}
+		if _, err := aes.NewCipher([]byte(key)); err != nil {
+			return nil, fmt.Errorf("invalid secret key %s: %w", keyID, err)
+		}
+		e.keys[keyID] = []byte(key)
+	}
+	if _, found := e.keys[e.activeKeyID]; e.activeKeyID != "" && !found {
+		return nil, fmt.Errorf("active secret key %s is not configured", e.activeKeyID)
+	}
+	return e, nil
+}
+
 type Encrypter struct {
+	// key is the legacy key used for the values without the key ID
 	key []byte
+
+	keys        map[string][]byte
+	activeKeyID string
 }
 
 func (e *Encrypter) Encrypt(obj []byte) ([]byte, error) {
+	if e.activeKeyID == "" {
+		return e.encryptLegacy(obj)
+	}
+	gcm, err := newGCM(e.keys[e.activeKeyID])
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	// the key ID is authenticated, so the value cannot be moved to another key
+	sealed := gcm.Seal(nonce, nonce, obj, []byte(e.activeKeyID))
+
+	return []byte(e.activeKeyID + keyIDSeparator + base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+func (e *Encrypter) Decrypt(obj []byte) ([]byte, error) {
+	keyID, value, found := strings.Cut(string(obj), keyIDSeparator)
+	if !found {
+		return e.decryptLegacy(obj)
+	}
+	key, known := e.keys[keyID]
+	if !known {
+		return nil, fmt.Errorf("secret key %s is not configured", keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("while decoding input object: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("cipher text is too short")
+	}
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	data, err := gcm.Open(nil, nonce, sealed, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("while decrypting object: %w", err)
+	}
+	return data, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *Encrypter) encryptLegacy(obj []byte) ([]byte, error) {
 	block, err := aes.NewCipher(e.key)
 	if err != nil {
 		return nil, err
@@ -36,7 +124,7 @@ func (e *Encrypter) Encrypt(obj []byte) ([]byte, error) {
 	return []byte(base64.StdEncoding.EncodeToString(bytes)), nil
 }
 
-func (e *Encrypter) Decrypt(obj []byte) ([]byte, error) {
+func (e *Encrypter) decryptLegacy(obj []byte) ([]byte, error) {
 	obj, err := base64.StdEncoding.DecodeString(string(obj))
 	if err != nil {
 		return nil, fmt.Errorf("while decoding input object: %w", err)
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,3 +71,88 @@ func TestNewEncrypter(t *testing.T) {
 	})
 
 }
+
+func TestNewEncrypterFromConfig(t *testing.T) {
+	legacyKey, firstKey, secondKey := rand.String(32), rand.String(32), rand.String(32)
+	data := []byte("test")
+
+	t.Run("should encrypt with the active key and decrypt with any configured key", func(t *testing.T) {
+		// given
+		first, err := NewEncrypterFromConfig(Config{SecretKeys: []string{"v1:" + firstKey}, ActiveSecretKeyID: "v1"})
+		require.NoError(t, err)
+		second, err := NewEncrypterFromConfig(Config{SecretKeys: []string{"v1:" + firstKey, "v2:" + secondKey}, ActiveSecretKeyID: "v2"})
+		require.NoError(t, err)
+
+		// when
+		encryptedWithFirst, err := first.Encrypt(data)
+		require.NoError(t, err)
+		encryptedWithSecond, err := second.Encrypt(data)
+		require.NoError(t, err)
+
+		// then
+		assert.True(t, strings.HasPrefix(string(encryptedWithFirst), "v1:"))
+		assert.True(t, strings.HasPrefix(string(encryptedWithSecond), "v2:"))
+
+		decrypted, err := second.Decrypt(encryptedWithFirst)
+		require.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+
+		_, err = first.Decrypt(encryptedWithSecond)
+		assert.EqualError(t, err, "secret key v2 is not configured")
+	})
+
+	t.Run("should decrypt the legacy values with the legacy key", func(t *testing.T) {
+		// given
+		encrypted, err := NewEncrypter(legacyKey).Encrypt(data)
+		require.NoError(t, err)
+		e, err := NewEncrypterFromConfig(Config{SecretKey: legacyKey, SecretKeys: []string{"v1:" + firstKey}, ActiveSecretKeyID: "v1"})
+		require.NoError(t, err)
+
+		// when
+		decrypted, err := e.Decrypt(encrypted)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+	})
+
+	t.Run("should encrypt with the legacy key if the active key is not set", func(t *testing.T) {
+		// given
+		e, err := NewEncrypterFromConfig(Config{SecretKey: legacyKey})
+		require.NoError(t, err)
+
+		// when
+		encrypted, err := e.Encrypt(data)
+		require.NoError(t, err)
+
+		// then
+		decrypted, err := NewEncrypter(legacyKey).Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+	})
+
+	t.Run("should reject the modified value", func(t *testing.T) {
+		// given
+		e, err := NewEncrypterFromConfig(Config{SecretKeys: []string{"v1:" + firstKey, "v2:" + firstKey}, ActiveSecretKeyID: "v1"})
+		require.NoError(t, err)
+		encrypted, err := e.Encrypt(data)
+		require.NoError(t, err)
+
+		// when
+		_, err = e.Decrypt([]byte(strings.Replace(string(encrypted), "v1:", "v2:", 1)))
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject invalid configuration", func(t *testing.T) {
+		_, err := NewEncrypterFromConfig(Config{SecretKeys: []string{firstKey}})
+		assert.EqualError(t, err, "secret key must be in the keyID:key format")
+
+		_, err = NewEncrypterFromConfig(Config{SecretKeys: []string{"v1:short"}})
+		assert.ErrorContains(t, err, "invalid secret key v1")
+
+		_, err = NewEncrypterFromConfig(Config{SecretKeys: []string{"v1:" + firstKey}, ActiveSecretKeyID: "v2"})
+		assert.EqualError(t, err, "active secret key v2 is not configured")
+	})
+}
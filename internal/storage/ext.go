@@ -28,6 +28,55 @@ type Instances interface {
 	ListWithoutDecryption(dbmodel.InstanceFilter) ([]internal.Instance, int, int, error)
 }
 
+type OperationTraces interface {
+	Insert(trace internal.OperationTrace) error
+	ListByOperationID(operationID string) ([]internal.OperationTrace, error)
+}
+
+type OperationLeases interface {
+	// Acquire takes the lease if it does not exist, is expired or is already owned by the owner, returns false if another owner holds it
+	Acquire(operationID, owner string, ttl time.Duration) (bool, error)
+	// Renew extends the lease held by the owner, returns false if the lease was taken over
+	Renew(operationID, owner string, ttl time.Duration) (bool, error)
+	Release(operationID, owner string) error
+	GetByOperationID(operationID string) (internal.OperationLease, error)
+}
+
+type Bindings interface {
+	Insert(binding *internal.Binding) error
+	Update(binding *internal.Binding) error
+	Get(instanceID, bindingID string) (*internal.Binding, error)
+	ListByInstanceID(instanceID string) ([]internal.Binding, error)
+	ListExpired(now time.Time) ([]internal.Binding, error)
+	Delete(instanceID, bindingID string) error
+}
+
+type HyperscalerAccounts interface {
+	Insert(account internal.HyperscalerAccount) error
+	Update(account internal.HyperscalerAccount) (*internal.HyperscalerAccount, error)
+	List(hyperscalerType string) ([]internal.HyperscalerAccount, error)
+}
+
+type RuntimeDrifts interface {
+	// Upsert stores the drift of the instance, replacing the previously detected one
+	Upsert(drift internal.RuntimeDrift) error
+	GetByInstanceID(instanceID string) (*internal.RuntimeDrift, error)
+	List() ([]internal.RuntimeDrift, error)
+	Delete(instanceID string) error
+}
+
+// EncryptedData re-encrypts the stored secrets with the active encryption key
+type EncryptedData interface {
+	// ReencryptInstances re-encrypts the provisioning parameters of at most batchSize instances with the IDs following afterID
+	// and returns the ID of the last processed instance, the empty ID is returned if there are no more instances.
+	// The records which cannot be re-encrypted are left unchanged and their number is returned.
+	ReencryptInstances(afterID string, batchSize int) (string, int, error)
+	ReencryptOperations(afterID string, batchSize int) (string, int, error)
+	ReencryptProvisionerInputs(afterID string, batchSize int) (string, int, error)
+	ReencryptBindings(afterID string, batchSize int) (string, int, error)
+	ReencryptRuntimeStates(afterID string, batchSize int) (string, int, error)
+}
+
 //go:generate mockery --name=Operations --output=automock --outpkg=mocks --case=underscore
 type Operations interface {
 	Provisioning
@@ -39,6 +88,7 @@ type Operations interface {
 	GetLastOperation(instanceID string) (*internal.Operation, error)
 	GetOperationByID(operationID string) (*internal.Operation, error)
 	GetNotFinishedOperationsByType(operationType internal.OperationType) ([]internal.Operation, error)
+	GetOperationsWithCompensationInProgress(operationType internal.OperationType) ([]internal.Operation, error)
 	GetOperationStatsByPlan() (map[string]internal.OperationStats, error)
 	GetOperationsForIDs(operationIDList []string) ([]internal.Operation, error)
 	GetOperationStatsForOrchestration(orchestrationID string) (map[string]int, error)
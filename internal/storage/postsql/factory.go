@@ -27,6 +27,7 @@ type ReadSession interface {
 	GetLastOperation(instanceID string) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationByID(opID string) (dbmodel.OperationDTO, dberr.Error)
 	GetNotFinishedOperationsByType(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
+	GetOperationsWithCompensationInProgress(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
 	CountNotFinishedOperationsByInstanceID(instanceID string) (int, dberr.Error)
 	GetOperationByTypeAndInstanceID(inID string, opType internal.OperationType) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationByInstanceID(inID string) (dbmodel.OperationDTO, dberr.Error)
@@ -52,6 +53,20 @@ type ReadSession interface {
 	GetLatestRuntimeStateWithKymaVersionByRuntimeID(runtimeID string) (dbmodel.RuntimeStateDTO, dberr.Error)
 	GetLatestRuntimeStateWithOIDCConfigByRuntimeID(runtimeID string) (dbmodel.RuntimeStateDTO, dberr.Error)
 	ListEvents(filter events.EventFilter) ([]events.EventDTO, error)
+	ListOperationTracesByOperationID(operationID string) ([]dbmodel.OperationTraceDTO, dberr.Error)
+	GetOperationLease(operationID string) (dbmodel.OperationLeaseDTO, dberr.Error)
+	GetBinding(instanceID, bindingID string) (dbmodel.BindingDTO, dberr.Error)
+	ListBindings(instanceID string) ([]dbmodel.BindingDTO, dberr.Error)
+	ListExpiredBindings(now time.Time) ([]dbmodel.BindingDTO, dberr.Error)
+	ListHyperscalerAccounts(hyperscalerType string) ([]dbmodel.HyperscalerAccountDTO, dberr.Error)
+	GetHyperscalerAccount(name string) (dbmodel.HyperscalerAccountDTO, dberr.Error)
+	GetRuntimeDrift(instanceID string) (dbmodel.RuntimeDriftDTO, dberr.Error)
+	ListRuntimeDrifts() ([]dbmodel.RuntimeDriftDTO, dberr.Error)
+	ListInstancesProvisioningParameters(afterID string, limit int) ([]dbmodel.ProvisioningParametersRecordDTO, dberr.Error)
+	ListOperationsProvisioningParameters(afterID string, limit int) ([]dbmodel.ProvisioningParametersRecordDTO, dberr.Error)
+	ListRuntimeStatesConfigs(afterID string, limit int) ([]dbmodel.RuntimeStateDTO, dberr.Error)
+	ListOperationsProvisionerInputs(afterID string, limit int) ([]dbmodel.ProvisionerInputRecordDTO, dberr.Error)
+	ListBindingsKubeconfigs(afterInstanceID, afterID string, limit int) ([]dbmodel.BindingDTO, dberr.Error)
 }
 
 //go:generate mockery --name=WriteSession
@@ -66,6 +81,24 @@ type WriteSession interface {
 	InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.Error
 	InsertEvent(level events.EventLevel, message, instanceID, operationID string) dberr.Error
 	DeleteEvents(until time.Time) dberr.Error
+	InsertOperationTrace(trace dbmodel.OperationTraceDTO) dberr.Error
+	InsertOperationLease(lease dbmodel.OperationLeaseDTO) dberr.Error
+	TakeOverOperationLease(lease dbmodel.OperationLeaseDTO, now time.Time) dberr.Error
+	RenewOperationLease(operationID, owner string, expiresAt time.Time) dberr.Error
+	DeleteOperationLease(operationID, owner string) dberr.Error
+	InsertBinding(binding dbmodel.BindingDTO) dberr.Error
+	UpdateBinding(binding dbmodel.BindingDTO) dberr.Error
+	DeleteBinding(instanceID, bindingID string) dberr.Error
+	InsertHyperscalerAccount(account dbmodel.HyperscalerAccountDTO) dberr.Error
+	UpdateHyperscalerAccount(account dbmodel.HyperscalerAccountDTO) dberr.Error
+	InsertRuntimeDrift(drift dbmodel.RuntimeDriftDTO) dberr.Error
+	UpdateRuntimeDrift(drift dbmodel.RuntimeDriftDTO) dberr.Error
+	DeleteRuntimeDrift(instanceID string) dberr.Error
+	UpdateInstanceProvisioningParameters(instanceID, previous, params string) dberr.Error
+	UpdateOperationProvisioningParameters(operationID, previous, params string) dberr.Error
+	UpdateRuntimeStateConfigs(previous, state dbmodel.RuntimeStateDTO) dberr.Error
+	UpdateOperationProvisionerInput(operationID, previous, input string) dberr.Error
+	UpdateBindingKubeconfig(instanceID, bindingID, previous, kubeconfig string) dberr.Error
 }
 
 type Transaction interface {
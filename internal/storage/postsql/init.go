@@ -12,12 +12,17 @@ import (
 )
 
 const (
-	schemaName             = "public"
-	InstancesTableName     = "instances"
-	OperationTableName     = "operations"
-	OrchestrationTableName = "orchestrations"
-	RuntimeStateTableName  = "runtime_states"
-	CreatedAtField         = "created_at"
+	schemaName                   = "public"
+	InstancesTableName           = "instances"
+	OperationTableName           = "operations"
+	OrchestrationTableName       = "orchestrations"
+	RuntimeStateTableName        = "runtime_states"
+	OperationTraceTableName      = "operation_traces"
+	OperationLeaseTableName      = "operation_leases"
+	BindingsTableName            = "bindings"
+	HyperscalerAccountsTableName = "hyperscaler_accounts"
+	RuntimeDriftsTableName       = "runtime_drifts"
+	CreatedAtField               = "created_at"
 )
 
 // InitializeDatabase opens database connection and initializes schema if it does not exist
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-project/kyma-environment-broker/common/events"
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
@@ -152,12 +153,11 @@ func (r readSession) ListOperations(filter dbmodel.OperationFilter) ([]dbmodel.O
 
 	stmt := r.session.Select("o.*").
 		From(dbr.I(OperationTableName).As("o")).
-		OrderBy("o.created_at")
+		OrderBy("o.created_at").
+		OrderBy("o.id")
 
 	// Add pagination if provided
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	paginate(stmt, "o.created_at", "o.id", filter.Page, filter.PageSize, filter.Cursor)
 
 	// Apply filtering if provided
 	addOperationFilters(stmt, filter)
@@ -194,12 +194,11 @@ func (r readSession) ListOrchestrations(filter dbmodel.OrchestrationFilter) ([]d
 
 	stmt := r.session.Select("*").
 		From(OrchestrationTableName).
-		OrderBy(CreatedAtField)
+		OrderBy(CreatedAtField).
+		OrderBy("orchestration_id")
 
 	// Add pagination if provided
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	paginate(stmt, CreatedAtField, "orchestration_id", filter.Page, filter.PageSize, filter.Cursor)
 
 	// Apply filtering if provided
 	addOrchestrationFilters(stmt, filter)
@@ -257,6 +256,24 @@ func (r readSession) GetNotFinishedOperationsByType(operationType internal.Opera
 	return operations, nil
 }
 
+func (r readSession) GetOperationsWithCompensationInProgress(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error) {
+	typeCondition := dbr.Eq("type", operationType)
+	stateCondition := dbr.Eq("state", domain.Failed)
+	var operations []dbmodel.OperationDTO
+
+	_, err := r.session.
+		Select("*").
+		From(OperationTableName).
+		Where(stateCondition).
+		Where(typeCondition).
+		Where("data->>'compensation_in_progress' = ?", "true").
+		Load(&operations)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations with compensation in progress: %s", err)
+	}
+	return operations, nil
+}
+
 func (r readSession) GetOperationByTypeAndInstanceID(inID string, opType internal.OperationType) (dbmodel.OperationDTO, dberr.Error) {
 	idCondition := dbr.Eq("instance_id", inID)
 	typeCondition := dbr.Eq("type", string(opType))
@@ -352,12 +369,11 @@ func (r readSession) ListOperationsByOrchestrationID(orchestrationID string, fil
 		Select("o.*").
 		From(dbr.I(OperationTableName).As("o")).
 		Where(condition).
-		OrderBy("o.created_at")
+		OrderBy("o.created_at").
+		OrderBy("o.id")
 
 	// Add pagination if provided
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	paginate(stmt, "o.created_at", "o.id", filter.Page, filter.PageSize, filter.Cursor)
 
 	// Apply filtering if provided
 	addOperationFilters(stmt, filter)
@@ -656,7 +672,8 @@ func (r readSession) ListInstances(filter dbmodel.InstanceFilter) ([]dbmodel.Ins
 		LeftJoin(dbr.I(OperationTableName).As("o2"), fmt.Sprintf("%s.instance_id = o2.instance_id AND o1.created_at < o2.created_at AND o2.state NOT IN ('%s', '%s')", InstancesTableName, orchestration.Pending, orchestration.Canceled)).
 		Where("o2.created_at IS NULL").
 		Where(fmt.Sprintf("o1.state NOT IN ('%s', '%s')", orchestration.Pending, orchestration.Canceled)).
-		OrderBy(fmt.Sprintf("%s.%s", InstancesTableName, CreatedAtField))
+		OrderBy(fmt.Sprintf("%s.%s", InstancesTableName, CreatedAtField)).
+		OrderBy(fmt.Sprintf("%s.instance_id", InstancesTableName))
 
 	if len(filter.States) > 0 {
 		stateFilters := buildInstanceStateFilters("o1", filter)
@@ -664,9 +681,7 @@ func (r readSession) ListInstances(filter dbmodel.InstanceFilter) ([]dbmodel.Ins
 	}
 
 	// Add pagination
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt = stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	paginate(stmt, fmt.Sprintf("%s.%s", InstancesTableName, CreatedAtField), fmt.Sprintf("%s.instance_id", InstancesTableName), filter.Page, filter.PageSize, filter.Cursor)
 
 	addInstanceFilters(stmt, filter)
 
@@ -700,6 +715,224 @@ func (r readSession) ListEvents(filter events.EventFilter) ([]events.EventDTO, e
 	return events, err
 }
 
+func (r readSession) ListOperationTracesByOperationID(operationID string) ([]dbmodel.OperationTraceDTO, dberr.Error) {
+	var traces []dbmodel.OperationTraceDTO
+	_, err := r.session.
+		Select("*").
+		From(OperationTraceTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		OrderBy("started_at").
+		Load(&traces)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operation traces: %s", err)
+	}
+	return traces, nil
+}
+
+func (r readSession) GetOperationLease(operationID string) (dbmodel.OperationLeaseDTO, dberr.Error) {
+	var lease dbmodel.OperationLeaseDTO
+	err := r.session.
+		Select("*").
+		From(OperationLeaseTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		LoadOne(&lease)
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.OperationLeaseDTO{}, dberr.NotFound("Cannot find lease of the operation %s", operationID)
+		}
+		return dbmodel.OperationLeaseDTO{}, dberr.Internal("Failed to get operation lease: %s", err)
+	}
+	return lease, nil
+}
+
+func (r readSession) GetBinding(instanceID, bindingID string) (dbmodel.BindingDTO, dberr.Error) {
+	var binding dbmodel.BindingDTO
+	err := r.session.
+		Select("*").
+		From(BindingsTableName).
+		Where(dbr.Eq("id", bindingID)).
+		Where(dbr.Eq("instance_id", instanceID)).
+		LoadOne(&binding)
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.BindingDTO{}, dberr.NotFound("Cannot find binding %s of the instance %s", bindingID, instanceID)
+		}
+		return dbmodel.BindingDTO{}, dberr.Internal("Failed to get binding: %s", err)
+	}
+	return binding, nil
+}
+
+func (r readSession) ListBindings(instanceID string) ([]dbmodel.BindingDTO, dberr.Error) {
+	var bindings []dbmodel.BindingDTO
+	_, err := r.session.
+		Select("*").
+		From(BindingsTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		OrderBy(CreatedAtField).
+		Load(&bindings)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get bindings: %s", err)
+	}
+	return bindings, nil
+}
+
+func (r readSession) ListExpiredBindings(now time.Time) ([]dbmodel.BindingDTO, dberr.Error) {
+	var bindings []dbmodel.BindingDTO
+	_, err := r.session.
+		Select("*").
+		From(BindingsTableName).
+		Where(dbr.Eq("state", string(domain.Succeeded))).
+		Where(dbr.Lte("expires_at", now)).
+		Where("expired_at IS NULL").
+		OrderBy("expires_at").
+		Load(&bindings)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get expired bindings: %s", err)
+	}
+	return bindings, nil
+}
+
+func (r readSession) ListHyperscalerAccounts(hyperscalerType string) ([]dbmodel.HyperscalerAccountDTO, dberr.Error) {
+	var accounts []dbmodel.HyperscalerAccountDTO
+	_, err := r.session.
+		Select("*").
+		From(HyperscalerAccountsTableName).
+		Where(dbr.Eq("hyperscaler_type", hyperscalerType)).
+		OrderBy("name").
+		Load(&accounts)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get hyperscaler accounts: %s", err)
+	}
+	return accounts, nil
+}
+
+func (r readSession) GetHyperscalerAccount(name string) (dbmodel.HyperscalerAccountDTO, dberr.Error) {
+	var account dbmodel.HyperscalerAccountDTO
+	err := r.session.
+		Select("*").
+		From(HyperscalerAccountsTableName).
+		Where(dbr.Eq("name", name)).
+		LoadOne(&account)
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.HyperscalerAccountDTO{}, dberr.NotFound("Cannot find hyperscaler account %s", name)
+		}
+		return dbmodel.HyperscalerAccountDTO{}, dberr.Internal("Failed to get hyperscaler account: %s", err)
+	}
+	return account, nil
+}
+
+func (r readSession) GetRuntimeDrift(instanceID string) (dbmodel.RuntimeDriftDTO, dberr.Error) {
+	var drift dbmodel.RuntimeDriftDTO
+	err := r.session.
+		Select("*").
+		From(RuntimeDriftsTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		LoadOne(&drift)
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.RuntimeDriftDTO{}, dberr.NotFound("Cannot find runtime drift for instance %s", instanceID)
+		}
+		return dbmodel.RuntimeDriftDTO{}, dberr.Internal("Failed to get runtime drift: %s", err)
+	}
+	return drift, nil
+}
+
+func (r readSession) ListRuntimeDrifts() ([]dbmodel.RuntimeDriftDTO, dberr.Error) {
+	var drifts []dbmodel.RuntimeDriftDTO
+	_, err := r.session.
+		Select("*").
+		From(RuntimeDriftsTableName).
+		OrderBy("instance_id").
+		Load(&drifts)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get runtime drifts: %s", err)
+	}
+	return drifts, nil
+}
+
+func (r readSession) ListInstancesProvisioningParameters(afterID string, limit int) ([]dbmodel.ProvisioningParametersRecordDTO, dberr.Error) {
+	var records []dbmodel.ProvisioningParametersRecordDTO
+	_, err := r.session.
+		Select("instance_id AS id", "provisioning_parameters").
+		From(InstancesTableName).
+		Where(dbr.Gt("instance_id", afterID)).
+		OrderBy("instance_id").
+		Limit(uint64(limit)).
+		Load(&records)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get instances provisioning parameters: %s", err)
+	}
+	return records, nil
+}
+
+func (r readSession) ListOperationsProvisioningParameters(afterID string, limit int) ([]dbmodel.ProvisioningParametersRecordDTO, dberr.Error) {
+	var records []dbmodel.ProvisioningParametersRecordDTO
+	_, err := r.session.
+		Select("id", "provisioning_parameters").
+		From(OperationTableName).
+		Where(dbr.Gt("id", afterID)).
+		Where("provisioning_parameters IS NOT NULL").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Load(&records)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations provisioning parameters: %s", err)
+	}
+	return records, nil
+}
+
+func (r readSession) ListRuntimeStatesConfigs(afterID string, limit int) ([]dbmodel.RuntimeStateDTO, dberr.Error) {
+	var states []dbmodel.RuntimeStateDTO
+	_, err := r.session.
+		Select("id", "kyma_config", "cluster_setup").
+		From(RuntimeStateTableName).
+		Where(dbr.Gt("id", afterID)).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Load(&states)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get runtime states configs: %s", err)
+	}
+	return states, nil
+}
+
+func (r readSession) ListOperationsProvisionerInputs(afterID string, limit int) ([]dbmodel.ProvisionerInputRecordDTO, dberr.Error) {
+	var records []dbmodel.ProvisionerInputRecordDTO
+	_, err := r.session.
+		Select("id", "provisioner_input").
+		From(OperationTableName).
+		Where(dbr.Gt("id", afterID)).
+		Where("provisioner_input IS NOT NULL").
+		Where(dbr.Neq("provisioner_input", "")).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Load(&records)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations provisioner inputs: %s", err)
+	}
+	return records, nil
+}
+
+// ListBindingsKubeconfigs lists the bindings with the kubeconfigs ordered by the instance ID and the binding ID, which identify the binding
+func (r readSession) ListBindingsKubeconfigs(afterInstanceID, afterID string, limit int) ([]dbmodel.BindingDTO, dberr.Error) {
+	var bindings []dbmodel.BindingDTO
+	_, err := r.session.
+		Select("instance_id", "id", "kubeconfig").
+		From(BindingsTableName).
+		Where("(instance_id, id) > (?, ?)", afterInstanceID, afterID).
+		Where("kubeconfig IS NOT NULL").
+		Where(dbr.Neq("kubeconfig", "")).
+		OrderBy("instance_id").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Load(&bindings)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get bindings kubeconfigs: %s", err)
+	}
+	return bindings, nil
+}
+
 func (r readSession) getInstanceCount(filter dbmodel.InstanceFilter) (int, error) {
 	var res struct {
 		Total int
@@ -733,6 +966,12 @@ func buildInstanceStateFilters(table string, filter dbmodel.InstanceFilter) dbr.
 				dbr.Eq(fmt.Sprintf("%s.state", table), domain.Succeeded),
 				dbr.Neq(fmt.Sprintf("%s.type", table), internal.OperationTypeDeprovision),
 			))
+		case dbmodel.InstanceHibernated:
+			exprs = append(exprs, dbr.And(
+				dbr.Eq(fmt.Sprintf("%s.state", table), domain.Succeeded),
+				dbr.Neq(fmt.Sprintf("%s.type", table), internal.OperationTypeDeprovision),
+				dbr.Expr(fmt.Sprintf("%s.hibernated_at IS NOT NULL", InstancesTableName)),
+			))
 		case dbmodel.InstanceFailed:
 			exprs = append(exprs, dbr.And(
 				dbr.Or(
@@ -787,6 +1026,9 @@ func addInstanceFilters(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
 	if len(filter.GlobalAccountIDs) > 0 {
 		stmt.Where("instances.global_account_id IN ?", filter.GlobalAccountIDs)
 	}
+	if len(filter.SubscriptionGlobalAccountIDs) > 0 {
+		stmt.Where("instances.subscription_global_account_id IN ?", filter.SubscriptionGlobalAccountIDs)
+	}
 	if len(filter.SubAccountIDs) > 0 {
 		stmt.Where("instances.sub_account_id IN ?", filter.SubAccountIDs)
 	}
@@ -889,3 +1131,17 @@ func (r readSession) getOrchestrationCount(filter dbmodel.OrchestrationFilter) (
 
 	return res.Total, err
 }
+
+// paginate applies the keyset pagination if the cursor is provided, otherwise the offset pagination is applied
+func paginate(stmt *dbr.SelectStmt, createdAtColumn, idColumn string, page, pageSize int, cursor *pagination.Cursor) {
+	if cursor != nil {
+		stmt.Where(fmt.Sprintf("(%s, %s) > (?, ?)", createdAtColumn, idColumn), cursor.CreatedAt, cursor.ID)
+		if pageSize > 0 {
+			stmt.Limit(uint64(pageSize))
+		}
+		return
+	}
+	if page > 0 && pageSize > 0 {
+		stmt.Paginate(uint64(page), uint64(pageSize))
+	}
+}
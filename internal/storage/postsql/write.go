@@ -38,6 +38,7 @@ func (ws writeSession) InsertInstance(instance dbmodel.InstanceDTO) dberr.Error
 		Pair("provider", instance.Provider).
 		Pair("deleted_at", instance.DeletedAt).
 		Pair("expired_at", instance.ExpiredAt).
+		Pair("hibernated_at", instance.HibernatedAt).
 		Pair("version", instance.Version).
 		Exec()
 
@@ -82,6 +83,7 @@ func (ws writeSession) UpdateInstance(instance dbmodel.InstanceDTO) dberr.Error
 		Set("deleted_at", instance.DeletedAt).
 		Set("version", instance.Version+1).
 		Set("expired_at", instance.ExpiredAt).
+		Set("hibernated_at", instance.HibernatedAt).
 		Exec()
 	if err != nil {
 		return dberr.Internal("Failed to update record to Instance table: %s", err)
@@ -112,6 +114,7 @@ func (ws writeSession) InsertOperation(op dbmodel.OperationDTO) dberr.Error {
 		Pair("data", op.Data).
 		Pair("orchestration_id", op.OrchestrationID.String).
 		Pair("provisioning_parameters", op.ProvisioningParameters.String).
+		Pair("provisioner_input", op.ProvisionerInput).
 		Pair("finished_stages", op.FinishedStages).
 		Exec()
 
@@ -136,6 +139,7 @@ func (ws writeSession) InsertOrchestration(o dbmodel.OrchestrationDTO) dberr.Err
 		Pair("state", o.State).
 		Pair("type", o.Type).
 		Pair("parameters", o.Parameters).
+		Pair("canary_state", o.CanaryState).
 		Exec()
 
 	if err != nil {
@@ -159,6 +163,7 @@ func (ws writeSession) UpdateOrchestration(o dbmodel.OrchestrationDTO) dberr.Err
 		Set("state", o.State).
 		Set("type", o.Type).
 		Set("parameters", o.Parameters).
+		Set("canary_state", o.CanaryState).
 		Exec()
 
 	if err != nil {
@@ -219,6 +224,7 @@ func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 		Set("data", op.Data).
 		Set("orchestration_id", op.OrchestrationID.String).
 		Set("provisioning_parameters", op.ProvisioningParameters.String).
+		Set("provisioner_input", op.ProvisionerInput).
 		Set("finished_stages", op.FinishedStages).
 		Exec()
 
@@ -265,6 +271,359 @@ func (ws writeSession) DeleteEvents(until time.Time) dberr.Error {
 	return nil
 }
 
+func (ws writeSession) InsertOperationTrace(trace dbmodel.OperationTraceDTO) dberr.Error {
+	_, err := ws.insertInto(OperationTraceTableName).
+		Pair("id", trace.ID).
+		Pair("operation_id", trace.OperationID).
+		Pair("instance_id", trace.InstanceID).
+		Pair("stage", trace.Stage).
+		Pair("step_name", trace.StepName).
+		Pair("started_at", trace.StartedAt).
+		Pair("finished_at", trace.FinishedAt).
+		Pair("backoff", trace.Backoff).
+		Pair("error", trace.Error).
+		Pair("state", trace.State).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to insert operation trace: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertOperationLease(lease dbmodel.OperationLeaseDTO) dberr.Error {
+	_, err := ws.insertInto(OperationLeaseTableName).
+		Pair("operation_id", lease.OperationID).
+		Pair("owner", lease.Owner).
+		Pair("acquired_at", lease.AcquiredAt).
+		Pair("expires_at", lease.ExpiresAt).
+		Exec()
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("lease of the operation %s already exist", lease.OperationID)
+			}
+		}
+		return dberr.Internal("Failed to insert operation lease: %s", err)
+	}
+	return nil
+}
+
+// TakeOverOperationLease sets the owner of the lease if the lease is already owned by the owner or is expired at the given time
+func (ws writeSession) TakeOverOperationLease(lease dbmodel.OperationLeaseDTO, now time.Time) dberr.Error {
+	res, err := ws.update(OperationLeaseTableName).
+		Where(dbr.Eq("operation_id", lease.OperationID)).
+		Where(dbr.Or(dbr.Eq("owner", lease.Owner), dbr.Lt("expires_at", now))).
+		Set("owner", lease.Owner).
+		Set("acquired_at", lease.AcquiredAt).
+		Set("expires_at", lease.ExpiresAt).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update operation lease: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.Conflict("lease of the operation %s is held by another owner", lease.OperationID)
+	}
+	return nil
+}
+
+func (ws writeSession) RenewOperationLease(operationID, owner string, expiresAt time.Time) dberr.Error {
+	res, err := ws.update(OperationLeaseTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		Where(dbr.Eq("owner", owner)).
+		Set("expires_at", expiresAt).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to renew operation lease: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.Conflict("lease of the operation %s is not held by %s", operationID, owner)
+	}
+	return nil
+}
+
+func (ws writeSession) DeleteOperationLease(operationID, owner string) dberr.Error {
+	_, err := ws.deleteFrom(OperationLeaseTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		Where(dbr.Eq("owner", owner)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to delete operation lease: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertBinding(binding dbmodel.BindingDTO) dberr.Error {
+	_, err := ws.insertInto(BindingsTableName).
+		Pair("id", binding.ID).
+		Pair("instance_id", binding.InstanceID).
+		Pair("created_at", binding.CreatedAt).
+		Pair("updated_at", binding.UpdatedAt).
+		Pair("expires_at", binding.ExpiresAt).
+		Pair("expired_at", binding.ExpiredAt).
+		Pair("kubeconfig", binding.Kubeconfig).
+		Pair("state", binding.State).
+		Pair("description", binding.Description).
+		Exec()
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("binding %s of the instance %s already exist", binding.ID, binding.InstanceID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to Bindings table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) UpdateBinding(binding dbmodel.BindingDTO) dberr.Error {
+	res, err := ws.update(BindingsTableName).
+		Where(dbr.Eq("id", binding.ID)).
+		Where(dbr.Eq("instance_id", binding.InstanceID)).
+		Set("updated_at", binding.UpdatedAt).
+		Set("expires_at", binding.ExpiresAt).
+		Set("expired_at", binding.ExpiredAt).
+		Set("kubeconfig", binding.Kubeconfig).
+		Set("state", binding.State).
+		Set("description", binding.Description).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update record to Bindings table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find binding %s of the instance %s", binding.ID, binding.InstanceID)
+	}
+	return nil
+}
+
+func (ws writeSession) DeleteBinding(instanceID, bindingID string) dberr.Error {
+	_, err := ws.deleteFrom(BindingsTableName).
+		Where(dbr.Eq("id", bindingID)).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to delete record from Bindings table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertHyperscalerAccount(account dbmodel.HyperscalerAccountDTO) dberr.Error {
+	_, err := ws.insertInto(HyperscalerAccountsTableName).
+		Pair("name", account.Name).
+		Pair("secret_name", account.SecretName).
+		Pair("hyperscaler_type", account.HyperscalerType).
+		Pair("tenant_name", account.TenantName).
+		Pair("shared", account.Shared).
+		Pair("dirty", account.Dirty).
+		Pair("internal", account.Internal).
+		Pair("eu_access", account.EUAccess).
+		Pair("manual_cleanup", account.ManualCleanup).
+		Pair("created_at", account.CreatedAt).
+		Pair("updated_at", account.UpdatedAt).
+		Pair("version", account.Version).
+		Exec()
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("hyperscaler account %s already exists", account.Name)
+			}
+		}
+		return dberr.Internal("Failed to insert record to HyperscalerAccounts table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) UpdateHyperscalerAccount(account dbmodel.HyperscalerAccountDTO) dberr.Error {
+	res, err := ws.update(HyperscalerAccountsTableName).
+		Where(dbr.Eq("name", account.Name)).
+		Where(dbr.Eq("version", account.Version)).
+		Set("secret_name", account.SecretName).
+		Set("tenant_name", account.TenantName).
+		Set("shared", account.Shared).
+		Set("dirty", account.Dirty).
+		Set("internal", account.Internal).
+		Set("eu_access", account.EUAccess).
+		Set("manual_cleanup", account.ManualCleanup).
+		Set("updated_at", account.UpdatedAt).
+		Set("version", account.Version+1).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update record to HyperscalerAccounts table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find hyperscaler account %s with version %d", account.Name, account.Version)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertRuntimeDrift(drift dbmodel.RuntimeDriftDTO) dberr.Error {
+	_, err := ws.insertInto(RuntimeDriftsTableName).
+		Pair("instance_id", drift.InstanceID).
+		Pair("runtime_id", drift.RuntimeID).
+		Pair("shoot_name", drift.ShootName).
+		Pair("findings", drift.Findings).
+		Pair("detected_at", drift.DetectedAt).
+		Exec()
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("runtime drift for instance %s already exists", drift.InstanceID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to RuntimeDrifts table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) UpdateRuntimeDrift(drift dbmodel.RuntimeDriftDTO) dberr.Error {
+	res, err := ws.update(RuntimeDriftsTableName).
+		Where(dbr.Eq("instance_id", drift.InstanceID)).
+		Set("runtime_id", drift.RuntimeID).
+		Set("shoot_name", drift.ShootName).
+		Set("findings", drift.Findings).
+		Set("detected_at", drift.DetectedAt).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update record to RuntimeDrifts table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find runtime drift for instance %s", drift.InstanceID)
+	}
+	return nil
+}
+
+func (ws writeSession) DeleteRuntimeDrift(instanceID string) dberr.Error {
+	_, err := ws.deleteFrom(RuntimeDriftsTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to delete record from RuntimeDrifts table: %s", err)
+	}
+	return nil
+}
+
+// UpdateInstanceProvisioningParameters replaces the provisioning parameters only if they were not changed since they were read
+func (ws writeSession) UpdateInstanceProvisioningParameters(instanceID, previous, params string) dberr.Error {
+	res, err := ws.update(InstancesTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Where(dbr.Eq("provisioning_parameters", previous)).
+		Set("provisioning_parameters", params).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update provisioning parameters in Instances table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find instance %s with unchanged provisioning parameters", instanceID)
+	}
+	return nil
+}
+
+// UpdateOperationProvisioningParameters replaces the provisioning parameters only if they were not changed since they were read
+func (ws writeSession) UpdateOperationProvisioningParameters(operationID, previous, params string) dberr.Error {
+	res, err := ws.update(OperationTableName).
+		Where(dbr.Eq("id", operationID)).
+		Where(dbr.Eq("provisioning_parameters", previous)).
+		Set("provisioning_parameters", params).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update provisioning parameters in Operations table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find operation %s with unchanged provisioning parameters", operationID)
+	}
+	return nil
+}
+
+// UpdateRuntimeStateConfigs replaces the encrypted configs of the runtime state, the runtime states are never modified
+func (ws writeSession) UpdateRuntimeStateConfigs(previous, state dbmodel.RuntimeStateDTO) dberr.Error {
+	res, err := ws.update(RuntimeStateTableName).
+		Where(dbr.Eq("id", state.ID)).
+		Where(dbr.Eq("kyma_config", previous.KymaConfig)).
+		Where(dbr.Eq("cluster_setup", previous.ClusterSetup)).
+		Set("kyma_config", state.KymaConfig).
+		Set("cluster_setup", state.ClusterSetup).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update configs in RuntimeStates table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find runtime state %s with unchanged configs", state.ID)
+	}
+	return nil
+}
+
+// UpdateOperationProvisionerInput replaces the provisioner input only if it was not changed since it was read
+func (ws writeSession) UpdateOperationProvisionerInput(operationID, previous, input string) dberr.Error {
+	res, err := ws.update(OperationTableName).
+		Where(dbr.Eq("id", operationID)).
+		Where(dbr.Eq("provisioner_input", previous)).
+		Set("provisioner_input", input).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update provisioner input in Operations table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find operation %s with unchanged provisioner input", operationID)
+	}
+	return nil
+}
+
+// UpdateBindingKubeconfig replaces the kubeconfig of the binding only if it was not changed since it was read
+func (ws writeSession) UpdateBindingKubeconfig(instanceID, bindingID, previous, kubeconfig string) dberr.Error {
+	res, err := ws.update(BindingsTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Where(dbr.Eq("id", bindingID)).
+		Where(dbr.Eq("kubeconfig", previous)).
+		Set("kubeconfig", kubeconfig).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update kubeconfig in Bindings table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find binding %s of the instance %s with unchanged kubeconfig", bindingID, instanceID)
+	}
+	return nil
+}
+
 func (ws writeSession) Commit() dberr.Error {
 	err := ws.transaction.Commit()
 	if err != nil {
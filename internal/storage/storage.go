@@ -23,6 +23,12 @@ type BrokerStorage interface {
 	Orchestrations() Orchestrations
 	RuntimeStates() RuntimeStates
 	Events() Events
+	OperationTraces() OperationTraces
+	OperationLeases() OperationLeases
+	Bindings() Bindings
+	HyperscalerAccounts() HyperscalerAccounts
+	RuntimeDrifts() RuntimeDrifts
+	EncryptedData() EncryptedData
 }
 
 const (
@@ -51,6 +57,12 @@ func NewFromConfig(cfg Config, evcfg events.Config, cipher postgres.Cipher, log
 		orchestrations: postgres.NewOrchestrations(fact),
 		runtimeStates:  postgres.NewRuntimeStates(fact, cipher),
 		events:         events.New(evcfg, eventstorage.New(fact, log)),
+		traces:         postgres.NewOperationTraces(fact),
+		leases:         postgres.NewOperationLeases(fact),
+		bindings:       postgres.NewBindings(fact, cipher),
+		accounts:       postgres.NewHyperscalerAccounts(fact),
+		drifts:         postgres.NewRuntimeDrifts(fact),
+		encryptedData:  postgres.NewEncryptedData(fact, cipher),
 	}, connection, nil
 }
 
@@ -62,6 +74,12 @@ func NewMemoryStorage() BrokerStorage {
 		orchestrations: memory.NewOrchestrations(),
 		runtimeStates:  memory.NewRuntimeStates(),
 		events:         events.New(events.Config{}, NewInMemoryEvents()),
+		traces:         memory.NewOperationTraces(),
+		leases:         memory.NewOperationLeases(),
+		bindings:       memory.NewBindings(),
+		accounts:       memory.NewHyperscalerAccounts(),
+		drifts:         memory.NewRuntimeDrifts(),
+		encryptedData:  memory.NewEncryptedData(),
 	}
 }
 
@@ -119,6 +137,12 @@ type storage struct {
 	orchestrations Orchestrations
 	runtimeStates  RuntimeStates
 	events         Events
+	traces         OperationTraces
+	leases         OperationLeases
+	bindings       Bindings
+	accounts       HyperscalerAccounts
+	drifts         RuntimeDrifts
+	encryptedData  EncryptedData
 }
 
 func (s storage) Instances() Instances {
@@ -148,3 +172,27 @@ func (s storage) RuntimeStates() RuntimeStates {
 func (s storage) Events() Events {
 	return s.events
 }
+
+func (s storage) OperationTraces() OperationTraces {
+	return s.traces
+}
+
+func (s storage) OperationLeases() OperationLeases {
+	return s.leases
+}
+
+func (s storage) Bindings() Bindings {
+	return s.bindings
+}
+
+func (s storage) HyperscalerAccounts() HyperscalerAccounts {
+	return s.accounts
+}
+
+func (s storage) RuntimeDrifts() RuntimeDrifts {
+	return s.drifts
+}
+
+func (s storage) EncryptedData() EncryptedData {
+	return s.encryptedData
+}
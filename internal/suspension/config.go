@@ -0,0 +1,85 @@
+package suspension
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+)
+
+// Action defines what happens with the cluster when the subaccount is deactivated
+type Action string
+
+const (
+	// ActionDeprovision deprovisions the cluster and keeps the instance, the cluster is provisioned again from the stored parameters on the unsuspension
+	ActionDeprovision Action = "deprovision"
+	// ActionHibernate hibernates the shoot, the shoot is woken up on the unsuspension
+	ActionHibernate Action = "hibernate"
+	// ActionIgnore leaves the cluster untouched
+	ActionIgnore Action = "ignore"
+)
+
+type Config struct {
+	// Actions defines the suspension action per plan in the format <plan name>:<action> separated by commas.
+	// The deactivation of the subaccounts is ignored for the plans which are not listed.
+	Actions PlanActions `envconfig:"default=trial:deprovision"`
+	// UnsuspensionRetry limits the retries of the failed unsuspensions
+	UnsuspensionRetry UnsuspensionRetryConfig
+	// Retrier periodically retries the failed unsuspensions of the active instances
+	Retrier RetrierConfig
+}
+
+type UnsuspensionRetryConfig struct {
+	MaxAttempts int           `envconfig:"default=3"`
+	Backoff     time.Duration `envconfig:"default=10m"`
+}
+
+type RetrierConfig struct {
+	Enabled  bool          `envconfig:"default=false"`
+	Interval time.Duration `envconfig:"default=15m"`
+}
+
+// PlanActions maps the plan names to the suspension actions
+type PlanActions map[string]Action
+
+// Unmarshal provides custom parsing of the suspension actions.
+// Implements envconfig.Unmarshal interface.
+func (a *PlanActions) Unmarshal(in string) error {
+	actions := PlanActions{}
+	for _, entry := range strings.Split(in, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		plan, action, found := strings.Cut(entry, ":")
+		if !found {
+			return fmt.Errorf("invalid suspension action %q, expected <plan name>:<action>", entry)
+		}
+		plan, action = strings.TrimSpace(plan), strings.TrimSpace(action)
+		if _, exists := broker.PlanIDsMapping[plan]; !exists {
+			return fmt.Errorf("unrecognized %v plan name", plan)
+		}
+		switch Action(action) {
+		case ActionDeprovision, ActionIgnore:
+		case ActionHibernate:
+			if broker.IsOwnClusterPlan(broker.PlanIDsMapping[plan]) {
+				return fmt.Errorf("the cluster of the %s plan cannot be hibernated", plan)
+			}
+		default:
+			return fmt.Errorf("unrecognized suspension action %v for the %s plan", action, plan)
+		}
+		actions[plan] = Action(action)
+	}
+
+	*a = actions
+	return nil
+}
+
+// ForPlan returns the suspension action of the given plan ID
+func (a PlanActions) ForPlan(planID string) Action {
+	action, found := a[broker.PlanNamesMapping[planID]]
+	if !found {
+		return ActionIgnore
+	}
+	return action
+}
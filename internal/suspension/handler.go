@@ -3,11 +3,13 @@ package suspension
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kyma-project/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -19,6 +21,8 @@ type ContextUpdateHandler struct {
 	operations          storage.Operations
 	provisioningQueue   Adder
 	deprovisioningQueue Adder
+	hibernator          Hibernator
+	cfg                 Config
 
 	log logrus.FieldLogger
 }
@@ -27,17 +31,23 @@ type Adder interface {
 	Add(processId string)
 }
 
-func NewContextUpdateHandler(operations storage.Operations, provisioningQueue Adder, deprovisioningQueue Adder, l logrus.FieldLogger) *ContextUpdateHandler {
+type Hibernator interface {
+	SetHibernation(shootName string, enabled bool) error
+}
+
+func NewContextUpdateHandler(operations storage.Operations, provisioningQueue Adder, deprovisioningQueue Adder, hibernator Hibernator, cfg Config, l logrus.FieldLogger) *ContextUpdateHandler {
 	return &ContextUpdateHandler{
 		operations:          operations,
 		provisioningQueue:   provisioningQueue,
 		deprovisioningQueue: deprovisioningQueue,
+		hibernator:          hibernator,
+		cfg:                 cfg,
 		log:                 l,
 	}
 }
 
 // Handle performs suspension/unsuspension for given instance.
-// Applies only when 'Active' parameter has changes and ServicePlanID is `Trial`
+// Applies only when 'Active' parameter has changes, the suspension action configured for the plan of the instance is used.
 func (h *ContextUpdateHandler) Handle(instance *internal.Instance, newCtx internal.ERSContext) (bool, error) {
 	l := h.log.WithFields(logrus.Fields{
 		"instanceID":      instance.InstanceID,
@@ -45,12 +55,15 @@ func (h *ContextUpdateHandler) Handle(instance *internal.Instance, newCtx intern
 		"globalAccountID": instance.GlobalAccountID,
 	})
 
-	if !broker.IsTrialPlan(instance.ServicePlanID) {
-		l.Info("Context update for non-trial instance, skipping")
+	switch h.cfg.Actions.ForPlan(instance.ServicePlanID) {
+	case ActionDeprovision:
+		return h.handleContextChange(newCtx, instance, l)
+	case ActionHibernate:
+		return h.handleHibernation(newCtx, instance, l)
+	default:
+		l.Infof("Context update for the %s plan without suspension, skipping", broker.PlanNamesMapping[instance.ServicePlanID])
 		return false, nil
 	}
-
-	return h.handleContextChange(newCtx, instance, l)
 }
 
 func (h *ContextUpdateHandler) handleContextChange(newCtx internal.ERSContext, instance *internal.Instance, l logrus.FieldLogger) (bool, error) {
@@ -68,8 +81,10 @@ func (h *ContextUpdateHandler) handleContextChange(newCtx internal.ERSContext, i
 	if newCtx.Active == nil || isActivated == *newCtx.Active {
 		l.Debugf("Context.Active flag was not changed, the current value: %v", isActivated)
 		if isActivated {
-			// instance is marked as Active and incoming context update is unsuspension
-			// TODO: consider retriggering failed unsuspension here
+			// instance is marked as Active and incoming context update is unsuspension - verify if KEB should retrigger the failed unsuspension
+			if newCtx.Active != nil {
+				return h.RetryFailedUnsuspension(instance, l)
+			}
 			l.Infof("Context.Active flag is true - not triggering suspension for instance ID %s", instance.InstanceID)
 			return false, nil
 		}
@@ -103,6 +118,108 @@ func (h *ContextUpdateHandler) handleContextChange(newCtx internal.ERSContext, i
 	}
 }
 
+// handleHibernation hibernates the shoot when the subaccount is deactivated and wakes it up when the subaccount is activated again.
+// The failed attempts are not stored, so they are repeated with the next context update.
+func (h *ContextUpdateHandler) handleHibernation(newCtx internal.ERSContext, instance *internal.Instance, l logrus.FieldLogger) (bool, error) {
+	if newCtx.Active == nil || instance.IsExpired() {
+		return false, nil
+	}
+	if *newCtx.Active == !instance.IsDeactivated() {
+		l.Debugf("Context.Active flag was not changed, the current value: %v", *newCtx.Active)
+		return false, nil
+	}
+	if instance.InstanceDetails.ShootName == "" {
+		l.Infof("Instance without the shoot, skipping hibernation")
+		return false, nil
+	}
+
+	hibernate := !*newCtx.Active
+	if err := h.hibernator.SetHibernation(instance.InstanceDetails.ShootName, hibernate); err != nil {
+		return false, err
+	}
+	if hibernate {
+		now := time.Now()
+		instance.HibernatedAt = &now
+		l.Infof("shoot %s hibernated by the suspension", instance.InstanceDetails.ShootName)
+	} else {
+		instance.HibernatedAt = nil
+		l.Infof("shoot %s woken up by the unsuspension", instance.InstanceDetails.ShootName)
+	}
+	return true, nil
+}
+
+// RetryFailedUnsuspension starts a new unsuspension if the last unsuspension of the active instance failed.
+// The retries are limited by the configured number of attempts and the delay between them doubles with every failed attempt.
+func (h *ContextUpdateHandler) RetryFailedUnsuspension(instance *internal.Instance, l logrus.FieldLogger) (bool, error) {
+	if instance.IsExpired() {
+		return false, nil
+	}
+	failed, err := h.failedUnsuspensions(instance.InstanceID)
+	if err != nil {
+		return false, err
+	}
+	if len(failed) == 0 {
+		l.Infof("Context.Active flag is true - not triggering suspension for instance ID %s", instance.InstanceID)
+		return false, nil
+	}
+
+	lastFailed := failed[0]
+	if len(failed) > h.cfg.UnsuspensionRetry.MaxAttempts {
+		l.Infof("unsuspension of instance %s failed %d times, not retrying", instance.InstanceID, len(failed))
+		return false, nil
+	}
+	backoff := h.cfg.UnsuspensionRetry.Backoff * time.Duration(1<<(len(failed)-1))
+	if time.Since(lastFailed.UpdatedAt) < backoff {
+		l.Infof("unsuspension %s failed less than %s ago, not retrying yet", lastFailed.ID, backoff)
+		return false, nil
+	}
+
+	l.Infof("retrying failed unsuspension %s, attempt %d of %d", lastFailed.ID, len(failed), h.cfg.UnsuspensionRetry.MaxAttempts)
+	events.Infof(instance.InstanceID, lastFailed.ID, "retrying failed unsuspension, attempt %d of %d", len(failed), h.cfg.UnsuspensionRetry.MaxAttempts)
+	return true, h.unsuspend(instance, l)
+}
+
+// failedUnsuspensions returns the provisioning operations started after the last succeeded suspension, the newest first.
+// Nothing is returned if the last operation of the instance is not a failed unsuspension.
+func (h *ContextUpdateHandler) failedUnsuspensions(instanceID string) ([]internal.ProvisioningOperation, error) {
+	lastSuspension, err := h.operations.GetDeprovisioningOperationByInstanceID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	if !lastSuspension.Temporary || lastSuspension.State != domain.Succeeded {
+		return nil, nil
+	}
+
+	provisionings, err := h.operations.ListProvisioningOperationsByInstanceID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	var unsuspensions []internal.ProvisioningOperation
+	for _, op := range provisionings {
+		if op.CreatedAt.After(lastSuspension.CreatedAt) {
+			unsuspensions = append(unsuspensions, op)
+		}
+	}
+	if len(unsuspensions) == 0 || unsuspensions[0].State != domain.Failed {
+		return nil, nil
+	}
+
+	lastOp, err := h.operations.GetLastOperation(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if lastOp.ID != unsuspensions[0].ID {
+		return nil, nil
+	}
+	return unsuspensions, nil
+}
+
 func (h *ContextUpdateHandler) suspend(instance *internal.Instance, log logrus.FieldLogger) error {
 	lastDeprovisioning, err := h.operations.GetDeprovisioningOperationByInstanceID(instance.InstanceID)
 	// there was an error - fail
@@ -1,6 +1,7 @@
 package suspension
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,7 +26,7 @@ func TestSuspension(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, newFakeHibernator(), fixConfig(), logrus.New())
 	instance := fixInstance(fixActiveErsContext())
 	st.Instances().Insert(*instance)
 
@@ -50,7 +51,7 @@ func TestSuspension_Retrigger(t *testing.T) {
 		deprovisioning := NewDummyQueue()
 		st := storage.NewMemoryStorage()
 
-		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, newFakeHibernator(), fixConfig(), logrus.New())
 		instance := fixInstance(fixInactiveErsContext())
 		st.Instances().Insert(*instance)
 		st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
@@ -86,7 +87,7 @@ func TestSuspension_Retrigger(t *testing.T) {
 		deprovisioning := NewDummyQueue()
 		st := storage.NewMemoryStorage()
 
-		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, newFakeHibernator(), fixConfig(), logrus.New())
 		instance := fixInstance(fixInactiveErsContext())
 		st.Instances().Insert(*instance)
 		st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
@@ -133,7 +134,7 @@ func TestUnsuspension(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, newFakeHibernator(), fixConfig(), logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 	instance.InstanceDetails.ShootName = "c-012345"
 	instance.InstanceDetails.ShootDomain = "c-012345.sap.com"
@@ -167,7 +168,7 @@ func TestUnsuspensionForDeprovisioningInstance(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, newFakeHibernator(), fixConfig(), logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 	instance.InstanceDetails.ShootName = "c-012345"
 	instance.InstanceDetails.ShootDomain = "c-012345.sap.com"
@@ -195,7 +196,7 @@ func TestUnsuspensionForExpiredInstance(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, newFakeHibernator(), fixConfig(), logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 	instance.InstanceDetails.ShootName = "c-012345"
 	instance.InstanceDetails.ShootDomain = "c-012345.sap.com"
@@ -213,6 +214,252 @@ func TestUnsuspensionForExpiredInstance(t *testing.T) {
 	assert.True(t, dberr.IsNotFound(err))
 }
 
+func TestSuspension_Hibernation(t *testing.T) {
+	t.Run("should hibernate the shoot when the subaccount is deactivated", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		deprovisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		hibernator := newFakeHibernator()
+
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, hibernator, fixConfig(), logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.InstanceDetails.ShootName = "c-012345"
+
+		// when
+		changed, err := svc.Handle(instance, fixInactiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed, "handler to change active flag")
+		assert.True(t, hibernator.hibernated["c-012345"])
+		assert.True(t, instance.IsHibernated())
+		assertQueue(t, deprovisioning)
+		assertQueue(t, provisioning)
+	})
+
+	t.Run("should wake up the shoot when the subaccount is activated", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		deprovisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		hibernator := newFakeHibernator()
+		hibernator.hibernated["c-012345"] = true
+
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, hibernator, fixConfig(), logrus.New())
+		instance := fixInstance(fixInactiveErsContext())
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.InstanceDetails.ShootName = "c-012345"
+		instance.HibernatedAt = ptr.Time(time.Now())
+
+		// when
+		changed, err := svc.Handle(instance, fixActiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed, "handler to change active flag")
+		assert.False(t, hibernator.hibernated["c-012345"])
+		assert.False(t, instance.IsHibernated())
+		assertQueue(t, deprovisioning)
+		assertQueue(t, provisioning)
+	})
+
+	t.Run("should not change the instance when the hibernation fails", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		hibernator := newFakeHibernator()
+		hibernator.err = fmt.Errorf("gardener not available")
+
+		svc := NewContextUpdateHandler(st.Operations(), NewDummyQueue(), NewDummyQueue(), hibernator, fixConfig(), logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.InstanceDetails.ShootName = "c-012345"
+
+		// when
+		changed, err := svc.Handle(instance, fixInactiveErsContext())
+
+		// then
+		assert.Error(t, err)
+		assert.False(t, changed)
+		assert.False(t, instance.IsHibernated())
+	})
+}
+
+func TestSuspension_IgnoredPlan(t *testing.T) {
+	// given
+	provisioning := NewDummyQueue()
+	deprovisioning := NewDummyQueue()
+	st := storage.NewMemoryStorage()
+	hibernator := newFakeHibernator()
+
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, hibernator, fixConfig(), logrus.New())
+	instance := fixInstance(fixActiveErsContext())
+	instance.ServicePlanID = broker.AWSPlanID
+
+	// when
+	changed, err := svc.Handle(instance, fixInactiveErsContext())
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, hibernator.hibernated)
+	assertQueue(t, deprovisioning)
+	assertQueue(t, provisioning)
+}
+
+func TestPlanActions_Unmarshal(t *testing.T) {
+	t.Run("valid actions", func(t *testing.T) {
+		// given
+		var actions PlanActions
+
+		// when
+		err := actions.Unmarshal("trial:deprovision, azure:hibernate,aws:ignore")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ActionDeprovision, actions.ForPlan(broker.TrialPlanID))
+		assert.Equal(t, ActionHibernate, actions.ForPlan(broker.AzurePlanID))
+		assert.Equal(t, ActionIgnore, actions.ForPlan(broker.AWSPlanID))
+		assert.Equal(t, ActionIgnore, actions.ForPlan(broker.GCPPlanID))
+	})
+
+	for name, in := range map[string]string{
+		"unknown plan":           "unknown:deprovision",
+		"unknown action":         "azure:remove",
+		"missing action":         "azure",
+		"own cluster hibernated": "own_cluster:hibernate",
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var actions PlanActions
+
+			// when
+			err := actions.Unmarshal(in)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUnsuspension_RetryFailed(t *testing.T) {
+	t.Run("should retry the failed unsuspension", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, NewDummyQueue(), newFakeHibernator(), fixConfig(), logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		require.NoError(t, st.Instances().Insert(*instance))
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 1, time.Now().Add(-2*time.Minute))
+
+		// when
+		changed, err := svc.Handle(instance, fixActiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		op, err := st.Operations().GetProvisioningOperationByInstanceID(instance.InstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LastOperationState(orchestration.Pending), op.State)
+		assertQueue(t, provisioning, op.ID)
+	})
+
+	t.Run("should not retry the failed unsuspension before the backoff", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, NewDummyQueue(), newFakeHibernator(), fixConfig(), logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		require.NoError(t, st.Instances().Insert(*instance))
+		// the second retry is delayed by two minutes
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 2, time.Now().Add(-90*time.Second))
+
+		// when
+		changed, err := svc.Handle(instance, fixActiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assertQueue(t, provisioning)
+	})
+
+	t.Run("should not retry the failed unsuspension after the max attempts", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, NewDummyQueue(), newFakeHibernator(), fixConfig(), logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		require.NoError(t, st.Instances().Insert(*instance))
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 3, time.Now().Add(-time.Hour))
+
+		// when
+		changed, err := svc.Handle(instance, fixActiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assertQueue(t, provisioning)
+	})
+
+	t.Run("should not retry the failed provisioning", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, NewDummyQueue(), newFakeHibernator(), fixConfig(), logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		require.NoError(t, st.Instances().Insert(*instance))
+		operation := fixture.FixProvisioningOperation("p-op", instance.InstanceID)
+		operation.State = domain.Failed
+		operation.UpdatedAt = time.Now().Add(-time.Hour)
+		require.NoError(t, st.Operations().InsertOperation(operation))
+
+		// when
+		changed, err := svc.Handle(instance, fixActiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assertQueue(t, provisioning)
+	})
+}
+
+// givenFailedUnsuspensions stores the provisioning, the suspension and the given number of failed unsuspensions, the last one failed at the given time
+func givenFailedUnsuspensions(t *testing.T, st storage.BrokerStorage, instanceID string, attempts int, lastFailedAt time.Time) {
+	start := lastFailedAt.Add(-time.Duration(attempts+2) * time.Minute)
+
+	provisioning := fixture.FixProvisioningOperation(instanceID+"-p-op", instanceID)
+	provisioning.CreatedAt = start
+	provisioning.UpdatedAt = start
+	require.NoError(t, st.Operations().InsertOperation(provisioning))
+
+	suspension := fixture.FixSuspensionOperationAsOperation(instanceID+"-s-op", instanceID)
+	suspension.CreatedAt = start.Add(time.Minute)
+	suspension.UpdatedAt = start.Add(time.Minute)
+	require.NoError(t, st.Operations().InsertOperation(suspension))
+
+	for i := 1; i <= attempts; i++ {
+		unsuspension := fixture.FixProvisioningOperation(fmt.Sprintf("%s-u-op-%d", instanceID, i), instanceID)
+		unsuspension.State = domain.Failed
+		unsuspension.CreatedAt = start.Add(time.Duration(i+1) * time.Minute)
+		unsuspension.UpdatedAt = unsuspension.CreatedAt
+		if i == attempts {
+			unsuspension.UpdatedAt = lastFailedAt
+		}
+		require.NoError(t, st.Operations().InsertOperation(unsuspension))
+	}
+}
+
+func fixConfig() Config {
+	return Config{
+		Actions: PlanActions{
+			broker.TrialPlanName: ActionDeprovision,
+			broker.AzurePlanName: ActionHibernate,
+		},
+		UnsuspensionRetry: UnsuspensionRetryConfig{MaxAttempts: 2, Backoff: time.Minute},
+	}
+}
+
 func fixInstance(ersContext internal.ERSContext) *internal.Instance {
 	instance := fixture.FixInstance("instance-id")
 	instance.ServicePlanID = broker.TrialPlanID
@@ -246,3 +493,20 @@ func NewDummyQueue() *dummyQueue {
 func (q *dummyQueue) Add(id string) {
 	q.IDs = append(q.IDs, id)
 }
+
+type fakeHibernator struct {
+	hibernated map[string]bool
+	err        error
+}
+
+func newFakeHibernator() *fakeHibernator {
+	return &fakeHibernator{hibernated: map[string]bool{}}
+}
+
+func (h *fakeHibernator) SetHibernation(shootName string, enabled bool) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.hibernated[shootName] = enabled
+	return nil
+}
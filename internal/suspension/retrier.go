@@ -0,0 +1,110 @@
+package suspension
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+)
+
+const instancesPageSize = 100
+
+// Retrier retries the failed unsuspensions of the instances whose subaccounts are active,
+// so the instances do not stay without the cluster until the next context update
+type Retrier struct {
+	instances storage.Instances
+	handler   *ContextUpdateHandler
+	cfg       RetrierConfig
+	log       logrus.FieldLogger
+	lease     *process.JobLease
+}
+
+func NewRetrier(db storage.BrokerStorage, handler *ContextUpdateHandler, cfg RetrierConfig, log logrus.FieldLogger) *Retrier {
+	return &Retrier{
+		instances: db.Instances(),
+		handler:   handler,
+		cfg:       cfg,
+		log:       log.WithField("service", "UnsuspensionRetrier"),
+	}
+}
+
+// UseLease makes the retrier run only in the KEB replica which holds the lease, so the unsuspension
+// of the instance is not retried by many replicas at the same time
+func (r *Retrier) UseLease(lease *process.JobLease) {
+	r.lease = lease
+}
+
+func (r *Retrier) Start(ctx context.Context) {
+	go r.run(ctx)
+}
+
+func (r *Retrier) run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !r.holdsLease() {
+				continue
+			}
+			if err := r.Run(); err != nil {
+				r.log.Errorf("unsuspension retry failed: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Retrier) holdsLease() bool {
+	if r.lease == nil {
+		return true
+	}
+	acquired, err := r.lease.Acquire()
+	if err != nil {
+		r.log.Errorf("unable to acquire the unsuspension retrier lease: %v", err)
+		return false
+	}
+	return acquired
+}
+
+// Run retries the unsuspensions of the failed active instances of the plans deprovisioned by the suspension
+func (r *Retrier) Run() error {
+	filter := dbmodel.InstanceFilter{
+		States:   []dbmodel.InstanceState{dbmodel.InstanceFailed},
+		PageSize: instancesPageSize,
+		Page:     1,
+	}
+	for {
+		instances, _, _, err := r.instances.List(filter)
+		if err != nil {
+			return fmt.Errorf("while listing instances: %w", err)
+		}
+		// the retried instances are no longer failed, so the pages are read after the cursor and not by the page number
+		for _, instance := range instances {
+			if err := r.retry(instance); err != nil {
+				r.log.Warnf("unable to retry the unsuspension of instance %s: %v", instance.InstanceID, err)
+			}
+		}
+		if len(instances) < instancesPageSize {
+			break
+		}
+		last := instances[len(instances)-1]
+		filter.Cursor = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.InstanceID}
+	}
+	return nil
+}
+
+func (r *Retrier) retry(instance internal.Instance) error {
+	if instance.IsDeactivated() || r.handler.cfg.Actions.ForPlan(instance.ServicePlanID) != ActionDeprovision {
+		return nil
+	}
+	_, err := r.handler.RetryFailedUnsuspension(&instance, r.log.WithField("instanceID", instance.InstanceID))
+	return err
+}
@@ -0,0 +1,110 @@
+package suspension
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrier_Run(t *testing.T) {
+	t.Run("should retry the failed unsuspension of the active instance", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		instance := fixInstance(fixActiveErsContext())
+		require.NoError(t, st.Instances().Insert(*instance))
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 1, time.Now().Add(-time.Hour))
+		retrier := newRetrier(st, provisioning)
+
+		// when
+		err := retrier.Run()
+
+		// then
+		require.NoError(t, err)
+		op, err := st.Operations().GetProvisioningOperationByInstanceID(instance.InstanceID)
+		require.NoError(t, err)
+		assertQueue(t, provisioning, op.ID)
+	})
+
+	t.Run("should skip the deactivated instance", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		instance := fixInstance(fixInactiveErsContext())
+		require.NoError(t, st.Instances().Insert(*instance))
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 1, time.Now().Add(-time.Hour))
+		retrier := newRetrier(st, provisioning)
+
+		// when
+		err := retrier.Run()
+
+		// then
+		require.NoError(t, err)
+		assertQueue(t, provisioning)
+	})
+
+	t.Run("should skip the instance of the plan not deprovisioned by the suspension", func(t *testing.T) {
+		// given
+		provisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		instance := fixInstance(fixActiveErsContext())
+		instance.ServicePlanID = broker.AzurePlanID
+		require.NoError(t, st.Instances().Insert(*instance))
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 1, time.Now().Add(-time.Hour))
+		retrier := newRetrier(st, provisioning)
+
+		// when
+		err := retrier.Run()
+
+		// then
+		require.NoError(t, err)
+		assertQueue(t, provisioning)
+	})
+}
+
+func newRetrier(st storage.BrokerStorage, provisioning *dummyQueue) *Retrier {
+	handler := NewContextUpdateHandler(st.Operations(), provisioning, NewDummyQueue(), newFakeHibernator(), fixConfig(), logrus.New())
+	return NewRetrier(st, handler, RetrierConfig{}, logrus.New())
+}
+
+func TestRetrier_RunAllPages(t *testing.T) {
+	// given
+	provisioning := NewDummyQueue()
+	st := storage.NewMemoryStorage()
+	count := 2*instancesPageSize + 1
+	for i := 0; i < count; i++ {
+		instance := fixInstance(fixActiveErsContext())
+		instance.InstanceID = fmt.Sprintf("instance-%03d", i)
+		require.NoError(t, st.Instances().Insert(*instance))
+		givenFailedUnsuspensions(t, st, instance.InstanceID, 1, time.Now().Add(-time.Hour))
+	}
+	retrier := newRetrier(st, provisioning)
+
+	// when
+	err := retrier.Run()
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, provisioning.IDs, count)
+}
+
+func TestRetrier_Lease(t *testing.T) {
+	// given
+	st := storage.NewMemoryStorage()
+	first := newRetrier(st, NewDummyQueue())
+	first.UseLease(process.NewJobLease(st.OperationLeases(), "unsuspension-retrier", "replica-1", time.Hour))
+	second := newRetrier(st, NewDummyQueue())
+	second.UseLease(process.NewJobLease(st.OperationLeases(), "unsuspension-retrier", "replica-2", time.Hour))
+
+	// then
+	assert.True(t, first.holdsLease())
+	assert.False(t, second.holdsLease())
+	assert.True(t, first.holdsLease())
+}
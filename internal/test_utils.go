@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -33,6 +34,7 @@ var envtestInstallMutex sync.Mutex
 func NewSchemeForTests() *k8sruntime.Scheme {
 	sch := k8sruntime.NewScheme()
 	corev1.AddToScheme(sch)
+	rbacv1.AddToScheme(sch)
 	apiextensionsv1.AddToScheme(sch)
 	return sch
 }
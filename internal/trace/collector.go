@@ -0,0 +1,56 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Collector stores a trace entry for every step processed by the StagedManager
+type Collector struct {
+	traces storage.OperationTraces
+	log    logrus.FieldLogger
+}
+
+func NewCollector(traces storage.OperationTraces, log logrus.FieldLogger) *Collector {
+	return &Collector{
+		traces: traces,
+		log:    log.WithField("service", "OperationTraceCollector"),
+	}
+}
+
+func (c *Collector) OnOperationStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.OperationStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.OperationStepProcessed but got %+v", ev)
+	}
+	// events published outside of steps (e.g. operation timeout) are not traced
+	if stepProcessed.StepName == "" {
+		return nil
+	}
+
+	trace := internal.OperationTrace{
+		ID:          uuid.NewString(),
+		OperationID: stepProcessed.Operation.ID,
+		InstanceID:  stepProcessed.Operation.InstanceID,
+		Stage:       stepProcessed.Stage,
+		StepName:    stepProcessed.StepName,
+		StartedAt:   stepProcessed.StartedAt,
+		FinishedAt:  stepProcessed.StartedAt.Add(stepProcessed.Duration),
+		Backoff:     stepProcessed.When,
+		State:       stepProcessed.Operation.State,
+	}
+	if stepProcessed.StepError != nil {
+		trace.Error = stepProcessed.StepError.Error()
+	}
+
+	if err := c.traces.Insert(trace); err != nil {
+		return fmt.Errorf("while inserting trace of step %s for operation %s: %w", trace.StepName, trace.OperationID, err)
+	}
+	return nil
+}
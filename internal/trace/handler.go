@@ -0,0 +1,96 @@
+package trace
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/sirupsen/logrus"
+)
+
+type StepTraceDTO struct {
+	Stage      string    `json:"stage"`
+	StepName   string    `json:"stepName"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Duration   string    `json:"duration"`
+	Backoff    string    `json:"backoff,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	State      string    `json:"state"`
+}
+
+type OperationTraceDTO struct {
+	OperationID string         `json:"operationID"`
+	InstanceID  string         `json:"instanceID"`
+	State       string         `json:"state"`
+	Steps       []StepTraceDTO `json:"steps"`
+}
+
+type Handler struct {
+	operations storage.Operations
+	traces     storage.OperationTraces
+	log        logrus.FieldLogger
+}
+
+func NewHandler(operations storage.Operations, traces storage.OperationTraces, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		operations: operations,
+		traces:     traces,
+		log:        log.WithField("service", "OperationTraceHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/operations/{operation_id}/trace", h.getTrace).Methods(http.MethodGet)
+}
+
+func (h *Handler) getTrace(w http.ResponseWriter, req *http.Request) {
+	operationID := mux.Vars(req)["operation_id"]
+
+	operation, err := h.operations.GetOperationByID(operationID)
+	if err != nil {
+		h.log.Errorf("while getting operation %s: %v", operationID, err)
+		switch {
+		case dberr.IsNotFound(err):
+			httputil.WriteErrorResponse(w, http.StatusNotFound, err)
+		default:
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting operation %s: %w", operationID, err))
+		}
+		return
+	}
+
+	traces, err := h.traces.ListByOperationID(operationID)
+	if err != nil {
+		h.log.Errorf("while getting traces of operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting traces of operation %s: %w", operationID, err))
+		return
+	}
+
+	response := OperationTraceDTO{
+		OperationID: operation.ID,
+		InstanceID:  operation.InstanceID,
+		State:       string(operation.State),
+		Steps:       make([]StepTraceDTO, 0, len(traces)),
+	}
+	for _, trace := range traces {
+		step := StepTraceDTO{
+			Stage:      trace.Stage,
+			StepName:   trace.StepName,
+			StartedAt:  trace.StartedAt,
+			FinishedAt: trace.FinishedAt,
+			Duration:   trace.FinishedAt.Sub(trace.StartedAt).String(),
+			Error:      trace.Error,
+			State:      string(trace.State),
+		}
+		if trace.Backoff > 0 {
+			step.Backoff = trace.Backoff.String()
+		}
+		response.Steps = append(response.Steps, step)
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
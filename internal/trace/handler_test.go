@@ -0,0 +1,118 @@
+package trace_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/trace"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requestPathFormat = "/operations/%s/trace"
+
+func TestOperationTrace(t *testing.T) {
+	router := mux.NewRouter()
+	db := storage.NewMemoryStorage()
+	logger := logrus.New()
+	collector := trace.NewCollector(db.OperationTraces(), logger)
+	handler := trace.NewHandler(db.Operations(), db.OperationTraces(), logger)
+	handler.AttachRoutes(router)
+
+	t.Run("should receive 404 Not Found response", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf(requestPathFormat, "op-404-not-found"), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("should return traces collected from processed steps", func(t *testing.T) {
+		// given
+		operation := fixture.FixProvisioningOperation("op-1", "inst-1")
+		operation.State = domain.InProgress
+		require.NoError(t, db.Operations().InsertOperation(operation))
+
+		startedAt := time.Now()
+		require.NoError(t, collector.OnOperationStepProcessed(context.Background(), process.OperationStepProcessed{
+			StepProcessed: process.StepProcessed{
+				StepName:  "Check_GardenerCluster",
+				Stage:     "create_runtime",
+				StartedAt: startedAt,
+				Duration:  time.Second,
+				When:      10 * time.Second,
+			},
+			Operation: operation,
+		}))
+		failedOperation := operation
+		failedOperation.State = domain.Failed
+		require.NoError(t, collector.OnOperationStepProcessed(context.Background(), process.OperationStepProcessed{
+			StepProcessed: process.StepProcessed{
+				StepName:  "Check_GardenerCluster",
+				Stage:     "create_runtime",
+				StartedAt: startedAt.Add(11 * time.Second),
+				Duration:  2 * time.Second,
+				StepError: fmt.Errorf("GardenerCluster not ready"),
+			},
+			Operation: failedOperation,
+		}))
+		// events published outside of steps are skipped
+		require.NoError(t, collector.OnOperationStepProcessed(context.Background(), process.OperationStepProcessed{
+			Operation: failedOperation,
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf(requestPathFormat, "op-1"), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+		var response trace.OperationTraceDTO
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "op-1", response.OperationID)
+		assert.Equal(t, "inst-1", response.InstanceID)
+		require.Len(t, response.Steps, 2)
+		assert.Equal(t, trace.StepTraceDTO{
+			Stage:      "create_runtime",
+			StepName:   "Check_GardenerCluster",
+			StartedAt:  response.Steps[0].StartedAt,
+			FinishedAt: response.Steps[0].FinishedAt,
+			Duration:   "1s",
+			Backoff:    "10s",
+			State:      string(domain.InProgress),
+		}, response.Steps[0])
+		assert.Equal(t, "2s", response.Steps[1].Duration)
+		assert.Empty(t, response.Steps[1].Backoff)
+		assert.Equal(t, "GardenerCluster not ready", response.Steps[1].Error)
+		assert.Equal(t, string(domain.Failed), response.Steps[1].State)
+	})
+}
+
+func TestCollector_WrongEvent(t *testing.T) {
+	// given
+	collector := trace.NewCollector(storage.NewMemoryStorage().OperationTraces(), logrus.New())
+
+	// when
+	err := collector.OnOperationStepProcessed(context.Background(), internal.Operation{})
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS operation_traces (
+    id             varchar(255) NOT NULL PRIMARY KEY,
+    operation_id   varchar(255) NOT NULL,
+    instance_id    varchar(255) NOT NULL,
+    stage          varchar(255) NOT NULL,
+    step_name      varchar(255) NOT NULL,
+    started_at     timestamp with time zone NOT NULL,
+    finished_at    timestamp with time zone NOT NULL,
+    backoff        bigint NOT NULL,
+    error          text,
+    state          varchar(32) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS operation_traces_operation_id ON operation_traces USING HASH (operation_id);
+
+COMMIT;
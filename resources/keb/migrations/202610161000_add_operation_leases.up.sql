@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS operation_leases (
+    operation_id   varchar(255) NOT NULL PRIMARY KEY,
+    owner          varchar(255) NOT NULL,
+    acquired_at    timestamp with time zone NOT NULL,
+    expires_at     timestamp with time zone NOT NULL
+);
+
+COMMIT;
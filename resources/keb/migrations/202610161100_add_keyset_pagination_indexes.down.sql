@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS instances_by_created_at_instance_id;
+DROP INDEX IF EXISTS operations_by_created_at_id;
+DROP INDEX IF EXISTS orchestrations_by_created_at_orchestration_id;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS instances_by_created_at_instance_id ON instances USING btree (created_at, instance_id);
+CREATE INDEX IF NOT EXISTS operations_by_created_at_id ON operations USING btree (created_at, id);
+CREATE INDEX IF NOT EXISTS orchestrations_by_created_at_orchestration_id ON orchestrations USING btree (created_at, orchestration_id);
+
+COMMIT;
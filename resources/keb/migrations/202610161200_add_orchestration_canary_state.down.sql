@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE orchestrations DROP COLUMN IF EXISTS canary_state;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE orchestrations ADD COLUMN IF NOT EXISTS canary_state text;
+
+COMMIT;
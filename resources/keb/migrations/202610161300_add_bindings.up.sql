@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS bindings (
+    id             varchar(255) NOT NULL,
+    instance_id    varchar(255) NOT NULL,
+    created_at     timestamp with time zone NOT NULL,
+    updated_at     timestamp with time zone NOT NULL,
+    expires_at     timestamp with time zone NOT NULL,
+    kubeconfig     text,
+    state          varchar(32) NOT NULL,
+    description    text,
+    PRIMARY KEY (instance_id, id)
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS bindings_expires_at;
+
+ALTER TABLE bindings DROP COLUMN IF EXISTS expired_at;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE bindings ADD COLUMN IF NOT EXISTS expired_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS bindings_expires_at ON bindings (expires_at) WHERE expired_at IS NULL;
+
+COMMIT;
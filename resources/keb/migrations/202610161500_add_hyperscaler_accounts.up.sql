@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS hyperscaler_accounts (
+    name             varchar(255) NOT NULL PRIMARY KEY,
+    secret_name      varchar(255) NOT NULL,
+    hyperscaler_type varchar(64) NOT NULL,
+    tenant_name      varchar(255) NOT NULL DEFAULT '',
+    shared           boolean NOT NULL DEFAULT false,
+    dirty            boolean NOT NULL DEFAULT false,
+    internal         boolean NOT NULL DEFAULT false,
+    eu_access        boolean NOT NULL DEFAULT false,
+    created_at       timestamp with time zone NOT NULL,
+    updated_at       timestamp with time zone NOT NULL,
+    version          integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS hyperscaler_accounts_type ON hyperscaler_accounts (hyperscaler_type);
+
+COMMIT;
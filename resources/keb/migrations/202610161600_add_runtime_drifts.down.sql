@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE runtime_drifts;
+
+COMMIT;
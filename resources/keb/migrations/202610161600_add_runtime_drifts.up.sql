@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS runtime_drifts (
+    instance_id varchar(255) NOT NULL PRIMARY KEY,
+    runtime_id  varchar(255) NOT NULL DEFAULT '',
+    shoot_name  varchar(255) NOT NULL DEFAULT '',
+    findings    text NOT NULL,
+    detected_at timestamp with time zone NOT NULL
+);
+
+COMMIT;
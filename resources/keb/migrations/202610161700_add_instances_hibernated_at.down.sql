@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE instances DROP COLUMN IF EXISTS hibernated_at;
+
+COMMIT;
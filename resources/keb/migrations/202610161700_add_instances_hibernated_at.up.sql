@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE instances ADD COLUMN IF NOT EXISTS hibernated_at timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE operations ADD COLUMN IF NOT EXISTS provisioner_input text;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE hyperscaler_accounts DROP COLUMN IF EXISTS manual_cleanup;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE hyperscaler_accounts ADD COLUMN IF NOT EXISTS manual_cleanup boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -16,9 +16,12 @@ export IMAGE_TAG=$1
 
 PROTOCOL=docker://
 IMAGE_NAMES=(
+  kyma-environment-binding-cleanup-job
   kyma-environment-broker
   kyma-environment-deprovision-retrigger-job
   kyma-environment-runtime-reconciler
+  kyma-environment-secret-binding-recycler-job
+  kyma-environment-secrets-reencryption-job
   kyma-environment-subaccount-cleanup-job
   kyma-environment-trial-cleanup-job
   kyma-environments-cleanup-job
@@ -25,6 +25,9 @@ protecode:
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environments-cleanup-job:${TAG}
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-runtime-reconciler:${TAG}
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-trial-cleanup-job:${TAG}
+  - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-binding-cleanup-job:${TAG}
+  - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-secret-binding-recycler-job:${TAG}
+  - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-secrets-reencryption-job:${TAG}
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-subaccount-cleanup-job:${TAG}
 whitesource:
   language: golang-mod